@@ -0,0 +1,112 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// WithAutoReload reloads the configuration every interval in the background, independently of Watch.
+// This gives eventual consistency with remote sources such as Consul without watching anything.
+// The timer starts after the first successful Load and runs until Close is called.
+// Failed reloads keep the previous configuration and are reported to the handlers registered with OnReloadError.
+func WithAutoReload(interval time.Duration) Option {
+	return func(c *Conflex) error {
+		if interval <= 0 {
+			return errors.New("auto reload interval must be positive")
+		}
+		c.autoReloadInterval = interval
+		return nil
+	}
+}
+
+// Close stops all background activity of the instance: scheduled reloads configured with WithAutoReload
+// and a watch started with StartWatch. It waits for running reloads to finish. Values remain readable
+// after Close, and Load can still be called explicitly. Calling Close more than once is a no-op.
+func (c *Conflex) Close() error {
+	c.lifecycleMu.Lock()
+	if c.closed {
+		c.lifecycleMu.Unlock()
+		return nil
+	}
+	c.closed = true
+	if c.done != nil {
+		close(c.done)
+	}
+	c.lifecycleMu.Unlock()
+
+	err := c.StopWatch(context.Background())
+	c.background.Wait()
+	return err
+}
+
+// startBackground runs fn in a goroutine that is stopped and waited for by Close.
+// The context passed to fn is cancelled when Close is called. It does nothing once the instance is closed.
+func (c *Conflex) startBackground(fn func(ctx context.Context)) {
+	c.lifecycleMu.Lock()
+	defer c.lifecycleMu.Unlock()
+
+	if c.closed {
+		return
+	}
+	if c.done == nil {
+		c.done = make(chan struct{})
+	}
+	done := c.done
+
+	c.background.Add(1)
+	go func() {
+		defer c.background.Done()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go func() {
+			select {
+			case <-done:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+
+		fn(ctx)
+	}()
+}
+
+// startAutoReload starts the auto reload timer, if configured and not yet running.
+func (c *Conflex) startAutoReload() {
+	if c.autoReloadInterval <= 0 {
+		return
+	}
+	c.autoReloadOnce.Do(func() {
+		c.startBackground(c.autoReload)
+	})
+}
+
+// autoReload reloads the configuration every auto reload interval until ctx is cancelled.
+func (c *Conflex) autoReload(ctx context.Context) {
+	ticker := time.NewTicker(c.autoReloadInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_ = c.reload(ctx)
+		}
+	}
+}
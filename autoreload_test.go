@@ -0,0 +1,106 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.companyinfo.dev/conflex/codec"
+)
+
+type AutoReloadTestSuite struct {
+	suite.Suite
+}
+
+func TestAutoReloadTestSuite(t *testing.T) {
+	suite.Run(t, new(AutoReloadTestSuite))
+}
+
+func (s *AutoReloadTestSuite) TestAutoReload() {
+	src := &mockSyncSource{conf: map[string]any{"foo": "bar"}}
+	c, err := New(WithSource(src), WithAutoReload(20*time.Millisecond))
+	s.Require().NoError(err)
+	defer func() { s.NoError(c.Close()) }()
+
+	// Nothing is reloaded before the first Load.
+	time.Sleep(60 * time.Millisecond)
+	s.Zero(c.ReloadStatus().Successes)
+
+	s.Require().NoError(c.Load(context.Background()))
+	src.set(map[string]any{"foo": "baz"}, nil)
+	s.Eventually(func() bool { return c.GetString("foo") == "baz" }, 2*time.Second, 10*time.Millisecond)
+
+	// A failing source keeps the last good configuration.
+	reloadErrs := make(chan error, 1)
+	c.OnReloadError(func(err error) {
+		select {
+		case reloadErrs <- err:
+		default:
+		}
+	})
+	src.set(nil, errors.New("source unavailable"))
+	select {
+	case err := <-reloadErrs:
+		s.ErrorContains(err, "source unavailable")
+	case <-time.After(2 * time.Second):
+		s.Fail("expected reload error")
+	}
+	s.Equal("baz", c.GetString("foo"))
+}
+
+func (s *AutoReloadTestSuite) TestClose_StopsAutoReload() {
+	src := &mockSyncSource{conf: map[string]any{"foo": "bar"}}
+	c, err := New(WithSource(src), WithAutoReload(10*time.Millisecond))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	s.NoError(c.Close())
+	s.NoError(c.Close())
+
+	attempts := c.ReloadStatus().LastAttempt
+	src.set(map[string]any{"foo": "baz"}, nil)
+	time.Sleep(50 * time.Millisecond)
+	s.Equal(attempts, c.ReloadStatus().LastAttempt)
+	s.Equal("bar", c.GetString("foo"))
+
+	// Explicit loads still work after Close, but do not restart the timer.
+	s.NoError(c.Load(context.Background()))
+	s.Equal("baz", c.GetString("foo"))
+}
+
+func (s *AutoReloadTestSuite) TestClose_StopsWatch() {
+	file := filepath.Join(s.T().TempDir(), "config.json")
+	s.Require().NoError(os.WriteFile(file, []byte(`{"foo": "bar"}`), 0o644))
+
+	c, err := New(WithFileSource(file, codec.TypeJSON))
+	s.Require().NoError(err)
+	errs, err := c.StartWatch(context.Background())
+	s.Require().NoError(err)
+
+	s.NoError(c.Close())
+	_, ok := <-errs
+	s.False(ok)
+}
+
+func (s *AutoReloadTestSuite) TestWithAutoReload_Invalid() {
+	_, err := New(WithAutoReload(0))
+	s.ErrorContains(err, "auto reload interval must be positive")
+}
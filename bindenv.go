@@ -0,0 +1,80 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"errors"
+	"os"
+	"strings"
+)
+
+// envBinding ties a configuration key to the environment variables that set it, in order of preference.
+type envBinding struct {
+	key     string
+	envVars []string
+}
+
+// BindEnv ties key to the given environment variables, regardless of any prefix convention, so that
+// BindEnv("database.primary.host", "DB_HOST") sets database.primary.host from DB_HOST. This is useful for
+// third-party images that dictate variable names.
+//
+// If several variables are given, the first one that is set is used. A bound variable that is set overrides
+// the value from every source, even if it is empty; one that is not set leaves the key as the sources define it.
+// Bindings take effect on the next Load or Reload.
+func (c *Conflex) BindEnv(key string, envVars ...string) error {
+	if c == nil {
+		return errors.New("conflex instance is nil")
+	}
+	if strings.TrimSpace(key) == "" {
+		return errors.New("key cannot be empty")
+	}
+	if len(envVars) == 0 {
+		return errors.New("at least one environment variable is required")
+	}
+	for _, name := range envVars {
+		if name == "" {
+			return errors.New("environment variable name cannot be empty")
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.envBindings = append(c.envBindings, envBinding{key: key, envVars: append([]string(nil), envVars...)})
+	return nil
+}
+
+// applyEnvBindings sets the keys bound with BindEnv from the environment. Later bindings of the same key win.
+// It returns the keys it set, mapped to the variables they were set from.
+func (c *Conflex) applyEnvBindings(values map[string]any) map[string]string {
+	c.mu.RLock()
+	bindings := c.envBindings
+	c.mu.RUnlock()
+
+	applied := make(map[string]string)
+	for _, b := range bindings {
+		for _, name := range b.envVars {
+			if value, ok := os.LookupEnv(name); ok {
+				// If a scalar is in the way of the key, it is left to binding to report.
+				key := c.normalizeKey(b.key)
+				if setValue(values, splitKey(key), value) {
+					applied[key] = name
+				}
+				break
+			}
+		}
+	}
+	return applied
+}
@@ -0,0 +1,106 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type BindEnvTestSuite struct {
+	suite.Suite
+}
+
+func TestBindEnvTestSuite(t *testing.T) {
+	suite.Run(t, new(BindEnvTestSuite))
+}
+
+func (s *BindEnvTestSuite) TestOverridesSources() {
+	s.T().Setenv("DB_HOST", "db.internal")
+	src := &mockSource{conf: map[string]any{
+		"database": map[string]any{"primary": map[string]any{"host": "localhost", "port": 5432}},
+	}}
+	c, err := New(WithSource(src))
+	s.Require().NoError(err)
+	s.Require().NoError(c.BindEnv("Database.Primary.Host", "DB_HOST"))
+	s.Require().NoError(c.BindEnv("database.primary.user", "DB_USER"))
+
+	s.Require().NoError(c.Load(context.Background()))
+	s.Equal("db.internal", c.GetString("database.primary.host"))
+	s.Equal(5432, c.GetInt("database.primary.port"))
+	s.Nil(c.Get("database.primary.user"))
+}
+
+func (s *BindEnvTestSuite) TestFirstSetVariableWins() {
+	s.T().Setenv("POSTGRES_HOST", "postgres.internal")
+	s.T().Setenv("PGHOST", "pg.internal")
+	c, err := New(WithSource(&mockSource{conf: map[string]any{}}))
+	s.Require().NoError(err)
+	s.Require().NoError(c.BindEnv("db.host", "DB_HOST", "POSTGRES_HOST", "PGHOST"))
+
+	s.Require().NoError(c.Load(context.Background()))
+	s.Equal("postgres.internal", c.GetString("db.host"))
+}
+
+func (s *BindEnvTestSuite) TestEmptyValueOverrides() {
+	s.T().Setenv("DB_PASSWORD", "")
+	c, err := New(WithSource(&mockSource{conf: map[string]any{"db": map[string]any{"password": "default"}}}))
+	s.Require().NoError(err)
+	s.Require().NoError(c.BindEnv("db.password", "DB_PASSWORD"))
+
+	s.Require().NoError(c.Load(context.Background()))
+	s.Equal("", c.Get("db.password"))
+}
+
+func (s *BindEnvTestSuite) TestTakesEffectOnReload() {
+	c, err := New(WithSource(&mockSource{conf: map[string]any{"port": 8080}}))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	s.T().Setenv("PORT", "9090")
+	s.Require().NoError(c.BindEnv("port", "PORT"))
+	s.Equal(8080, c.GetInt("port"))
+
+	s.Require().NoError(c.Load(context.Background()))
+	s.Equal(9090, c.GetInt("port"))
+}
+
+func (s *BindEnvTestSuite) TestBindsToStruct() {
+	type Config struct {
+		Port int `conflex:"port,required"`
+	}
+
+	s.T().Setenv("PORT", "9090")
+	var cfg Config
+	c, err := New(WithSource(&mockSource{conf: map[string]any{}}), WithBinding(&cfg))
+	s.Require().NoError(err)
+	s.Require().NoError(c.BindEnv("port", "PORT"))
+
+	s.Require().NoError(c.Load(context.Background()))
+	s.Equal(9090, cfg.Port)
+}
+
+func (s *BindEnvTestSuite) TestInvalidArguments() {
+	c, err := New()
+	s.Require().NoError(err)
+	s.EqualError(c.BindEnv("", "DB_HOST"), "key cannot be empty")
+	s.EqualError(c.BindEnv("db.host"), "at least one environment variable is required")
+	s.EqualError(c.BindEnv("db.host", "DB_HOST", ""), "environment variable name cannot be empty")
+
+	var nilConflex *Conflex
+	s.Error(nilConflex.BindEnv("db.host", "DB_HOST"))
+}
@@ -0,0 +1,225 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// BundleFormatVersion is the version of the bundle format produced by ExportBundle.
+const BundleFormatVersion = 1
+
+// ErrInvalidBundleSignature is returned by ImportBundle when the bundle signature does not match.
+var ErrInvalidBundleSignature = errors.New("invalid bundle signature")
+
+// Bundle is a self-contained snapshot of an effective configuration, produced by ExportBundle.
+// It implements Source, so an imported bundle can be replayed to reproduce the configuration elsewhere.
+type Bundle struct {
+	FormatVersion   int               `json:"format_version"`
+	CreatedAt       time.Time         `json:"created_at"`
+	Checksum        string            `json:"checksum"`                   // Checksum of the configuration, as returned by Conflex.Checksum
+	Sources         []string          `json:"sources"`                    // Description of the configured sources
+	Origins         map[string]string `json:"origins,omitempty"`          // Name of the source of every leaf key, as returned by Conflex.Origin
+	Secrets         []string          `json:"secrets,omitempty"`          // Keys classified as SensitivitySecret
+	SecretsIncluded bool              `json:"secrets_included,omitempty"` // Whether Config holds the values of Secrets instead of RedactedValue
+	Schema          json.RawMessage   `json:"schema,omitempty"`
+	Config          map[string]any    `json:"config"`
+}
+
+// BundleOption configures how ExportBundle exports the configuration.
+type BundleOption func(o *bundleOptions)
+
+// bundleOptions holds the settings applied by BundleOption values.
+type bundleOptions struct {
+	includeSecrets bool
+}
+
+// BundleIncludeSecrets makes ExportBundle export the values of secret keys in plaintext. The bundle is signed,
+// not encrypted, so it must then be stored and transferred like the secrets it holds.
+func BundleIncludeSecrets() BundleOption {
+	return func(o *bundleOptions) {
+		o.includeSecrets = true
+	}
+}
+
+// signedBundle is the on-disk representation of a bundle: the bundle document and its HMAC-SHA256 signature.
+type signedBundle struct {
+	Bundle    json.RawMessage `json:"bundle"`
+	Signature string          `json:"signature"`
+}
+
+// Load returns a copy of the configuration stored in the bundle.
+func (b *Bundle) Load(_ context.Context) (map[string]any, error) {
+	return copyMap(b.Config), nil
+}
+
+// ExportBundle exports the effective configuration, a description of the configured sources, the origin of every
+// key, the checksum of the configuration, and the JSON Schema (if one was provided as bytes) as a single document
+// signed with HMAC-SHA256 using key. The result can be imported with ImportBundle to reproduce the configuration,
+// e.g. on a developer machine.
+//
+// The values of keys classified as SensitivitySecret are replaced with RedactedValue, unless BundleIncludeSecrets
+// is given; the keys are listed in Bundle.Secrets either way. The checksum is the one Checksum returns, so it can be
+// compared with the checksum reported by running instances.
+func (c *Conflex) ExportBundle(key []byte, options ...BundleOption) ([]byte, error) {
+	if len(key) == 0 {
+		return nil, errors.New("bundle signing key cannot be empty")
+	}
+	var o bundleOptions
+	for _, option := range options {
+		option(&o)
+	}
+
+	c.mu.RLock()
+	version := c.version
+	values := map[string]any{}
+	if c.values != nil {
+		values = *c.values
+	}
+	origins := make(map[string]string, len(c.keyOrigins))
+	for k, origin := range c.keyOrigins {
+		origins[k] = origin
+	}
+	c.mu.RUnlock()
+
+	checksum, err := c.checksum(version, values, checksumOptions{})
+	if err != nil {
+		return nil, NewConfigError("bundle", "checksum", err)
+	}
+
+	// Loads replace the values map instead of modifying it, so it can be read without holding the lock.
+	var secrets []string
+	redacted := redactValues(values, func(key string) bool {
+		if c.Sensitivity(key) != SensitivitySecret {
+			return false
+		}
+		secrets = append(secrets, key)
+		return true
+	})
+	sort.Strings(secrets)
+	if !o.includeSecrets {
+		values = redacted
+	} else {
+		values = copyMap(values)
+	}
+
+	sources := make([]string, 0, len(c.sources))
+	for _, src := range c.sources {
+		sources = append(sources, describeSource(src))
+	}
+
+	bundle := Bundle{
+		FormatVersion:   BundleFormatVersion,
+		CreatedAt:       time.Now().UTC(),
+		Checksum:        checksum,
+		Sources:         sources,
+		Origins:         origins,
+		Secrets:         secrets,
+		SecretsIncluded: o.includeSecrets,
+		Config:          values,
+	}
+	if c.jsonSchema != "" {
+		bundle.Schema = json.RawMessage(c.jsonSchema)
+	}
+
+	document, err := json.Marshal(bundle)
+	if err != nil {
+		return nil, NewConfigError("bundle", "encode", err)
+	}
+
+	return json.MarshalIndent(signedBundle{
+		Bundle:    document,
+		Signature: hex.EncodeToString(signBundle(key, document)),
+	}, "", "  ")
+}
+
+// ImportBundle verifies the signature of a bundle produced by ExportBundle and decodes it.
+// Numbers are decoded as json.Number to preserve their exact representation.
+// The returned bundle can be passed to WithSource to replay the configuration.
+func ImportBundle(data, key []byte) (*Bundle, error) {
+	if len(key) == 0 {
+		return nil, errors.New("bundle signing key cannot be empty")
+	}
+
+	var signed signedBundle
+	if err := json.Unmarshal(data, &signed); err != nil {
+		return nil, NewConfigError("bundle", "decode", err)
+	}
+
+	// The signature covers the compact encoding of the bundle document, so re-indenting the file does not invalidate it.
+	var document bytes.Buffer
+	if err := json.Compact(&document, signed.Bundle); err != nil {
+		return nil, NewConfigError("bundle", "decode", err)
+	}
+
+	signature, err := hex.DecodeString(signed.Signature)
+	if err != nil || !hmac.Equal(signature, signBundle(key, document.Bytes())) {
+		return nil, NewConfigError("bundle", "verify", ErrInvalidBundleSignature)
+	}
+
+	decoder := json.NewDecoder(&document)
+	decoder.UseNumber()
+
+	var bundle Bundle
+	if err := decoder.Decode(&bundle); err != nil {
+		return nil, NewConfigError("bundle", "decode", err)
+	}
+	if bundle.FormatVersion != BundleFormatVersion {
+		return nil, NewConfigError("bundle", "decode",
+			fmt.Errorf("unsupported bundle format version %d", bundle.FormatVersion))
+	}
+	if bundle.Config == nil {
+		bundle.Config = map[string]any{}
+	}
+
+	// The checksum covers the configuration with the secrets redacted, whether or not the bundle includes them.
+	secrets := make(map[string]bool, len(bundle.Secrets))
+	for _, key := range bundle.Secrets {
+		secrets[key] = true
+	}
+	checksum, err := valuesChecksum(redactValues(bundle.Config, func(key string) bool { return secrets[key] }))
+	if err != nil {
+		return nil, NewConfigError("bundle", "checksum", err)
+	}
+	if checksum != bundle.Checksum {
+		return nil, NewConfigError("bundle", "verify", errors.New("bundle checksum mismatch"))
+	}
+
+	return &bundle, nil
+}
+
+// signBundle returns the HMAC-SHA256 signature of the bundle document.
+func signBundle(key, document []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(document)
+	return mac.Sum(nil)
+}
+
+// describeSource returns a short human-readable description of a source.
+func describeSource(src Source) string {
+	if ps, ok := src.(pathSource); ok && ps.Path() != "" {
+		return fmt.Sprintf("%T(%s)", src, ps.Path())
+	}
+	return fmt.Sprintf("%T", src)
+}
@@ -0,0 +1,168 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.companyinfo.dev/conflex/codec"
+)
+
+type BundleTestSuite struct {
+	suite.Suite
+	key []byte
+}
+
+func TestBundleTestSuite(t *testing.T) {
+	suite.Run(t, new(BundleTestSuite))
+}
+
+func (s *BundleTestSuite) SetupTest() {
+	s.key = []byte("bundle-signing-key")
+}
+
+func (s *BundleTestSuite) newLoaded() *Conflex {
+	c, err := New(
+		WithContentSource([]byte(`{"server": {"host": "localhost", "port": 8080}, "ratio": 0.5}`), codec.TypeJSON),
+		WithJSONSchema([]byte(testSchemaServer)),
+	)
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+	return c
+}
+
+const testSchemaServer = `{"type":"object","properties":{"server":{"type":"object"}},"required":["server"]}`
+
+func (s *BundleTestSuite) TestExportImport_RoundTrip() {
+	data, err := s.newLoaded().ExportBundle(s.key)
+	s.Require().NoError(err)
+
+	bundle, err := ImportBundle(data, s.key)
+	s.Require().NoError(err)
+	s.Equal(BundleFormatVersion, bundle.FormatVersion)
+	s.Equal([]string{"*source.File"}, bundle.Sources)
+	s.JSONEq(testSchemaServer, string(bundle.Schema))
+
+	replayed, err := New(WithSource(bundle), WithJSONSchema(bundle.Schema))
+	s.Require().NoError(err)
+	s.Require().NoError(replayed.Load(context.Background()))
+	s.Equal("localhost", replayed.GetString("server.host"))
+	s.Equal(8080, replayed.GetInt("server.port"))
+	s.Equal(0.5, replayed.GetFloat64("ratio"))
+}
+
+func (s *BundleTestSuite) TestImport_WrongKey() {
+	data, err := s.newLoaded().ExportBundle(s.key)
+	s.Require().NoError(err)
+
+	_, err = ImportBundle(data, []byte("other-key"))
+	s.Error(err)
+	s.True(errors.Is(err, ErrInvalidBundleSignature))
+}
+
+func (s *BundleTestSuite) TestImport_Tampered() {
+	data, err := s.newLoaded().ExportBundle(s.key)
+	s.Require().NoError(err)
+
+	tampered := bytes.Replace(data, []byte("localhost"), []byte("evilhost"), 1)
+	_, err = ImportBundle(tampered, s.key)
+	s.True(errors.Is(err, ErrInvalidBundleSignature))
+}
+
+func (s *BundleTestSuite) TestImport_Malformed() {
+	_, err := ImportBundle([]byte(`{`), s.key)
+	s.Error(err)
+}
+
+func (s *BundleTestSuite) TestEmptyKey() {
+	_, err := s.newLoaded().ExportBundle(nil)
+	s.Error(err)
+	_, err = ImportBundle([]byte(`{}`), nil)
+	s.Error(err)
+}
+
+func (s *BundleTestSuite) TestBundleLoad_ReturnsCopy() {
+	bundle := &Bundle{Config: map[string]any{"nested": map[string]any{"foo": "bar"}}}
+	conf, err := bundle.Load(context.Background())
+	s.Require().NoError(err)
+	conf["nested"].(map[string]any)["foo"] = "mutated"
+	s.Equal("bar", bundle.Config["nested"].(map[string]any)["foo"])
+}
+
+func (s *BundleTestSuite) TestExport_Origins() {
+	c, err := New(
+		WithNamedSource("defaults", &mockSource{conf: map[string]any{"server": map[string]any{"host": "localhost", "port": 8080}}}),
+		WithNamedSource("override", &mockSource{conf: map[string]any{"server": map[string]any{"port": 9090}}}),
+	)
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	data, err := c.ExportBundle(s.key)
+	s.Require().NoError(err)
+	bundle, err := ImportBundle(data, s.key)
+	s.Require().NoError(err)
+	s.Equal(map[string]string{"server.host": "defaults", "server.port": "override"}, bundle.Origins)
+}
+
+func (s *BundleTestSuite) TestExport_RedactsSecrets() {
+	c, err := New(
+		WithSource(&mockSource{conf: map[string]any{"db": map[string]any{"host": "db.internal", "password": "hunter2"}}}),
+		WithRedactedKeys("db.password"),
+	)
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	data, err := c.ExportBundle(s.key)
+	s.Require().NoError(err)
+	s.NotContains(string(data), "hunter2")
+
+	bundle, err := ImportBundle(data, s.key)
+	s.Require().NoError(err)
+	s.Equal(RedactedValue, bundle.Config["db"].(map[string]any)["password"])
+	s.Equal("db.internal", bundle.Config["db"].(map[string]any)["host"])
+	s.Equal([]string{"db.password"}, bundle.Secrets)
+	s.False(bundle.SecretsIncluded)
+
+	data, err = c.ExportBundle(s.key, BundleIncludeSecrets())
+	s.Require().NoError(err)
+	bundle, err = ImportBundle(data, s.key)
+	s.Require().NoError(err)
+	s.Equal("hunter2", bundle.Config["db"].(map[string]any)["password"])
+	s.Equal([]string{"db.password"}, bundle.Secrets)
+	s.True(bundle.SecretsIncluded)
+}
+
+func (s *BundleTestSuite) TestExport_Checksum() {
+	c, err := New(
+		WithSource(&mockSource{conf: map[string]any{"db": map[string]any{"port": 5432, "password": "hunter2"}}}),
+		WithRedactedKeys("db.password"),
+	)
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+	checksum, err := c.Checksum()
+	s.Require().NoError(err)
+
+	for _, options := range [][]BundleOption{nil, {BundleIncludeSecrets()}} {
+		data, err := c.ExportBundle(s.key, options...)
+		s.Require().NoError(err)
+		bundle, err := ImportBundle(data, s.key)
+		s.Require().NoError(err)
+		s.Equal(checksum, bundle.Checksum, "the bundle checksum is the one running instances report")
+	}
+}
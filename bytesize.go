@@ -0,0 +1,114 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/spf13/cast"
+)
+
+// ByteSize is a number of bytes. Struct fields of this type accept human-readable sizes such as "512KB" or
+// "10MiB" as well as plain numbers of bytes; see ParseByteSize for the accepted units.
+type ByteSize int64
+
+var byteSizeType = reflect.TypeOf(ByteSize(0))
+
+// byteSizeUnits maps the accepted unit suffixes, in lower case, to their multipliers. Units with an "i" are
+// powers of 1024 (IEC), units without are powers of 1000 (SI).
+var byteSizeUnits = map[string]float64{
+	"":    1,
+	"b":   1,
+	"kb":  1e3,
+	"mb":  1e6,
+	"gb":  1e9,
+	"tb":  1e12,
+	"pb":  1e15,
+	"ki":  1 << 10,
+	"kib": 1 << 10,
+	"mi":  1 << 20,
+	"mib": 1 << 20,
+	"gi":  1 << 30,
+	"gib": 1 << 30,
+	"ti":  1 << 40,
+	"tib": 1 << 40,
+	"pi":  1 << 50,
+	"pib": 1 << 50,
+}
+
+// ParseByteSize parses a human-readable size such as "512KB", "10MiB" or "1.5 GB" into a number of bytes.
+// The number may be fractional and may be separated from the unit by spaces. Units are case-insensitive:
+// B, KB, MB, GB, TB and PB are powers of 1000, and KiB, MiB, GiB, TiB and PiB (or Ki, Mi, Gi, Ti and Pi)
+// are powers of 1024. A number without a unit is a number of bytes. Negative sizes are rejected.
+func ParseByteSize(s string) (int64, error) {
+	trimmed := strings.TrimSpace(s)
+	i := strings.IndexFunc(trimmed, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if i < 0 {
+		i = len(trimmed)
+	}
+	number, unit := trimmed[:i], strings.ToLower(strings.TrimSpace(trimmed[i:]))
+
+	value, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+	multiplier, ok := byteSizeUnits[unit]
+	if !ok {
+		return 0, fmt.Errorf("invalid size %q: unknown unit %q", s, strings.TrimSpace(trimmed[i:]))
+	}
+
+	bytes := math.Round(value * multiplier)
+	if bytes >= math.MaxInt64 {
+		return 0, fmt.Errorf("invalid size %q: out of range", s)
+	}
+	return int64(bytes), nil
+}
+
+// toByteSizeE converts v to a number of bytes. Strings are parsed with ParseByteSize; numbers are taken
+// to be bytes already.
+func toByteSizeE(v any) (int64, error) {
+	if s, ok := v.(string); ok {
+		return ParseByteSize(s)
+	}
+	n, err := cast.ToInt64E(v)
+	if err != nil {
+		return 0, fmt.Errorf("unable to cast %#v of type %T to a byte size", v, v)
+	}
+	if n < 0 {
+		return 0, fmt.Errorf("invalid size %d: must not be negative", n)
+	}
+	return n, nil
+}
+
+// byteSizeHookFunc returns a decode hook that converts human-readable sizes to ByteSize.
+func byteSizeHookFunc() mapstructure.DecodeHookFuncType {
+	return func(_ reflect.Type, to reflect.Type, data any) (any, error) {
+		if to != byteSizeType {
+			return data, nil
+		}
+		n, err := toByteSizeE(data)
+		if err != nil {
+			return nil, err
+		}
+		return ByteSize(n), nil
+	}
+}
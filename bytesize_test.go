@@ -0,0 +1,120 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ByteSizeTestSuite struct {
+	suite.Suite
+}
+
+func TestByteSizeTestSuite(t *testing.T) {
+	suite.Run(t, new(ByteSizeTestSuite))
+}
+
+func (s *ByteSizeTestSuite) TestParseByteSize() {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"0", 0},
+		{"1024", 1024},
+		{"512B", 512},
+		{"512KB", 512_000},
+		{"512kb", 512_000},
+		{"10MiB", 10 << 20},
+		{"10Mi", 10 << 20},
+		{"1.5 GB", 1_500_000_000},
+		{"1.5GiB", 3 << 29},
+		{" 2TB ", 2_000_000_000_000},
+		{"1PiB", 1 << 50},
+	}
+	for _, tt := range tests {
+		s.Run(tt.input, func() {
+			n, err := ParseByteSize(tt.input)
+			s.Require().NoError(err)
+			s.Equal(tt.expected, n)
+		})
+	}
+}
+
+func (s *ByteSizeTestSuite) TestParseByteSizeErrors() {
+	for _, input := range []string{"", "MB", "-5KB", "10XB", "1.2.3MB", "10000PB"} {
+		s.Run(input, func() {
+			_, err := ParseByteSize(input)
+			s.Error(err)
+		})
+	}
+
+	_, err := ParseByteSize("10XB")
+	s.EqualError(err, `invalid size "10XB": unknown unit "XB"`)
+}
+
+func (s *ByteSizeTestSuite) TestGetSizeInBytes() {
+	src := &mockSource{conf: map[string]any{
+		"cache":  map[string]any{"max": "64MiB", "buffer": 4096, "invalid": "lots", "negative": -1},
+		"shards": 3,
+	}}
+	c, err := New(WithSource(src))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	s.Equal(int64(64<<20), c.GetSizeInBytes("cache.max"))
+	s.Equal(int64(4096), c.GetSizeInBytes("cache.buffer"))
+	s.Zero(c.GetSizeInBytes("cache.invalid"))
+	s.Zero(c.GetSizeInBytes("missing"))
+	s.Equal(int64(64<<20), c.Sub("cache").GetSizeInBytes("max"))
+
+	_, err = c.GetSizeInBytesE("missing")
+	s.EqualError(err, `key "missing" not found`)
+	_, err = c.GetSizeInBytesE("cache.invalid")
+	s.Error(err)
+	_, err = c.GetSizeInBytesE("cache.negative")
+	s.Error(err)
+}
+
+func (s *ByteSizeTestSuite) TestBinding() {
+	type Config struct {
+		Max     ByteSize `conflex:"max"`
+		Buffer  ByteSize `conflex:"buffer"`
+		Default ByteSize `conflex:"default,default=1KiB"`
+	}
+
+	src := &mockSource{conf: map[string]any{"max": "10MiB", "buffer": 4096}}
+	var cfg Config
+	c, err := New(WithSource(src), WithBinding(&cfg))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	s.Equal(ByteSize(10<<20), cfg.Max)
+	s.Equal(ByteSize(4096), cfg.Buffer)
+	s.Equal(ByteSize(1024), cfg.Default)
+}
+
+func (s *ByteSizeTestSuite) TestBindingRejectsInvalidSize() {
+	type Config struct {
+		Max ByteSize `conflex:"max"`
+	}
+
+	src := &mockSource{conf: map[string]any{"max": "lots"}}
+	c, err := New(WithSource(src), WithBinding(&Config{}))
+	s.Require().NoError(err)
+	s.ErrorContains(c.Load(context.Background()), `invalid size "lots"`)
+}
@@ -87,3 +87,50 @@ func TestNormalizeMapKeys(t *testing.T) {
 
 	assert.Equal(t, expected, normalized)
 }
+
+func TestCaseSensitiveKeys(t *testing.T) {
+	config := map[string]any{
+		"annotations": map[string]any{
+			"app.kubernetes.io/Name": "api",
+			"app.kubernetes.io/name": "web",
+		},
+		"Server": map[string]any{"Port": 8080},
+	}
+
+	type Binding struct {
+		Server struct {
+			Port int    `conflex:"Port,required"`
+			Host string `conflex:"Host,default=localhost"`
+		} `conflex:"Server"`
+	}
+
+	var binding Binding
+	cfg, err := New(WithSource(&mockSource{conf: config}), WithCaseSensitiveKeys(), WithBinding(&binding))
+	require.NoError(t, err)
+	require.NoError(t, cfg.Load(context.Background()))
+
+	annotations := cfg.GetStringMap("annotations")
+	assert.Equal(t, "api", annotations["app.kubernetes.io/Name"])
+	assert.Equal(t, "web", annotations["app.kubernetes.io/name"])
+
+	assert.Equal(t, 8080, cfg.GetInt("Server.Port"))
+	assert.Nil(t, cfg.Get("server.port"))
+	assert.Equal(t, "localhost", cfg.GetString("Server.Host"))
+	assert.Equal(t, 8080, binding.Server.Port)
+	assert.Equal(t, "localhost", binding.Server.Host)
+
+	// The source's map is copied, not normalized in place or shared.
+	assert.Equal(t, map[string]any{"Port": 8080}, config["Server"])
+}
+
+func TestCaseSensitiveKeysRequired(t *testing.T) {
+	type Binding struct {
+		Port int `conflex:"Port,required"`
+	}
+
+	cfg, err := New(WithSource(&mockSource{conf: map[string]any{"port": 8080}}), WithCaseSensitiveKeys(), WithBinding(&Binding{}))
+	require.NoError(t, err)
+	err = cfg.Load(context.Background())
+	require.ErrorIs(t, err, ErrRequiredKeyMissing)
+	assert.ErrorContains(t, err, "Port")
+}
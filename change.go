@@ -0,0 +1,404 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// OnChange registers a handler that is called after a successful Load whenever the merged configuration differs
+// from the previous one. The handler receives copies of the previous and the new configuration, so it may
+// inspect or modify them freely. Handlers are called synchronously, in registration order, from the goroutine
+// that performed the Load.
+func (c *Conflex) OnChange(fn func(old, new map[string]any)) {
+	if fn == nil {
+		return
+	}
+	c.onChange(fn)
+}
+
+// changeHandler is a handler registered with OnChange. Handlers are compared by pointer, so the same function
+// can be registered several times and removed once.
+type changeHandler struct {
+	fn func(old, new map[string]any)
+}
+
+// onChange registers fn like OnChange and returns a function that removes it again; it is safe to call more
+// than once.
+func (c *Conflex) onChange(fn func(old, new map[string]any)) func() {
+	handler := &changeHandler{fn: fn}
+
+	c.mu.Lock()
+	c.changeHandlers = append(c.changeHandlers, handler)
+	c.mu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			for i, h := range c.changeHandlers {
+				if h == handler {
+					c.changeHandlers = append(c.changeHandlers[:i:i], c.changeHandlers[i+1:]...)
+					break
+				}
+			}
+		})
+	}
+}
+
+// ChangeType describes how a configuration key changed.
+type ChangeType int
+
+const (
+	// ChangeAdded means the key did not exist in the previous configuration.
+	ChangeAdded ChangeType = iota + 1
+	// ChangeRemoved means the key no longer exists in the new configuration.
+	ChangeRemoved
+	// ChangeModified means the key exists in both configurations with different values.
+	ChangeModified
+)
+
+// String returns the name of the change type.
+func (t ChangeType) String() string {
+	switch t {
+	case ChangeAdded:
+		return "added"
+	case ChangeRemoved:
+		return "removed"
+	case ChangeModified:
+		return "modified"
+	default:
+		return fmt.Sprintf("ChangeType(%d)", int(t))
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler, so change types are written by name in JSON audit logs.
+func (t ChangeType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// ChangeEvent describes a change to a single configuration key.
+// Key is the dot-separated path of a leaf value; Old is nil if the key was added and New is nil if it was removed.
+type ChangeEvent struct {
+	Key  string     `json:"key"`
+	Type ChangeType `json:"type"`
+	Old  any        `json:"old,omitempty"`
+	New  any        `json:"new,omitempty"`
+}
+
+// OnDiff registers a handler that is called after a successful Load whenever the merged configuration differs
+// from the previous one. The handler receives the changes as computed by Diff, with secret values redacted,
+// which makes it suitable for audit logging. Handlers are called synchronously, after the OnChange handlers.
+func (c *Conflex) OnDiff(fn func(changes []ChangeEvent)) {
+	if fn == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.diffHandlers = append(c.diffHandlers, fn)
+}
+
+// Diff returns the changes between two configurations, one event per added, removed, or modified leaf key,
+// sorted by key. Values of keys classified as SensitivitySecret are replaced by RedactedValue.
+func (c *Conflex) Diff(oldValues, newValues map[string]any) []ChangeEvent {
+	return c.redactChanges(changeEvents(oldValues, newValues))
+}
+
+// redactChanges replaces the values of secret keys in events by RedactedValue, in place.
+func (c *Conflex) redactChanges(events []ChangeEvent) []ChangeEvent {
+	for i, event := range events {
+		if c.Sensitivity(event.Key) != SensitivitySecret {
+			continue
+		}
+		if event.Old != nil {
+			events[i].Old = RedactedValue
+		}
+		if event.New != nil {
+			events[i].New = RedactedValue
+		}
+	}
+	return events
+}
+
+// subscription delivers change events under a key prefix to a channel.
+// A slow subscriber never blocks Load: events it has not received yet are coalesced per key, so the queue holds
+// at most one event per key under the prefix.
+type subscription struct {
+	prefix string
+	redact func(events []ChangeEvent) []ChangeEvent
+	out    chan ChangeEvent
+	notify chan struct{}
+	done   chan struct{}
+	mu     sync.Mutex
+	queue  []ChangeEvent
+	queued map[string]int // Position of the pending event of every key, counting the events already delivered
+	sent   int            // Number of events taken from the front of queue since it was last compacted
+}
+
+// Subscribe returns a channel that receives an event for every changed key under keyPrefix after each successful
+// Load, so components can react to changes in their own subtree only. An empty prefix subscribes to all keys.
+// Values of keys classified as SensitivitySecret are replaced by RedactedValue, as in Diff.
+//
+// Loads never wait for the subscriber. While it has not received the event of a key, later changes of the key are
+// coalesced into that event: it reports the change from the oldest value the subscriber has not seen to the newest
+// one, and is dropped if the key is back at that value. The subscriber therefore always ends up with the current
+// value of every key, but may skip intermediate values.
+//
+// The returned cancel function stops the subscription and closes the channel; it is safe to call more than once.
+func (c *Conflex) Subscribe(keyPrefix string) (<-chan ChangeEvent, func()) {
+	sub := &subscription{
+		prefix: c.normalizeKey(strings.TrimSuffix(keyPrefix, ".")),
+		redact: c.redactChanges,
+		out:    make(chan ChangeEvent),
+		notify: make(chan struct{}, 1),
+		done:   make(chan struct{}),
+		queued: make(map[string]int),
+	}
+
+	c.mu.Lock()
+	c.subscriptions = append(c.subscriptions, sub)
+	c.mu.Unlock()
+
+	go sub.run()
+
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			c.mu.Lock()
+			for i, s := range c.subscriptions {
+				if s == sub {
+					c.subscriptions = append(c.subscriptions[:i:i], c.subscriptions[i+1:]...)
+					break
+				}
+			}
+			c.mu.Unlock()
+			close(sub.done)
+		})
+	}
+
+	return sub.out, cancel
+}
+
+// publish queues the events matching the subscription prefix, coalescing them with pending events of the same key.
+func (s *subscription) publish(events []ChangeEvent) {
+	s.mu.Lock()
+	dropped := false
+	for _, event := range events {
+		if s.prefix != "" && !keyHasPrefix(event.Key, s.prefix) {
+			continue
+		}
+		i, ok := s.queued[event.Key]
+		if !ok {
+			s.queued[event.Key] = s.sent + len(s.queue)
+			s.queue = append(s.queue, event)
+			continue
+		}
+		i -= s.sent
+		merged, keep := coalesceChanges(s.queue[i], event)
+		s.queue[i] = merged
+		if !keep {
+			s.queue[i].Key = ""
+			delete(s.queued, event.Key)
+			dropped = true
+		}
+	}
+	if dropped {
+		s.compact()
+	}
+	queued := len(s.queue) > 0
+	s.mu.Unlock()
+
+	if queued {
+		select {
+		case s.notify <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// compact removes the dropped events, marked by an empty key, from the queue. It must be called with mu held.
+func (s *subscription) compact() {
+	queue := s.queue[:0]
+	for _, event := range s.queue {
+		if event.Key == "" {
+			continue
+		}
+		s.queued[event.Key] = len(queue)
+		queue = append(queue, event)
+	}
+	s.queue = queue
+	s.sent = 0
+}
+
+// coalesceChanges merges two successive changes of a key into one, reporting false if the key ends up unchanged.
+func coalesceChanges(first, second ChangeEvent) (ChangeEvent, bool) {
+	hadOld := first.Type != ChangeAdded
+	hasNew := second.Type != ChangeRemoved
+	merged := ChangeEvent{Key: first.Key, Old: first.Old, New: second.New}
+	switch {
+	case !hadOld && !hasNew:
+		return merged, false
+	case !hadOld:
+		merged.Type = ChangeAdded
+	case !hasNew:
+		merged.Type = ChangeRemoved
+	default:
+		merged.Type = ChangeModified
+		if reflect.DeepEqual(merged.Old, merged.New) {
+			return merged, false
+		}
+	}
+	return merged, true
+}
+
+// run forwards queued events to the subscriber until the subscription is cancelled.
+func (s *subscription) run() {
+	defer close(s.out)
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-s.notify:
+		}
+
+		for {
+			s.mu.Lock()
+			if len(s.queue) == 0 {
+				s.mu.Unlock()
+				break
+			}
+			event := s.queue[0]
+			s.queue = s.queue[1:]
+			s.sent++
+			delete(s.queued, event.Key)
+			if len(s.queue) == 0 {
+				s.queue, s.sent = nil, 0
+			}
+			s.mu.Unlock()
+
+			select {
+			case s.out <- s.redact([]ChangeEvent{event})[0]:
+			case <-s.done:
+				return
+			}
+		}
+	}
+}
+
+// notifyChange calls the registered change handlers and publishes change events to subscribers
+// if the configuration changed.
+func (c *Conflex) notifyChange(oldValues, newValues map[string]any) {
+	c.mu.RLock()
+	handlers := c.changeHandlers
+	diffHandlers := c.diffHandlers
+	subscriptions := c.subscriptions
+	c.mu.RUnlock()
+
+	if len(handlers) == 0 && len(diffHandlers) == 0 && len(subscriptions) == 0 {
+		return
+	}
+
+	if oldValues == nil {
+		oldValues = map[string]any{}
+	}
+	if reflect.DeepEqual(oldValues, newValues) {
+		return
+	}
+
+	for _, h := range handlers {
+		h.fn(copyMap(oldValues), copyMap(newValues))
+	}
+
+	if len(diffHandlers) == 0 && len(subscriptions) == 0 {
+		return
+	}
+
+	events := changeEvents(oldValues, newValues)
+	for _, sub := range subscriptions {
+		sub.publish(events)
+	}
+	for _, fn := range diffHandlers {
+		redacted := make([]ChangeEvent, len(events))
+		copy(redacted, events)
+		fn(c.redactChanges(redacted))
+	}
+}
+
+// changeEvents returns an event for every leaf value that was added, removed, or modified, sorted by key.
+func changeEvents(oldValues, newValues map[string]any) []ChangeEvent {
+	oldLeaves := leafValues(oldValues)
+	newLeaves := leafValues(newValues)
+
+	keys := changedLeafKeys(oldLeaves, newLeaves)
+	events := make([]ChangeEvent, 0, len(keys))
+	for _, key := range keys {
+		oldValue, inOld := oldLeaves[key]
+		newValue, inNew := newLeaves[key]
+		changeType := ChangeModified
+		switch {
+		case !inOld:
+			changeType = ChangeAdded
+		case !inNew:
+			changeType = ChangeRemoved
+		}
+		events = append(events, ChangeEvent{
+			Key:  key,
+			Type: changeType,
+			Old:  copyValue(oldValue),
+			New:  copyValue(newValue),
+		})
+	}
+	return events
+}
+
+// changedKeys returns the sorted dot-separated paths of all leaf values that were added, removed, or modified
+// between oldValues and newValues.
+func changedKeys(oldValues, newValues map[string]any) []string {
+	return changedLeafKeys(leafValues(oldValues), leafValues(newValues))
+}
+
+// changedLeafKeys returns the sorted keys that differ between two flattened leaf maps.
+func changedLeafKeys(oldLeaves, newLeaves map[string]any) []string {
+	var keys []string
+	for key, oldValue := range oldLeaves {
+		if newValue, ok := newLeaves[key]; !ok || !reflect.DeepEqual(oldValue, newValue) {
+			keys = append(keys, key)
+		}
+	}
+	for key := range newLeaves {
+		if _, ok := oldLeaves[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// leafValues flattens m into a map from dot-separated leaf paths to values.
+func leafValues(m map[string]any) map[string]any {
+	leaves := make(map[string]any)
+	walkLeaves(m, func(path []string, value any) {
+		leaves[joinKey(path)] = value
+	})
+	return leaves
+}
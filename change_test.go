@@ -0,0 +1,274 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ChangeTestSuite struct {
+	suite.Suite
+}
+
+func TestChangeTestSuite(t *testing.T) {
+	suite.Run(t, new(ChangeTestSuite))
+}
+
+func (s *ChangeTestSuite) TestOnChange_CalledOnlyWhenValuesDiffer() {
+	src := &mockSource{conf: map[string]any{"foo": "bar"}}
+	c, err := New(WithSource(src))
+	s.Require().NoError(err)
+
+	var calls []map[string]any
+	c.OnChange(func(old, _ map[string]any) {
+		calls = append(calls, old)
+	})
+
+	s.Require().NoError(c.Load(context.Background()))
+	s.Len(calls, 1)
+	s.Empty(calls[0])
+
+	s.Require().NoError(c.Load(context.Background()))
+	s.Len(calls, 1)
+
+	src.conf = map[string]any{"foo": "baz"}
+	s.Require().NoError(c.Load(context.Background()))
+	s.Len(calls, 2)
+	s.Equal("bar", calls[1]["foo"])
+}
+
+func (s *ChangeTestSuite) TestOnChange_NotCalledOnFailedLoad() {
+	src := &mockSource{conf: map[string]any{"foo": "bar"}}
+	c, err := New(WithSource(src))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	called := false
+	c.OnChange(func(_, _ map[string]any) {
+		called = true
+	})
+
+	src.err = errors.New("fail")
+	s.Error(c.Load(context.Background()))
+	s.False(called)
+}
+
+func (s *ChangeTestSuite) TestOnChange_HandlersReceiveCopies() {
+	src := &mockSource{conf: map[string]any{"nested": map[string]any{"foo": "bar"}}}
+	c, err := New(WithSource(src))
+	s.Require().NoError(err)
+
+	c.OnChange(func(_, newValues map[string]any) {
+		newValues["nested"].(map[string]any)["foo"] = "mutated"
+	})
+	c.OnChange(nil)
+
+	s.Require().NoError(c.Load(context.Background()))
+	s.Equal("bar", c.GetString("nested.foo"))
+}
+
+func (s *ChangeTestSuite) receive(ch <-chan ChangeEvent) ChangeEvent {
+	select {
+	case event := <-ch:
+		return event
+	case <-time.After(time.Second):
+		s.FailNow("expected change event")
+		return ChangeEvent{}
+	}
+}
+
+func (s *ChangeTestSuite) TestSubscribe_FiltersByPrefix() {
+	src := &mockSource{conf: map[string]any{
+		"logging": map[string]any{"level": "info"},
+		"server":  map[string]any{"port": 8080},
+	}}
+	c, err := New(WithSource(src))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	events, cancel := c.Subscribe("Logging.")
+	defer cancel()
+
+	src.conf = map[string]any{
+		"logging": map[string]any{"level": "debug", "format": "json"},
+		"server":  map[string]any{"port": 9090},
+	}
+	s.Require().NoError(c.Load(context.Background()))
+
+	s.Equal(ChangeEvent{Key: "logging.format", Type: ChangeAdded, Old: nil, New: "json"}, s.receive(events))
+	s.Equal(ChangeEvent{Key: "logging.level", Type: ChangeModified, Old: "info", New: "debug"}, s.receive(events))
+
+	select {
+	case event := <-events:
+		s.Failf("unexpected event", "%+v", event)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func (s *ChangeTestSuite) TestSubscribe_AllKeysAndRemovals() {
+	src := &mockSource{conf: map[string]any{"a": 1, "b": 2}}
+	c, err := New(WithSource(src))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	events, cancel := c.Subscribe("")
+	defer cancel()
+
+	src.conf = map[string]any{"a": 1}
+	s.Require().NoError(c.Load(context.Background()))
+	s.Equal(ChangeEvent{Key: "b", Type: ChangeRemoved, Old: 2, New: nil}, s.receive(events))
+}
+
+func (s *ChangeTestSuite) TestSubscribe_SlowSubscriberDoesNotBlockLoad() {
+	src := &mockSource{conf: map[string]any{"counter": 0}}
+	c, err := New(WithSource(src))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	events, cancel := c.Subscribe("counter")
+	defer cancel()
+
+	for i := 1; i <= 100; i++ {
+		src.conf = map[string]any{"counter": i}
+		s.Require().NoError(c.Load(context.Background()))
+	}
+
+	// Changes the subscriber has not received yet are coalesced, so it ends up with the newest value.
+	var last ChangeEvent
+	for last.New != 100 {
+		last = s.receive(events)
+		s.Equal(ChangeModified, last.Type)
+	}
+	select {
+	case event := <-events:
+		s.Failf("unexpected event", "%+v", event)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
+
+func (s *ChangeTestSuite) TestCoalesceChanges() {
+	added := ChangeEvent{Key: "k", Type: ChangeAdded, New: 1}
+	modified := ChangeEvent{Key: "k", Type: ChangeModified, Old: 1, New: 2}
+	removed := ChangeEvent{Key: "k", Type: ChangeRemoved, Old: 2}
+
+	merged, keep := coalesceChanges(added, modified)
+	s.True(keep)
+	s.Equal(ChangeEvent{Key: "k", Type: ChangeAdded, New: 2}, merged)
+
+	_, keep = coalesceChanges(added, ChangeEvent{Key: "k", Type: ChangeRemoved, Old: 1})
+	s.False(keep, "a key added and removed again is unchanged")
+
+	merged, keep = coalesceChanges(modified, removed)
+	s.True(keep)
+	s.Equal(ChangeEvent{Key: "k", Type: ChangeRemoved, Old: 1}, merged)
+
+	merged, keep = coalesceChanges(removed, ChangeEvent{Key: "k", Type: ChangeAdded, New: 3})
+	s.True(keep)
+	s.Equal(ChangeEvent{Key: "k", Type: ChangeModified, Old: 2, New: 3}, merged)
+
+	_, keep = coalesceChanges(modified, ChangeEvent{Key: "k", Type: ChangeModified, Old: 2, New: 1})
+	s.False(keep, "a key back at its old value is unchanged")
+}
+
+func (s *ChangeTestSuite) TestSubscribe_DropsRevertedChanges() {
+	sub := &subscription{queued: make(map[string]int)}
+	sub.publish(changeEvents(map[string]any{"a": 1, "b": 1, "c": 1}, map[string]any{"a": 2, "b": 2, "c": 2}))
+	sub.publish(changeEvents(map[string]any{"a": 2, "b": 2, "c": 2}, map[string]any{"a": 2, "b": 1, "c": 3}))
+	s.Equal([]ChangeEvent{
+		{Key: "a", Type: ChangeModified, Old: 1, New: 2},
+		{Key: "c", Type: ChangeModified, Old: 1, New: 3},
+	}, sub.queue)
+	s.Equal(map[string]int{"a": 0, "c": 1}, sub.queued)
+}
+
+func (s *ChangeTestSuite) TestSubscribe_Cancel() {
+	src := &mockSource{conf: map[string]any{"foo": "bar"}}
+	c, err := New(WithSource(src))
+	s.Require().NoError(err)
+
+	events, cancel := c.Subscribe("foo")
+	cancel()
+	cancel()
+
+	_, ok := <-events
+	s.False(ok)
+
+	c.mu.RLock()
+	s.Empty(c.subscriptions)
+	c.mu.RUnlock()
+
+	s.NoError(c.Load(context.Background()))
+}
+
+func (s *ChangeTestSuite) TestDiff() {
+	c, err := New(WithSensitivity(SensitivitySecret, "database.password"))
+	s.Require().NoError(err)
+
+	oldValues := map[string]any{
+		"database": map[string]any{"host": "db1", "password": "old-secret"},
+		"removed":  true,
+	}
+	newValues := map[string]any{
+		"database": map[string]any{"host": "db2", "password": "new-secret"},
+		"added":    1,
+	}
+
+	s.Equal([]ChangeEvent{
+		{Key: "added", Type: ChangeAdded, New: 1},
+		{Key: "database.host", Type: ChangeModified, Old: "db1", New: "db2"},
+		{Key: "database.password", Type: ChangeModified, Old: RedactedValue, New: RedactedValue},
+		{Key: "removed", Type: ChangeRemoved, Old: true},
+	}, c.Diff(oldValues, newValues))
+
+	s.Empty(c.Diff(oldValues, oldValues))
+}
+
+func (s *ChangeTestSuite) TestOnDiff() {
+	src := &mockSource{conf: map[string]any{"token": "a", "level": "info"}}
+	c, err := New(WithSource(src), WithSensitivity(SensitivitySecret, "token"))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	var diffs [][]ChangeEvent
+	c.OnDiff(func(changes []ChangeEvent) {
+		diffs = append(diffs, changes)
+	})
+	c.OnDiff(nil)
+
+	// Subscribers receive redacted values as well.
+	events, cancel := c.Subscribe("token")
+	defer cancel()
+
+	src.conf = map[string]any{"token": "b", "level": "info"}
+	s.Require().NoError(c.Load(context.Background()))
+	s.Require().NoError(c.Load(context.Background()))
+
+	s.Require().Len(diffs, 1)
+	s.Equal([]ChangeEvent{{Key: "token", Type: ChangeModified, Old: RedactedValue, New: RedactedValue}}, diffs[0])
+	s.Equal(ChangeEvent{Key: "token", Type: ChangeModified, Old: RedactedValue, New: RedactedValue}, s.receive(events))
+}
+
+func (s *ChangeTestSuite) TestChangeTypeJSON() {
+	data, err := json.Marshal(ChangeEvent{Key: "foo", Type: ChangeAdded, New: "bar"})
+	s.Require().NoError(err)
+	s.JSONEq(`{"key": "foo", "type": "added", "new": "bar"}`, string(data))
+	s.Equal("ChangeType(0)", ChangeType(0).String())
+}
@@ -0,0 +1,102 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+)
+
+// ChecksumOption configures how Checksum hashes the configuration.
+type ChecksumOption func(o *checksumOptions)
+
+// checksumOptions holds the settings applied by ChecksumOption values.
+type checksumOptions struct {
+	includeSecrets bool
+}
+
+// ChecksumIncludeSecrets makes Checksum hash the values of secret keys as well, so rotating a secret changes
+// the checksum. The checksum does not reveal the secrets, but it can be used to confirm a guess of them, so
+// treat it like the secrets it covers.
+func ChecksumIncludeSecrets() ChecksumOption {
+	return func(o *checksumOptions) {
+		o.includeSecrets = true
+	}
+}
+
+// Checksum returns a stable fingerprint of the effective configuration: the hex-encoded SHA-256 hash of its
+// JSON encoding, with object keys sorted. Equal configurations always have the same checksum, so deployments
+// can compare it to detect drift between instances, and change handlers can compare it to skip reloads that
+// did not change anything relevant.
+//
+// By default, the values of keys classified as secret (see SafeValues) are replaced with RedactedValue before
+// hashing, so the checksum can be logged and exported; see ChecksumIncludeSecrets. The checksum is computed
+// once per load and cached. An error is returned if the configuration holds values that cannot be encoded as
+// JSON, such as NaN.
+func (c *Conflex) Checksum(options ...ChecksumOption) (string, error) {
+	var o checksumOptions
+	for _, option := range options {
+		option(&o)
+	}
+
+	c.mu.RLock()
+	version := c.version
+	values := map[string]any{}
+	if c.values != nil {
+		values = *c.values
+	}
+	c.mu.RUnlock()
+
+	return c.checksum(version, values, o)
+}
+
+// checksum implements Checksum for values, the configuration at version, caching the result per version.
+func (c *Conflex) checksum(version uint64, values map[string]any, o checksumOptions) (string, error) {
+	c.checksumMu.Lock()
+	defer c.checksumMu.Unlock()
+
+	if c.checksumVersion != version || c.checksums == nil {
+		c.checksums = make(map[checksumOptions]string)
+		c.checksumVersion = version
+	}
+	if sum, ok := c.checksums[o]; ok {
+		return sum, nil
+	}
+
+	// Loads replace the values map instead of modifying it, so it can be read without holding the lock.
+	if !o.includeSecrets {
+		values = redactValues(values, func(key string) bool {
+			return c.Sensitivity(key) == SensitivitySecret
+		})
+	}
+	sum, err := valuesChecksum(values)
+	if err != nil {
+		return "", NewConfigError("checksum", "encode", err)
+	}
+	c.checksums[o] = sum
+	return sum, nil
+}
+
+// valuesChecksum returns the hex-encoded SHA-256 hash of the JSON encoding of values.
+// JSON object keys are sorted by encoding/json, so equal configurations always yield the same checksum.
+func valuesChecksum(values map[string]any) (string, error) {
+	data, err := json.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
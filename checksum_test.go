@@ -0,0 +1,94 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ChecksumTestSuite struct {
+	suite.Suite
+}
+
+func TestChecksumTestSuite(t *testing.T) {
+	suite.Run(t, new(ChecksumTestSuite))
+}
+
+func (s *ChecksumTestSuite) load(conf map[string]any, options ...Option) *Conflex {
+	c, err := New(append([]Option{WithSource(&mockSource{conf: conf})}, options...)...)
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+	return c
+}
+
+func (s *ChecksumTestSuite) checksum(c *Conflex, options ...ChecksumOption) string {
+	sum, err := c.Checksum(options...)
+	s.Require().NoError(err)
+	s.Len(sum, 64)
+	return sum
+}
+
+func (s *ChecksumTestSuite) TestStable() {
+	first := s.load(map[string]any{"server": map[string]any{"host": "localhost", "port": 8080}, "debug": true})
+	second := s.load(map[string]any{"debug": true, "server": map[string]any{"port": 8080, "host": "localhost"}})
+	other := s.load(map[string]any{"debug": true, "server": map[string]any{"port": 9090, "host": "localhost"}})
+
+	s.Equal(s.checksum(first), s.checksum(second))
+	s.Equal(s.checksum(first), s.checksum(first))
+	s.NotEqual(s.checksum(first), s.checksum(other))
+}
+
+func (s *ChecksumTestSuite) TestSecrets() {
+	first := s.load(map[string]any{"db": map[string]any{"password": "one"}}, WithRedactedKeys("db.password"))
+	second := s.load(map[string]any{"db": map[string]any{"password": "two"}}, WithRedactedKeys("db.password"))
+
+	s.Equal(s.checksum(first), s.checksum(second))
+	s.NotEqual(s.checksum(first, ChecksumIncludeSecrets()), s.checksum(second, ChecksumIncludeSecrets()))
+	s.NotEqual(s.checksum(first), s.checksum(first, ChecksumIncludeSecrets()))
+}
+
+func (s *ChecksumTestSuite) TestReload() {
+	src := &mockSyncSource{conf: map[string]any{"foo": "bar"}}
+	c, err := New(WithSource(src))
+	s.Require().NoError(err)
+
+	empty := s.checksum(c)
+	s.Require().NoError(c.Load(context.Background()))
+	loaded := s.checksum(c)
+	s.NotEqual(empty, loaded)
+
+	var inHandler string
+	c.OnChange(func(_, _ map[string]any) {
+		inHandler = s.checksum(c)
+	})
+	src.set(map[string]any{"foo": "baz"}, nil)
+	s.Require().NoError(c.Load(context.Background()))
+	s.NotEqual(loaded, inHandler)
+	s.Equal(inHandler, s.checksum(c))
+
+	src.set(map[string]any{"foo": "bar"}, nil)
+	s.Require().NoError(c.Load(context.Background()))
+	s.Equal(loaded, s.checksum(c))
+}
+
+func (s *ChecksumTestSuite) TestUnencodable() {
+	c := s.load(map[string]any{"ratio": math.NaN()})
+	_, err := c.Checksum()
+	s.ErrorContains(err, "checksum")
+}
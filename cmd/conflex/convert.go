@@ -0,0 +1,91 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.companyinfo.dev/conflex"
+	"go.companyinfo.dev/conflex/codec"
+	"go.companyinfo.dev/conflex/dumper"
+)
+
+// runConvert implements "conflex convert": it loads the sources and writes the merged configuration with the
+// encoder registered for the target codec. The configuration is loaded in round-trip mode, so loading the output
+// yields the same configuration.
+func runConvert(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("convert", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	to := fs.String("to", "", "codec to convert to, such as json, yaml or toml; defaults to the codec for the --output extension")
+	output := fs.String("output", "", "path of the file to write; defaults to standard output")
+	fs.StringVar(output, "o", "", "shorthand for --output")
+	caseSensitive := fs.Bool("case-sensitive", false, "keep the case of keys instead of lowercasing them")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: conflex convert --to <codec> [--output file] <sources...>")
+		fs.PrintDefaults()
+		fmt.Fprint(fs.Output(), sourcesUsage)
+	}
+
+	var encoder codec.Encoder
+	c, code := loadSources(ctx, fs, args, func(config *conflex.Config) error {
+		target := codec.Type(*to)
+		if target == "" {
+			var ok bool
+			if target, ok = extensionCodecs[strings.ToLower(filepath.Ext(*output))]; !ok {
+				return errors.New("--to is required unless the extension of --output names a codec")
+			}
+		}
+		var err error
+		if encoder, err = codec.GetEncoder(target); err != nil {
+			return err
+		}
+		config.Options = append(config.Options, conflex.WithRoundTrip())
+		if *caseSensitive {
+			config.Options = append(config.Options, conflex.WithCaseSensitiveKeys())
+		}
+		return nil
+	}, stderr)
+	if c == nil {
+		return code
+	}
+
+	data, err := encoder.Encode(dumper.Normalize(c.AllSettings()))
+	if err != nil {
+		fmt.Fprintf(stderr, "conflex convert: failed to encode configuration: %v\n", err)
+		return exitFailure
+	}
+	if !bytes.HasSuffix(data, []byte("\n")) {
+		data = append(data, '\n')
+	}
+
+	if *output == "" {
+		_, err = stdout.Write(data)
+	} else {
+		err = os.WriteFile(*output, data, dumper.DefaultFilePermissions)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "conflex convert: %v\n", err)
+		return exitFailure
+	}
+	return exitOK
+}
@@ -0,0 +1,98 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"go.companyinfo.dev/conflex/codec"
+)
+
+type ConvertTestSuite struct {
+	suite.Suite
+	dir    string
+	config string
+}
+
+func TestConvertTestSuite(t *testing.T) {
+	suite.Run(t, new(ConvertTestSuite))
+}
+
+func (s *ConvertTestSuite) SetupTest() {
+	s.dir = s.T().TempDir()
+	s.config = writeFile(&s.Suite, s.dir, "config.toml", `
+[Server]
+port = 8080
+timeout = "5s"
+
+[[backends]]
+name = "primary"
+`)
+}
+
+func (s *ConvertTestSuite) TestToStdout() {
+	code, stdout, stderr := execute("convert", s.config, "--to", "yaml")
+	s.Equal(exitOK, code, stderr)
+	s.Equal("backends:\n- name: primary\nserver:\n  port: 8080\n  timeout: 5s\n", stdout)
+}
+
+func (s *ConvertTestSuite) TestToFile() {
+	output := filepath.Join(s.dir, "config.json")
+	code, stdout, stderr := execute("convert", "-o", output, "--case-sensitive", s.config)
+	s.Equal(exitOK, code, stderr)
+	s.Empty(stdout)
+
+	data, err := os.ReadFile(output)
+	s.Require().NoError(err)
+	var converted map[string]any
+	s.Require().NoError(codec.JSONCodec{}.Decode(data, &converted))
+	s.Equal(map[string]any{
+		"Server":   map[string]any{"port": float64(8080), "timeout": "5s"},
+		"backends": []any{map[string]any{"name": "primary"}},
+	}, converted)
+}
+
+func (s *ConvertTestSuite) TestRoundTrip() {
+	code, yaml, stderr := execute("convert", s.config, "--to", "yaml")
+	s.Require().Equal(exitOK, code, stderr)
+	converted := writeFile(&s.Suite, s.dir, "converted.yaml", yaml)
+
+	code, toml, stderr := execute("convert", converted, "--to", "toml")
+	s.Require().Equal(exitOK, code, stderr)
+	_, original, _ := execute("convert", s.config, "--to", "toml")
+	s.Equal(original, toml)
+}
+
+func (s *ConvertTestSuite) TestErrors() {
+	code, _, stderr := execute("convert", s.config)
+	s.Equal(exitUsage, code)
+	s.Contains(stderr, "--to is required")
+
+	code, _, stderr = execute("convert", s.config, "--to", "xml")
+	s.Equal(exitUsage, code)
+	s.Contains(stderr, "encoder not found for type: xml")
+
+	code, _, stderr = execute("convert", s.config, "--to", "env_var")
+	s.Equal(exitFailure, code)
+	s.Contains(stderr, "failed to encode configuration")
+
+	code, _, stderr = execute("convert", s.config, "--to", "json", "-o", filepath.Join(s.dir, "missing", "out.json"))
+	s.Equal(exitFailure, code)
+	s.Contains(stderr, "no such file or directory")
+}
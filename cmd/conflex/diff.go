@@ -0,0 +1,147 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"go.companyinfo.dev/conflex"
+)
+
+// stringsFlag is a flag that can be repeated, collecting its values.
+type stringsFlag []string
+
+func (f *stringsFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *stringsFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+// runDiff implements "conflex diff": it loads two configurations, A and B, and lists the keys that were added,
+// removed or modified going from A to B. The sources of each side are given with the repeatable -a and -b flags,
+// or as two positional arguments when each side has a single source.
+func runDiff(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("diff", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	format := fs.String("format", "text", "output format: text or json")
+	var secrets stringsFlag
+	fs.Var(&secrets, "secret", "pattern of keys whose values are redacted, such as database.*.password; can be repeated")
+	var sourcesA, sourcesB stringsFlag
+	fs.Var(&sourcesA, "a", "source of the first configuration; can be repeated")
+	fs.Var(&sourcesB, "b", "source of the second configuration; can be repeated")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: conflex diff [flags] -a <source> [-a <source>...] -b <source> [-b <source>...]")
+		fmt.Fprintln(fs.Output(), "       conflex diff [flags] <source-a> <source-b>")
+		fmt.Fprintln(fs.Output(), "\nSources of each side are merged in the order they are given.")
+		fmt.Fprintln(fs.Output(), "The exit code is 0 if the configurations are equal, 1 if they differ and 2 on errors.")
+		fs.PrintDefaults()
+		fmt.Fprint(fs.Output(), sourcesUsage)
+	}
+
+	positional, err := parseFlags(fs, args)
+	if errors.Is(err, flag.ErrHelp) {
+		return exitOK
+	}
+	if err != nil {
+		return exitTrouble
+	}
+	if *format != "text" && *format != "json" {
+		fmt.Fprintf(stderr, "conflex diff: unknown format %q\n", *format)
+		return exitTrouble
+	}
+	sides := [][]string{sourcesA, sourcesB}
+	switch {
+	case len(sourcesA) == 0 && len(sourcesB) == 0 && len(positional) == 2:
+		sides = [][]string{positional[:1], positional[1:]}
+	case len(sourcesA) == 0 || len(sourcesB) == 0 || len(positional) > 0:
+		fmt.Fprintln(stderr, "conflex diff: exactly two configurations are required")
+		fs.Usage()
+		return exitTrouble
+	}
+
+	// Round-trip mode converts values to canonical types, so 8080 read from YAML equals 8080 read from JSON.
+	options := []conflex.Option{conflex.WithRoundTrip()}
+	if len(secrets) > 0 {
+		options = append(options, conflex.WithSensitivity(conflex.SensitivitySecret, secrets...))
+	}
+	configs := make([]*conflex.Conflex, len(sides))
+	for i, side := range sides {
+		sources, err := parseSources(side)
+		if err != nil {
+			fmt.Fprintf(stderr, "conflex diff: %v\n", err)
+			return exitTrouble
+		}
+		c, _ := loadConfig(ctx, "diff", conflex.Config{Sources: sources, Options: options}, stderr)
+		if c == nil {
+			return exitTrouble
+		}
+		configs[i] = c
+	}
+
+	changes := configs[1].Diff(configs[0].AllSettings(), configs[1].AllSettings())
+	if err := writeChanges(stdout, *format, changes); err != nil {
+		fmt.Fprintf(stderr, "conflex diff: %v\n", err)
+		return exitTrouble
+	}
+	if len(changes) > 0 {
+		return exitDifferent
+	}
+	return exitOK
+}
+
+// writeChanges writes changes to w in format. The text format has one line per key: "+ key: value" for added
+// keys, "- key: value" for removed keys and "~ key: old -> new" for modified keys, with values written as JSON.
+func writeChanges(w io.Writer, format string, changes []conflex.ChangeEvent) error {
+	if format == "json" {
+		if changes == nil {
+			changes = []conflex.ChangeEvent{}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(changes)
+	}
+
+	var b strings.Builder
+	for _, change := range changes {
+		switch change.Type {
+		case conflex.ChangeAdded:
+			fmt.Fprintf(&b, "+ %s: %s\n", change.Key, formatValue(change.New))
+		case conflex.ChangeRemoved:
+			fmt.Fprintf(&b, "- %s: %s\n", change.Key, formatValue(change.Old))
+		default:
+			fmt.Fprintf(&b, "~ %s: %s -> %s\n", change.Key, formatValue(change.Old), formatValue(change.New))
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// formatValue formats a configuration value as JSON, or with fmt if it cannot be encoded as JSON.
+func formatValue(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
@@ -0,0 +1,130 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"go.companyinfo.dev/conflex"
+)
+
+type DiffTestSuite struct {
+	suite.Suite
+	dir        string
+	staging    string
+	production string
+}
+
+func TestDiffTestSuite(t *testing.T) {
+	suite.Run(t, new(DiffTestSuite))
+}
+
+func (s *DiffTestSuite) SetupTest() {
+	s.dir = s.T().TempDir()
+	s.staging = writeFile(&s.Suite, s.dir, "staging.yaml", `
+server:
+  host: staging.internal
+  port: 8080
+  debug: true
+database:
+  password: staging-secret
+`)
+	s.production = writeFile(&s.Suite, s.dir, "production.json", `{
+		"server": {"host": "prod.internal", "port": 8080},
+		"database": {"password": "prod-secret"},
+		"replicas": [1, 2]
+	}`)
+}
+
+func (s *DiffTestSuite) TestText() {
+	code, stdout, stderr := execute("diff", "--secret", "database.password", s.staging, s.production)
+	s.Equal(exitDifferent, code, stderr)
+	s.Equal(`~ database.password: "***" -> "***"
++ replicas: [1,2]
+- server.debug: true
+~ server.host: "staging.internal" -> "prod.internal"
+`, stdout)
+}
+
+func (s *DiffTestSuite) TestJSON() {
+	code, stdout, stderr := execute("diff", "--format", "json", s.staging, s.production)
+	s.Equal(exitDifferent, code, stderr)
+
+	var changes []map[string]any
+	s.Require().NoError(json.Unmarshal([]byte(stdout), &changes))
+	s.Len(changes, 4)
+	s.Equal(map[string]any{"key": "server.debug", "type": "removed", "old": true}, changes[2])
+}
+
+func (s *DiffTestSuite) TestEqual() {
+	s.T().Setenv("DIFF_SERVER_HOST", "prod.internal")
+	override := writeFile(&s.Suite, s.dir, "override.yaml", "server:\n  host: staging.internal\n")
+
+	code, stdout, stderr := execute("diff", "-a", s.staging, "-b", s.staging, "-b", "env://DIFF_", "-b", override)
+	s.Equal(exitOK, code, stderr)
+	s.Empty(stdout)
+
+	code, stdout, _ = execute("diff", "--format", "json", s.staging, s.staging)
+	s.Equal(exitOK, code)
+	s.Equal("[]\n", stdout)
+}
+
+func (s *DiffTestSuite) TestErrors() {
+	code, _, stderr := execute("diff", s.staging)
+	s.Equal(exitTrouble, code)
+	s.Contains(stderr, "exactly two configurations are required")
+
+	code, _, stderr = execute("diff", "-a", s.staging, s.production)
+	s.Equal(exitTrouble, code)
+	s.Contains(stderr, "exactly two configurations are required")
+
+	code, _, stderr = execute("diff", "-a", s.staging, "-b", s.production, s.production)
+	s.Equal(exitTrouble, code)
+	s.Contains(stderr, "exactly two configurations are required")
+
+	code, _, stderr = execute("diff", s.staging, s.dir+"/missing.yaml")
+	s.Equal(exitTrouble, code)
+	s.Contains(stderr, "failed to read file")
+
+	code, _, stderr = execute("diff", s.staging, "b.ini")
+	s.Equal(exitTrouble, code)
+	s.Contains(stderr, `source "b.ini"`)
+
+	code, _, stderr = execute("diff", "--format", "xml", s.staging, s.production)
+	s.Equal(exitTrouble, code)
+	s.Contains(stderr, `unknown format "xml"`)
+}
+
+func (s *DiffTestSuite) TestSourcesWithCommas() {
+	dir := s.dir + "/a,b"
+	s.Require().NoError(os.Mkdir(dir, 0o755))
+	path := writeFile(&s.Suite, dir, "config.yaml", "server:\n  host: prod.internal\n")
+
+	code, stdout, stderr := execute("diff", "-a", s.staging, "-b", s.staging, "-b", path)
+	s.Equal(exitDifferent, code, stderr)
+	s.Equal("~ server.host: \"staging.internal\" -> \"prod.internal\"\n", stdout)
+}
+
+func (s *DiffTestSuite) TestFormatValue() {
+	s.Equal(`"text"`, formatValue("text"))
+	s.Equal(`{"a":1}`, formatValue(map[string]any{"a": 1}))
+	s.Equal("null", formatValue(nil))
+	s.Equal("(1+2i)", formatValue(complex(1, 2)))
+	s.Equal(`"***"`, formatValue(conflex.RedactedValue))
+}
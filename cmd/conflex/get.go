@@ -0,0 +1,116 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"go.companyinfo.dev/conflex"
+	"go.companyinfo.dev/conflex/codec"
+)
+
+// runGet implements "conflex get": it loads the sources and prints the value of a single key, for use in shell
+// scripts.
+func runGet(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("get", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	format := fs.String("format", "raw", "output format: raw, or a codec such as json, yaml or toml")
+	var sources stringsFlag
+	fs.Var(&sources, "s", "source to load; can be repeated")
+	fs.Var(&sources, "source", "same as -s")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: conflex get [--format raw|json|yaml|toml] <key> -s <source> [-s <source>...]")
+		fmt.Fprintln(fs.Output(), "\nThe raw format prints strings and numbers as they are and maps and lists as JSON.")
+		fmt.Fprintln(fs.Output(), "The exit code is 1 if the key is not set.")
+		fs.PrintDefaults()
+		fmt.Fprint(fs.Output(), sourcesUsage)
+	}
+
+	positional, err := parseFlags(fs, args)
+	if errors.Is(err, flag.ErrHelp) {
+		return exitOK
+	}
+	if err != nil {
+		return exitUsage
+	}
+	if len(positional) == 0 {
+		fmt.Fprintln(stderr, "conflex get: a key is required")
+		fs.Usage()
+		return exitUsage
+	}
+
+	var encoder codec.Encoder
+	if *format != "raw" {
+		if encoder, err = codec.GetEncoder(codec.Type(*format)); err != nil {
+			fmt.Fprintf(stderr, "conflex get: %v\n", err)
+			return exitUsage
+		}
+	}
+
+	// Positional arguments after the key are sources too, merged after those given with -s.
+	key := positional[0]
+	config, err := parseSources(append(sources, positional[1:]...))
+	if err != nil {
+		fmt.Fprintf(stderr, "conflex get: %v\n", err)
+		fs.Usage()
+		return exitUsage
+	}
+	c, code := loadConfig(ctx, "get", conflex.Config{Sources: config, Options: []conflex.Option{conflex.WithRoundTrip()}}, stderr)
+	if c == nil {
+		return code
+	}
+
+	value := c.Get(key)
+	if value == nil {
+		fmt.Fprintf(stderr, "conflex get: key %q is not set\n", key)
+		return exitFailure
+	}
+	data, err := formatGetValue(value, encoder)
+	if err != nil {
+		fmt.Fprintf(stderr, "conflex get: failed to encode value: %v\n", err)
+		return exitFailure
+	}
+	if !bytes.HasSuffix(data, []byte("\n")) {
+		data = append(data, '\n')
+	}
+	if _, err := stdout.Write(data); err != nil {
+		fmt.Fprintf(stderr, "conflex get: %v\n", err)
+		return exitFailure
+	}
+	return exitOK
+}
+
+// formatGetValue encodes value with encoder, or in the raw format if encoder is nil: strings and numbers as they
+// are, and maps and lists as JSON.
+func formatGetValue(value any, encoder codec.Encoder) ([]byte, error) {
+	if encoder != nil {
+		return encoder.Encode(value)
+	}
+
+	switch v := value.(type) {
+	case string:
+		return []byte(v), nil
+	case map[string]any, []any:
+		return json.Marshal(v)
+	default:
+		return []byte(fmt.Sprint(v)), nil
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type GetTestSuite struct {
+	suite.Suite
+	dir    string
+	config string
+}
+
+func TestGetTestSuite(t *testing.T) {
+	suite.Run(t, new(GetTestSuite))
+}
+
+func (s *GetTestSuite) SetupTest() {
+	s.dir = s.T().TempDir()
+	s.config = writeFile(&s.Suite, s.dir, "config.yaml", `
+server:
+  host: example.com
+  port: 8080
+  timeout: 5s
+  tags: [web, api]
+`)
+}
+
+func (s *GetTestSuite) TestRaw() {
+	tests := map[string]string{
+		"server.host":   "example.com\n",
+		"server.port":   "8080\n",
+		"server.tags.1": "api\n",
+		"server.tags":   "[\"web\",\"api\"]\n",
+		"server":        `{"host":"example.com","port":8080,"tags":["web","api"],"timeout":"5s"}` + "\n",
+	}
+	for key, want := range tests {
+		code, stdout, stderr := execute("get", key, "-s", s.config)
+		s.Equal(exitOK, code, stderr)
+		s.Equal(want, stdout, key)
+	}
+}
+
+func (s *GetTestSuite) TestFormats() {
+	code, stdout, _ := execute("get", "--format", "json", "server.host", "-s", s.config)
+	s.Equal(exitOK, code)
+	s.Equal("\"example.com\"\n", stdout)
+
+	code, stdout, _ = execute("get", "server.tags", "--format", "yaml", "--source", s.config)
+	s.Equal(exitOK, code)
+	s.Equal("- web\n- api\n", stdout)
+}
+
+func (s *GetTestSuite) TestSourcesInOrder() {
+	s.T().Setenv("GET_SERVER_HOST", "override.example.com")
+
+	code, stdout, _ := execute("get", "server.host", "-s", s.config, "-s", "env://GET_")
+	s.Equal(exitOK, code)
+	s.Equal("override.example.com\n", stdout)
+
+	// Positional sources are merged after those given with -s.
+	code, stdout, _ = execute("get", "server.host", "-s", "env://GET_", s.config)
+	s.Equal(exitOK, code)
+	s.Equal("example.com\n", stdout)
+}
+
+func (s *GetTestSuite) TestMissingKey() {
+	code, stdout, stderr := execute("get", "server.missing", "-s", s.config)
+	s.Equal(exitFailure, code)
+	s.Empty(stdout)
+	s.Contains(stderr, `key "server.missing" is not set`)
+}
+
+func (s *GetTestSuite) TestErrors() {
+	code, _, stderr := execute("get", "-s", s.config)
+	s.Equal(exitUsage, code)
+	s.Contains(stderr, "a key is required")
+
+	code, _, stderr = execute("get", "server.port")
+	s.Equal(exitUsage, code)
+	s.Contains(stderr, "at least one source is required")
+
+	code, _, stderr = execute("get", "server.port", "-s", s.config, "--format", "xml")
+	s.Equal(exitUsage, code)
+	s.Contains(stderr, "encoder not found for type: xml")
+
+	code, _, stderr = execute("get", "server.port", "-s", filepath.Join(s.dir, "missing.yaml"))
+	s.Equal(exitFailure, code)
+	s.Contains(stderr, "failed to read file")
+}
+
+func (s *GetTestSuite) TestEncodeError() {
+	code, _, stderr := execute("get", "server.port", "-s", s.config, "--format", "env_var")
+	s.Equal(exitFailure, code)
+	s.Contains(stderr, "failed to encode value")
+}
@@ -0,0 +1,104 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command conflex loads configuration the way the conflex library does, for use in scripts and CI pipelines.
+//
+// Usage:
+//
+//	conflex <command> [flags] <sources...>
+//
+// Sources are merged in the order they are given, so later sources override earlier ones. See parseSource for
+// the accepted forms.
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+)
+
+// Exit codes of the conflex command.
+const (
+	exitOK      = 0 // The command succeeded
+	exitFailure = 1 // The configuration could not be loaded or is invalid
+	exitUsage   = 2 // The command line is invalid
+
+	// Like diff(1), "conflex diff" exits with 1 if the configurations differ and with 2 on any error.
+	exitDifferent = 1
+	exitTrouble   = 2
+)
+
+// command is a subcommand of the conflex command.
+type command struct {
+	summary string
+	run     func(ctx context.Context, args []string, stdout, stderr io.Writer) int
+}
+
+// commands holds the subcommands by name.
+var commands = map[string]command{
+	"convert":  {summary: "convert the merged configuration to another format", run: runConvert},
+	"diff":     {summary: "list the keys that differ between two configurations", run: runDiff},
+	"get":      {summary: "print the value of a single key", run: runGet},
+	"validate": {summary: "load sources and validate the merged configuration", run: runValidate},
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	code := run(ctx, os.Args[1:], os.Stdout, os.Stderr)
+	stop()
+	os.Exit(code)
+}
+
+// run runs the command line args and returns the exit code.
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		usage(stderr)
+		return exitUsage
+	}
+	switch args[0] {
+	case "help", "-h", "-help", "--help":
+		usage(stdout)
+		return exitOK
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "conflex: unknown command %q\n\n", args[0])
+		usage(stderr)
+		return exitUsage
+	}
+	return cmd.run(ctx, args[1:], stdout, stderr)
+}
+
+// usage writes the list of commands to w.
+func usage(w io.Writer) {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	var b strings.Builder
+	b.WriteString("Usage: conflex <command> [flags] <sources...>\n\nCommands:\n")
+	for _, name := range names {
+		fmt.Fprintf(&b, "  %-10s %s\n", name, commands[name].summary)
+	}
+	b.WriteString("\nRun 'conflex <command> -h' for the flags of a command.\n")
+	b.WriteString(sourcesUsage)
+	_, _ = io.WriteString(w, b.String())
+}
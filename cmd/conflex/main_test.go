@@ -0,0 +1,64 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type MainTestSuite struct {
+	suite.Suite
+}
+
+func TestMainTestSuite(t *testing.T) {
+	suite.Run(t, new(MainTestSuite))
+}
+
+// execute runs the command line args and returns the exit code and the output.
+func execute(args ...string) (code int, stdout, stderr string) {
+	var out, errOut bytes.Buffer
+	code = run(context.Background(), args, &out, &errOut)
+	return code, out.String(), errOut.String()
+}
+
+// writeFile writes data to name in dir and returns its path.
+func writeFile(s *suite.Suite, dir, name, data string) string {
+	path := filepath.Join(dir, name)
+	s.Require().NoError(os.WriteFile(path, []byte(data), 0o600))
+	return path
+}
+
+func (s *MainTestSuite) TestUsage() {
+	code, _, stderr := execute()
+	s.Equal(exitUsage, code)
+	s.Contains(stderr, "Usage: conflex <command>")
+	s.Contains(stderr, "validate")
+
+	code, stdout, _ := execute("help")
+	s.Equal(exitOK, code)
+	s.Contains(stdout, "env://PREFIX_")
+}
+
+func (s *MainTestSuite) TestUnknownCommand() {
+	code, _, stderr := execute("frobnicate")
+	s.Equal(exitUsage, code)
+	s.Contains(stderr, `unknown command "frobnicate"`)
+}
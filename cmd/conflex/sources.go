@@ -0,0 +1,160 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"go.companyinfo.dev/conflex"
+	"go.companyinfo.dev/conflex/codec"
+)
+
+// sourcesUsage describes the source arguments accepted by every command.
+const sourcesUsage = `
+Sources:
+  config.yaml                 a file; the codec follows from the extension (.json, .yaml, .yml, .toml)
+  file://config.conf?codec=X  a file with an explicit codec
+  env://PREFIX_               environment variables starting with PREFIX_
+  consul://app/config.json    a Consul key, read from the agent at CONSUL_HTTP_ADDR
+  https://host/config.yaml    a configuration served over HTTP(S)
+
+A "codec" query parameter sets the codec of any source, as in consul://app/config?codec=yaml.
+`
+
+// extensionCodecs maps file extensions to the codecs that decode them.
+var extensionCodecs = map[string]codec.Type{
+	".json": codec.TypeJSON,
+	".yaml": codec.TypeYAML,
+	".yml":  codec.TypeYAML,
+	".toml": codec.TypeTOML,
+}
+
+// parseSources returns the sources described by the command-line arguments args, named after the arguments.
+func parseSources(args []string) ([]conflex.SourceConfig, error) {
+	if len(args) == 0 {
+		return nil, errors.New("at least one source is required")
+	}
+
+	sources := make([]conflex.SourceConfig, 0, len(args))
+	for _, arg := range args {
+		sc, err := parseSource(arg)
+		if err != nil {
+			return nil, fmt.Errorf("source %q: %w", arg, err)
+		}
+		sources = append(sources, sc)
+	}
+	return sources, nil
+}
+
+// parseSource returns the source described by a command-line argument, in one of the forms listed in
+// sourcesUsage. Arguments without a scheme are file paths.
+func parseSource(arg string) (conflex.SourceConfig, error) {
+	scheme, rest, ok := strings.Cut(arg, "://")
+	if !ok {
+		return fileSource(arg, arg, "")
+	}
+
+	switch scheme {
+	case "file", "env", "consul":
+		location, rawQuery, _ := strings.Cut(rest, "?")
+		query, err := url.ParseQuery(rawQuery)
+		if err != nil {
+			return conflex.SourceConfig{}, err
+		}
+		codecType := codec.Type(query.Get("codec"))
+		switch scheme {
+		case "file":
+			return fileSource(arg, location, codecType)
+		case "env":
+			return conflex.SourceConfig{Name: arg, Type: "env", Prefix: location}, nil
+		default:
+			if location == "" {
+				return conflex.SourceConfig{}, errors.New("consul key cannot be empty")
+			}
+			codecType, err = inferCodec(path.Ext(location), codecType)
+			if err != nil {
+				return conflex.SourceConfig{}, err
+			}
+			return conflex.SourceConfig{Name: arg, Type: "consul", Path: location, Codec: codecType}, nil
+		}
+	case "http", "https":
+		u, err := url.Parse(arg)
+		if err != nil {
+			return conflex.SourceConfig{}, err
+		}
+		query := u.Query()
+		codecType := codec.Type(query.Get("codec"))
+		if query.Has("codec") {
+			query.Del("codec")
+			u.RawQuery = query.Encode()
+		}
+		codecType, err = inferCodec(path.Ext(u.Path), codecType)
+		if err != nil {
+			return conflex.SourceConfig{}, err
+		}
+		return conflex.SourceConfig{Name: u.Redacted(), Type: "http", URL: u.String(), Codec: codecType}, nil
+	default:
+		return conflex.SourceConfig{}, fmt.Errorf("unknown scheme %q", scheme)
+	}
+}
+
+// fileSource returns the file source at path, named name.
+func fileSource(name, path string, codecType codec.Type) (conflex.SourceConfig, error) {
+	if path == "" {
+		return conflex.SourceConfig{}, errors.New("file path cannot be empty")
+	}
+	codecType, err := inferCodec(filepath.Ext(path), codecType)
+	if err != nil {
+		return conflex.SourceConfig{}, err
+	}
+	return conflex.SourceConfig{Name: name, Type: "file", Path: path, Codec: codecType}, nil
+}
+
+// inferCodec returns codecType if it is set, and otherwise the codec for the extension ext.
+func inferCodec(ext string, codecType codec.Type) (codec.Type, error) {
+	if codecType != "" {
+		return codecType, nil
+	}
+	if codecType, ok := extensionCodecs[strings.ToLower(ext)]; ok {
+		return codecType, nil
+	}
+	return "", errors.New(`cannot infer the codec; add a "codec" query parameter`)
+}
+
+// parseFlags parses the flags in args, which may be interleaved with positional arguments, and returns the
+// positional arguments. Arguments after "--" are always positional.
+func parseFlags(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		rest := fs.Args()
+		if parsed := len(args) - len(rest); parsed > 0 && args[parsed-1] == "--" {
+			return append(positional, rest...), nil
+		}
+		if len(rest) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, rest[0])
+		args = rest[1:]
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"go.companyinfo.dev/conflex"
+)
+
+// runValidate implements "conflex validate": it loads the sources, validates the merged configuration against
+// the schema, if any, and lists the validation errors.
+func runValidate(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("validate", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	schemaFile := fs.String("schema", "", "path of a JSON Schema file to validate the configuration against")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: conflex validate [--schema schema.json] <sources...>")
+		fs.PrintDefaults()
+		fmt.Fprint(fs.Output(), sourcesUsage)
+	}
+
+	c, code := loadSources(ctx, fs, args, func(config *conflex.Config) error {
+		config.SchemaFile = *schemaFile
+		return nil
+	}, stderr)
+	if c == nil {
+		return code
+	}
+
+	fmt.Fprintf(stdout, "configuration is valid (%d keys)\n", len(c.AllKeys()))
+	return exitOK
+}
+
+// loadSources parses the flags of fs and the sources in args, and loads them with the options set by configure,
+// which runs after the flags are parsed and may reject them. It returns nil and the exit code if the command
+// line is invalid or the configuration cannot be loaded, after writing the problem to stderr.
+func loadSources(ctx context.Context, fs *flag.FlagSet, args []string, configure func(*conflex.Config) error, stderr io.Writer) (*conflex.Conflex, int) {
+	positional, err := parseFlags(fs, args)
+	if errors.Is(err, flag.ErrHelp) {
+		return nil, exitOK
+	}
+	if err != nil {
+		return nil, exitUsage
+	}
+	sources, err := parseSources(positional)
+	if err != nil {
+		fmt.Fprintf(stderr, "conflex %s: %v\n", fs.Name(), err)
+		fs.Usage()
+		return nil, exitUsage
+	}
+
+	config := conflex.Config{Sources: sources}
+	if configure != nil {
+		if err := configure(&config); err != nil {
+			fmt.Fprintf(stderr, "conflex %s: %v\n", fs.Name(), err)
+			return nil, exitUsage
+		}
+	}
+	return loadConfig(ctx, fs.Name(), config, stderr)
+}
+
+// loadConfig creates an instance from config and loads it, for the command named name. It returns nil and the
+// exit code if the instance cannot be created or loaded, after writing the problem to stderr. Validation errors
+// are listed one per line, with the source of the value.
+func loadConfig(ctx context.Context, name string, config conflex.Config, stderr io.Writer) (*conflex.Conflex, int) {
+	c, err := conflex.NewFromConfig(config)
+	if err != nil {
+		fmt.Fprintf(stderr, "conflex %s: %v\n", name, err)
+		return nil, exitUsage
+	}
+
+	if err := c.Load(ctx); err != nil {
+		details := conflex.ValidationErrors(err)
+		if len(details) == 0 {
+			fmt.Fprintf(stderr, "conflex %s: %v\n", name, err)
+			return nil, exitFailure
+		}
+		fmt.Fprintf(stderr, "conflex %s: %d validation error(s):\n", name, len(details))
+		for _, detail := range details {
+			fmt.Fprintf(stderr, "  %s\n", describeValidationError(detail))
+		}
+		return nil, exitFailure
+	}
+	return c, exitOK
+}
+
+// describeValidationError formats a validation error as "key: message (from source)".
+func describeValidationError(e *conflex.ValidationError) string {
+	key := e.Key()
+	if key == "" {
+		key = "(root)"
+	}
+	s := key + ": " + e.Message
+	if e.Source != "" {
+		s += " (from " + e.Source + ")"
+	}
+	return s
+}
@@ -0,0 +1,94 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ValidateTestSuite struct {
+	suite.Suite
+	dir    string
+	schema string
+}
+
+func TestValidateTestSuite(t *testing.T) {
+	suite.Run(t, new(ValidateTestSuite))
+}
+
+func (s *ValidateTestSuite) SetupTest() {
+	s.dir = s.T().TempDir()
+	s.schema = writeFile(&s.Suite, s.dir, "schema.json", `{
+		"type": "object",
+		"properties": {"server": {"type": "object", "properties": {"port": {"type": "integer", "minimum": 1}}}},
+		"required": ["server"]
+	}`)
+}
+
+func (s *ValidateTestSuite) TestValid() {
+	config := writeFile(&s.Suite, s.dir, "config.yaml", "server:\n  port: 8080\n")
+	s.T().Setenv("VALIDATE_SERVER_HOST", "example.com")
+
+	code, stdout, stderr := execute("validate", "--schema", s.schema, config, "env://VALIDATE_")
+	s.Equal(exitOK, code, stderr)
+	s.Equal("configuration is valid (2 keys)\n", stdout)
+}
+
+func (s *ValidateTestSuite) TestInvalid() {
+	config := writeFile(&s.Suite, s.dir, "config.yaml", "server:\n  port: 0\n")
+
+	code, stdout, stderr := execute("validate", config, "--schema", s.schema)
+	s.Equal(exitFailure, code)
+	s.Empty(stdout)
+	s.Contains(stderr, "1 validation error(s)")
+	s.Contains(stderr, "server.port: minimum: got 0, want 1 (from "+config+")")
+}
+
+func (s *ValidateTestSuite) TestWithoutSchema() {
+	config := writeFile(&s.Suite, s.dir, "config.json", `{"server": {"port": 0}}`)
+
+	code, stdout, _ := execute("validate", config)
+	s.Equal(exitOK, code)
+	s.Contains(stdout, "configuration is valid")
+}
+
+func (s *ValidateTestSuite) TestLoadError() {
+	code, _, stderr := execute("validate", s.dir+"/missing.yaml")
+	s.Equal(exitFailure, code)
+	s.Contains(stderr, "failed to read file")
+
+	config := writeFile(&s.Suite, s.dir, "broken.json", `{"server":`)
+	code, _, stderr = execute("validate", config)
+	s.Equal(exitFailure, code)
+	s.Contains(stderr, "failed to decode file")
+}
+
+func (s *ValidateTestSuite) TestUsageErrors() {
+	code, _, stderr := execute("validate")
+	s.Equal(exitUsage, code)
+	s.Contains(stderr, "at least one source is required")
+
+	code, _, stderr = execute("validate", "--schema", s.dir+"/missing.json", "config.yaml")
+	s.Equal(exitUsage, code)
+	s.Contains(stderr, "schema_file")
+
+	code, _, _ = execute("validate", "--bogus", "config.yaml")
+	s.Equal(exitUsage, code)
+
+	code, _, _ = execute("validate", "-h")
+	s.Equal(exitOK, code)
+}
@@ -15,6 +15,8 @@
 // Package codec provides functionality for encoding and decoding data.
 package codec
 
+import "io"
+
 // Type is a string type used for encoding and decoding data.
 type Type string
 
@@ -29,3 +31,23 @@ type Encoder interface {
 type Decoder interface {
 	Decode(data []byte, v any) error
 }
+
+// StreamDecoder is implemented by decoders that can decode directly from a reader, so a large input does not have
+// to be read into memory in full before it is decoded. Of the built-in codecs, only JSONCodec implements it: the
+// YAML parser builds a syntax tree of the whole document, so YAML input is always read in full.
+type StreamDecoder interface {
+	DecodeReader(r io.Reader, v any) error
+}
+
+// DecodeReader decodes the data read from r into the value pointed to by v. It streams the data into decoder if
+// the decoder implements StreamDecoder, and otherwise reads all of it and calls Decode.
+func DecodeReader(decoder Decoder, r io.Reader, v any) error {
+	if sd, ok := decoder.(StreamDecoder); ok {
+		return sd.DecodeReader(r, v)
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	return decoder.Decode(data, v)
+}
@@ -16,7 +16,6 @@
 package codec
 
 import (
-	"bytes"
 	"fmt"
 	"strings"
 )
@@ -40,67 +39,80 @@ func (EnvVarCodec) Encode(_ any) ([]byte, error) {
 	return nil, fmt.Errorf("encoding to environment variables is not supported")
 }
 
+// EntryDecoder is implemented by decoders that decode environment entries, "KEY=value" strings as returned by
+// os.Environ, directly, without joining them into a single document first.
+type EntryDecoder interface {
+	DecodeEntries(entries []string, v any) error
+}
+
 // Decode decodes the provided data bytes into a configuration map.
 // The data is expected to be in the format of environment variables, with each line containing a key-value pair separated by an equals sign.
 func (EnvVarCodec) Decode(data []byte, v any) error {
-	conf := make(map[string]any)
+	ptr, ok := v.(*map[string]any)
+	if !ok {
+		return fmt.Errorf("EnvVarCodec.Decode: expected *map[string]any, got %T", v)
+	}
 
-	for _, env := range bytes.Split(data, []byte("\n")) {
-		pair := strings.SplitN(string(env), "=", 2)
-		if len(pair) != 2 {
-			continue
-		}
+	// Entries are cut from a single string, so keys and values share its memory instead of being copied.
+	rest := string(data)
+	conf := make(map[string]any, strings.Count(rest, "\n")+1)
+	for rest != "" {
+		var entry string
+		entry, rest, _ = strings.Cut(rest, "\n")
+		setEnvEntry(conf, entry)
+	}
+	*ptr = conf
 
-		// Sanitize key - trim whitespace
-		key := strings.TrimSpace(pair[0])
-		if key == "" {
-			continue
-		}
+	return nil
+}
 
-		// Split key by underscores and filter out empty parts
-		rawParts := strings.Split(strings.ToLower(key), "_")
-		parts := make([]string, 0, len(rawParts))
-		for _, part := range rawParts {
-			if part != "" {
-				parts = append(parts, part)
-			}
-		}
+// DecodeEntries decodes environment entries, such as those returned by os.Environ, into a configuration map,
+// like Decode does with the entries on separate lines.
+func (EnvVarCodec) DecodeEntries(entries []string, v any) error {
+	ptr, ok := v.(*map[string]any)
+	if !ok {
+		return fmt.Errorf("EnvVarCodec.DecodeEntries: expected *map[string]any, got %T", v)
+	}
 
-		// Skip if no valid parts remain
-		if len(parts) == 0 {
+	conf := make(map[string]any, len(entries))
+	for _, entry := range entries {
+		setEnvEntry(conf, entry)
+	}
+	*ptr = conf
+
+	return nil
+}
+
+// setEnvEntry stores the value of a "KEY=value" entry in conf, nested by the underscores in the key.
+// Keys are lowercased, empty key parts are ignored, and whitespace around keys and values is trimmed.
+// Values that are in the way of the intermediate maps are replaced. Entries without "=" are skipped.
+func setEnvEntry(conf map[string]any, entry string) {
+	key, value, ok := strings.Cut(entry, "=")
+	if !ok {
+		return
+	}
+
+	key = strings.ToLower(strings.TrimSpace(key))
+	current := conf
+	var last string
+	for key != "" {
+		var part string
+		part, key, _ = strings.Cut(key, "_")
+		if part == "" {
 			continue
 		}
-
-		current := conf
-		// Create nested structure for all parts except the last one
-		for i := 0; i < len(parts)-1; i++ {
-			part := parts[i]
-			if _, exists := current[part]; !exists {
-				current[part] = make(map[string]any)
-			}
-			// Handle type conflicts: if current[part] is not a map, overwrite it
-			if nextMap, ok := current[part].(map[string]any); ok {
-				current = nextMap
-			} else {
-				current[part] = make(map[string]any)
-				if nextMap, ok := current[part].(map[string]any); ok {
-					current = nextMap
-				} else {
-					// This should never happen, but handle it gracefully
-					return fmt.Errorf("failed to create nested map for key: %s", part)
-				}
+		if last != "" {
+			next, ok := current[last].(map[string]any)
+			if !ok {
+				next = make(map[string]any)
+				current[last] = next
 			}
+			current = next
 		}
-
-		// Set the final value, trimming whitespace from value as well
-		current[parts[len(parts)-1]] = strings.TrimSpace(pair[1])
+		last = part
 	}
 
-	ptr, ok := v.(*map[string]any)
-	if !ok {
-		return fmt.Errorf("EnvVarCodec.Decode: expected *map[string]any, got %T", v)
+	if last != "" {
+		current[last] = strings.TrimSpace(value)
 	}
-	*ptr = conf
-
-	return nil
 }
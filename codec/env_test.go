@@ -15,6 +15,8 @@
 package codec
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
@@ -167,3 +169,41 @@ func (s *EnvVarCodecTestSuite) TestDecode_EdgeCases_SingleUnderscore() {
 	s.NoError(err)
 	s.Empty(v) // Single underscore should result in empty parts and be skipped
 }
+
+// TestDecodeEntries tests that entries decode to the same configuration as the equivalent lines.
+func (s *EnvVarCodecTestSuite) TestDecodeEntries() {
+	entries := []string{
+		"FOO=bar", "  BAZ\t=\tqux ", "DB_HOST=localhost", "DB_PORT=5432", "DB=scalar", "DB_USER=admin",
+		"URL=http://example.com/?a=b", "NOEQUALS", "=value", "___=skipped", "_LEADING__AND_TRAILING_=x",
+	}
+	var want, got map[string]any
+	s.Require().NoError(s.codec.Decode([]byte(strings.Join(entries, "\n")), &want))
+	s.Require().NoError(s.codec.DecodeEntries(entries, &got))
+	s.Equal(want, got)
+	s.Equal(map[string]any{"user": "admin"}, got["db"])
+	s.Equal("http://example.com/?a=b", got["url"])
+	s.Equal(map[string]any{"and": map[string]any{"trailing": "x"}}, got["leading"])
+
+	var v []string
+	s.Error(s.codec.DecodeEntries(entries, &v))
+}
+
+// benchmarkEnviron returns n environment entries, nested like those of a typical service.
+func benchmarkEnviron(n int) []string {
+	entries := make([]string, n)
+	for i := range entries {
+		entries[i] = fmt.Sprintf("SERVICE%d_DATABASE_POOL_SIZE_%d=%d", i%50, i, i)
+	}
+	return entries
+}
+
+func BenchmarkEnvVarCodec_Decode(b *testing.B) {
+	data := []byte(strings.Join(benchmarkEnviron(5000), "\n"))
+	b.ReportAllocs()
+	for range b.N {
+		var v map[string]any
+		if err := (EnvVarCodec{}).Decode(data, &v); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
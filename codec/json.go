@@ -15,7 +15,12 @@
 // Package codec provides functionality for encoding and decoding data.
 package codec
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+)
 
 // TypeJSON is a constant representing the "json" encoding type.
 const TypeJSON Type = "json"
@@ -41,3 +46,106 @@ func (JSONCodec) Encode(v any) ([]byte, error) {
 func (JSONCodec) Decode(data []byte, v any) error {
 	return json.Unmarshal(data, v)
 }
+
+// DecodeReader decodes the JSON document read from r into the value pointed to by v. A document decoded into a
+// *map[string]any is decoded token by token, so only the decoded values are held in memory and not the document
+// itself; other values are decoded with a json.Decoder. The result is the same as that of Decode, which also
+// fails if the document is followed by anything other than whitespace.
+func (JSONCodec) DecodeReader(r io.Reader, v any) error {
+	dec := json.NewDecoder(r)
+	if m, ok := v.(*map[string]any); ok {
+		value, err := decodeJSONValue(dec)
+		if err != nil {
+			return err
+		}
+		switch value := value.(type) {
+		case nil:
+			*m = nil
+		case map[string]any:
+			if *m == nil {
+				*m = value
+				break
+			}
+			for k, v := range value {
+				(*m)[k] = v
+			}
+		default:
+			return &json.UnmarshalTypeError{Value: jsonKind(value), Type: reflect.TypeOf(*m), Offset: dec.InputOffset()}
+		}
+	} else if err := dec.Decode(v); err != nil {
+		if errors.Is(err, io.EOF) {
+			return io.ErrUnexpectedEOF
+		}
+		return err
+	}
+
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		if err != nil {
+			return err
+		}
+		return errors.New("invalid character after top-level value")
+	}
+	return nil
+}
+
+// decodeJSONValue decodes the next JSON value from dec into the types json.Unmarshal uses for an any.
+func decodeJSONValue(dec *json.Decoder) (any, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+
+	switch tok {
+	case json.Delim('{'):
+		object := make(map[string]any)
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeJSONValue(dec)
+			if err != nil {
+				return nil, err
+			}
+			object[key.(string)] = value
+		}
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+		return object, nil
+	case json.Delim('['):
+		array := []any{}
+		for dec.More() {
+			value, err := decodeJSONValue(dec)
+			if err != nil {
+				return nil, err
+			}
+			array = append(array, value)
+		}
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+		return array, nil
+	default:
+		return tok, nil
+	}
+}
+
+// jsonKind returns the JSON kind of a decoded value, as reported by json.UnmarshalTypeError.
+func jsonKind(v any) string {
+	switch v.(type) {
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "bool"
+	default:
+		return "object"
+	}
+}
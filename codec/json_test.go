@@ -15,6 +15,7 @@
 package codec
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
@@ -78,3 +79,44 @@ func (s *JSONCodecTestSuite) TestDecode_Error() {
 	err := s.codec.Decode([]byte(`{"foo":`), &v) // invalid JSON
 	s.Error(err)
 }
+
+func (s *JSONCodecTestSuite) TestDecodeReader() {
+	docs := []string{
+		`{"foo": "bar", "num": 42, "ratio": 0.5, "on": true, "off": null}`,
+		`{"nested": {"list": [1, "two", {"three": 3}, []], "empty": {}}}`,
+		`{"dup": 1, "dup": 2}`,
+		`  {"unicode": "h\u00e9llo"}` + "\n\t",
+		`null`,
+	}
+	for _, doc := range docs {
+		var want, got map[string]any
+		s.Require().NoError(s.codec.Decode([]byte(doc), &want))
+		s.Require().NoError(s.codec.DecodeReader(strings.NewReader(doc), &got), doc)
+		s.Equal(want, got, doc)
+	}
+}
+
+func (s *JSONCodecTestSuite) TestDecodeReader_MergesIntoExistingMap() {
+	v := map[string]any{"keep": true, "foo": "old"}
+	s.Require().NoError(s.codec.DecodeReader(strings.NewReader(`{"foo": "bar"}`), &v))
+	s.Equal(map[string]any{"keep": true, "foo": "bar"}, v)
+}
+
+func (s *JSONCodecTestSuite) TestDecodeReader_Struct() {
+	var v struct {
+		Foo string `json:"foo"`
+	}
+	s.Require().NoError(s.codec.DecodeReader(strings.NewReader(`{"foo": "bar"}`), &v))
+	s.Equal("bar", v.Foo)
+
+	s.Error(s.codec.DecodeReader(strings.NewReader(`{"foo": "bar"} {}`), &v))
+}
+
+func (s *JSONCodecTestSuite) TestDecodeReader_Error() {
+	docs := []string{``, `{"foo":`, `{"foo": "bar",}`, `{"foo": "bar"} x`, `{} {}`, `[1, 2]`, `"foo"`}
+	for _, doc := range docs {
+		var v map[string]any
+		s.Error(s.codec.Decode([]byte(doc), &v), doc)
+		s.Error(s.codec.DecodeReader(strings.NewReader(doc), &v), doc)
+	}
+}
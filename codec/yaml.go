@@ -15,7 +15,20 @@
 // Package codec provides functionality for encoding and decoding data.
 package codec
 
-import "github.com/goccy/go-yaml"
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/goccy/go-yaml/ast"
+	"github.com/goccy/go-yaml/parser"
+	"github.com/goccy/go-yaml/token"
+)
 
 // TypeYAML is a constant representing the "yaml" encoding type.
 const TypeYAML Type = "yaml"
@@ -27,6 +40,8 @@ func init() {
 }
 
 // YAMLCodec is a struct that implements the Codec interface for YAML encoding and decoding.
+// It does not implement StreamDecoder: the parser needs the whole document, and go-yaml's Decoder reads all of
+// its reader before parsing, so streaming would not lower the memory a decode uses. Files are read in full instead.
 type YAMLCodec struct{}
 
 // Encode encodes the given value 'v' to a YAML-encoded byte slice.
@@ -35,6 +50,145 @@ func (YAMLCodec) Encode(v any) ([]byte, error) {
 }
 
 // Decode decodes the YAML-encoded data into the value pointed to by v.
+// Values tagged with !!timestamp are decoded to time.Time and values tagged with !!binary to []byte.
+// Untagged timestamps stay strings, like RFC3339 strings in JSON sources.
+// Malformed tagged values are reported as errors instead of being decoded to zero values.
 func (YAMLCodec) Decode(data []byte, v any) error {
-	return yaml.Unmarshal(data, v)
+	if !bytes.Contains(data, []byte("!!timestamp")) && !bytes.Contains(data, []byte("!!binary")) {
+		return yaml.Unmarshal(data, v)
+	}
+
+	file, err := parser.ParseBytes(data, 0)
+	if err != nil {
+		return err
+	}
+	if len(file.Docs) == 0 || file.Docs[0].Body == nil {
+		return yaml.Unmarshal(data, v)
+	}
+
+	normalizer := &yamlTagNormalizer{}
+	ast.Walk(normalizer, file.Docs[0])
+	if normalizer.err != nil {
+		return normalizer.err
+	}
+
+	return yaml.NodeToValue(file.Docs[0].Body, v)
+}
+
+// yamlTimestampPattern matches the timestamp formats defined by the YAML timestamp type
+// (https://yaml.org/type/timestamp.html).
+var yamlTimestampPattern = regexp.MustCompile(
+	`^(\d{4})-(\d{1,2})-(\d{1,2})` +
+		`(?:(?:[Tt]|[ \t]+)(\d{1,2}):(\d{2}):(\d{2})(?:\.(\d*))?(?:[ \t]*(Z|[-+]\d{1,2}(?::\d{2})?))?)?$`)
+
+// yamlTagNormalizer validates !!timestamp and !!binary values and rewrites them to the canonical forms
+// understood by the YAML decoder, so every valid YAML timestamp and multi-line binary value decodes correctly.
+type yamlTagNormalizer struct {
+	err error
+}
+
+// Visit implements ast.Visitor.
+func (n *yamlTagNormalizer) Visit(node ast.Node) ast.Visitor {
+	if n.err != nil {
+		return nil
+	}
+	tag, ok := node.(*ast.TagNode)
+	if !ok {
+		return n
+	}
+
+	var normalize func(string) (string, error)
+	switch token.ReservedTagKeyword(tag.Start.Value) {
+	case token.TimestampTag:
+		normalize = normalizeYAMLTimestamp
+	case token.BinaryTag:
+		normalize = normalizeYAMLBinary
+	default:
+		return n
+	}
+
+	value := tagStringNode(tag)
+	if value == nil {
+		n.err = fmt.Errorf("line %d: %s value must be a string", tag.Start.Position.Line, tag.Start.Value)
+		return nil
+	}
+	normalized, err := normalize(value.Value)
+	if err != nil {
+		n.err = fmt.Errorf("line %d: invalid %s value: %w", tag.Start.Position.Line, tag.Start.Value, err)
+		return nil
+	}
+	value.Value = normalized
+
+	return n
+}
+
+// tagStringNode returns the string node holding the value of a tag, or nil if the value is not a string.
+func tagStringNode(tag *ast.TagNode) *ast.StringNode {
+	switch value := tag.Value.(type) {
+	case *ast.StringNode:
+		return value
+	case *ast.LiteralNode:
+		return value.Value
+	default:
+		return nil
+	}
+}
+
+// normalizeYAMLTimestamp parses a YAML timestamp and formats it as RFC3339.
+func normalizeYAMLTimestamp(s string) (string, error) {
+	t, err := parseYAMLTimestamp(strings.TrimSpace(s))
+	if err != nil {
+		return "", err
+	}
+	return t.Format(time.RFC3339Nano), nil
+}
+
+// parseYAMLTimestamp parses a timestamp in any of the formats allowed by the YAML timestamp type.
+// Timestamps without a time zone are in UTC.
+func parseYAMLTimestamp(s string) (time.Time, error) {
+	m := yamlTimestampPattern.FindStringSubmatch(s)
+	if m == nil {
+		return time.Time{}, fmt.Errorf("%q is not a valid timestamp", s)
+	}
+
+	atoi := func(s string) int {
+		i, _ := strconv.Atoi(s)
+		return i
+	}
+	year, month, day := atoi(m[1]), atoi(m[2]), atoi(m[3])
+	hour, minute, sec := atoi(m[4]), atoi(m[5]), atoi(m[6])
+
+	nsec := 0
+	if fraction := m[7]; fraction != "" {
+		if len(fraction) > 9 {
+			fraction = fraction[:9]
+		}
+		nsec = atoi(fraction + strings.Repeat("0", 9-len(fraction)))
+	}
+
+	loc := time.UTC
+	if zone := m[8]; zone != "" && zone != "Z" {
+		hours, minutes, _ := strings.Cut(zone[1:], ":")
+		offset := atoi(hours)*3600 + atoi(minutes)*60
+		if zone[0] == '-' {
+			offset = -offset
+		}
+		loc = time.FixedZone("", offset)
+	}
+
+	t := time.Date(year, time.Month(month), day, hour, minute, sec, nsec, loc)
+	if t.Month() != time.Month(month) || t.Day() != day || t.Hour() != hour || t.Minute() != minute || t.Second() != sec {
+		return time.Time{}, fmt.Errorf("%q is out of range", s)
+	}
+	return t, nil
+}
+
+// normalizeYAMLBinary validates base64-encoded binary data and removes the line breaks
+// that YAML allows inside it.
+func normalizeYAMLBinary(s string) (string, error) {
+	cleaned := strings.Join(strings.Fields(s), "")
+	if _, err := base64.StdEncoding.DecodeString(cleaned); err != nil {
+		return "", err
+	}
+	return cleaned, nil
 }
@@ -16,7 +16,9 @@
 package codec
 
 import (
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 )
@@ -227,3 +229,70 @@ func (s *YAMLCodecTestSuite) TestDecode_DeeplyNested() {
 	m := v["a"].(map[string]any)["b"].(map[string]any)["c"].(map[string]any)["d"].(map[string]any)
 	s.Assert().EqualValues(1, m["e"])
 }
+
+func (s *YAMLCodecTestSuite) TestDecode_TimestampTag() {
+	yamlStr := `date: !!timestamp 2024-01-02
+canonical: !!timestamp 2001-12-15T02:59:43.1Z
+spaced: !!timestamp 2001-12-14 21:59:43.10 -5
+quoted: !!timestamp "2001-12-14t21:59:43.10-05:00"
+untagged: 2024-01-02T03:04:05Z
+nested:
+  - !!timestamp 2024-06-30T12:00:00+02:00
+`
+	var v map[string]any
+	s.Require().NoError(s.codec.Decode([]byte(yamlStr), &v))
+
+	s.Assert().Equal(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), v["date"])
+
+	expected := time.Date(2001, 12, 15, 2, 59, 43, 100000000, time.UTC)
+	for _, key := range []string{"canonical", "spaced", "quoted"} {
+		t, ok := v[key].(time.Time)
+		s.Require().True(ok, "expected time.Time for %s, got %T", key, v[key])
+		s.Assert().True(expected.Equal(t), "unexpected time for %s: %v", key, t)
+	}
+
+	// Untagged timestamps stay strings, like RFC3339 strings in JSON.
+	s.Assert().Equal("2024-01-02T03:04:05Z", v["untagged"])
+
+	nested, ok := v["nested"].([]any)
+	s.Require().True(ok)
+	s.Assert().True(time.Date(2024, 6, 30, 10, 0, 0, 0, time.UTC).Equal(nested[0].(time.Time)))
+}
+
+func (s *YAMLCodecTestSuite) TestDecode_TimestampTag_Invalid() {
+	var v map[string]any
+	err := s.codec.Decode([]byte("date: !!timestamp 2024-13-02\n"), &v)
+	s.Require().Error(err)
+	s.Assert().Contains(err.Error(), "invalid !!timestamp value")
+
+	err = s.codec.Decode([]byte("date: !!timestamp yesterday\n"), &v)
+	s.Require().Error(err)
+	s.Assert().Contains(err.Error(), "is not a valid timestamp")
+}
+
+func (s *YAMLCodecTestSuite) TestDecode_BinaryTag() {
+	yamlStr := `inline: !!binary aGVsbG8=
+block: !!binary |
+  aGVs
+  bG8gd29ybGQ=
+`
+	var v map[string]any
+	s.Require().NoError(s.codec.Decode([]byte(yamlStr), &v))
+	s.Assert().Equal([]byte("hello"), v["inline"])
+	s.Assert().Equal([]byte("hello world"), v["block"])
+}
+
+func (s *YAMLCodecTestSuite) TestDecode_BinaryTag_Invalid() {
+	var v map[string]any
+	err := s.codec.Decode([]byte("data: !!binary '***'\n"), &v)
+	s.Require().Error(err)
+	s.Assert().Contains(err.Error(), "invalid !!binary value")
+}
+
+func (s *YAMLCodecTestSuite) TestDecodeReader() {
+	var v map[string]any
+	s.Require().NoError(DecodeReader(s.codec, strings.NewReader("foo: bar\nnum: 42\n"), &v))
+	s.Equal(map[string]any{"foo": "bar", "num": uint64(42)}, v)
+
+	s.Error(DecodeReader(s.codec, strings.NewReader("foo: [bar"), &v))
+}
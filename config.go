@@ -0,0 +1,133 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"go.companyinfo.dev/conflex/codec"
+)
+
+// Config describes a Conflex instance declaratively, as an alternative to functional options.
+// The sources and the schema can themselves be loaded from configuration, for example by binding a
+// YAML file that lists the sources to a Config; bindings and further options are set in code.
+type Config struct {
+	Sources    []SourceConfig `conflex:"sources"`     // Sources in the order they are merged
+	Schema     string         `conflex:"schema"`      // JSON Schema document to validate against, as with WithJSONSchema
+	SchemaFile string         `conflex:"schema_file"` // Path of a JSON Schema file, as an alternative to Schema
+	Bindings   []any          `conflex:"-"`           // Pointers to structs to bind, as with WithBinding
+	Options    []Option       `conflex:"-"`           // Further options, applied after everything else
+}
+
+// SourceConfig describes one source of a Config.
+type SourceConfig struct {
+	Name     string     `conflex:"name"`     // Name of the source, as with WithNamedSource; empty for an unnamed source
+	Type     string     `conflex:"type"`     // The kind of source: "file", "content", "env", "consul" or "http"
+	Path     string     `conflex:"path"`     // File path for "file" sources, key for "consul" sources
+	URL      string     `conflex:"url"`      // URL of "http" sources
+	Codec    codec.Type `conflex:"codec"`    // Codec of "file", "content", "consul" and "http" sources
+	Prefix   string     `conflex:"prefix"`   // Environment variable prefix for "env" sources
+	Content  string     `conflex:"content"`  // Configuration data for "content" sources
+	Optional bool       `conflex:"optional"` // Whether Load continues without the source, as with WithOptionalSource
+}
+
+// option returns the option that adds the described source.
+func (sc SourceConfig) option() Option {
+	var add Option
+	switch sc.Type {
+	case "file":
+		add = WithFileSource(sc.Path, sc.Codec)
+	case "content":
+		add = WithContentSource([]byte(sc.Content), sc.Codec)
+	case "env":
+		add = WithOSEnvVarSource(sc.Prefix)
+	case "consul":
+		add = WithConsulSource(sc.Path, sc.Codec)
+	case "http":
+		add = WithHTTPSource(sc.URL, sc.Codec)
+	case "":
+		return func(*Conflex) error { return errors.New("source type cannot be empty") }
+	default:
+		return func(*Conflex) error { return fmt.Errorf("unknown source type %q", sc.Type) }
+	}
+	if sc.Name == "" && !sc.Optional {
+		return add
+	}
+
+	return func(c *Conflex) error {
+		if sc.Name != "" {
+			if err := c.checkSourceName(sc.Name); err != nil {
+				return err
+			}
+		}
+		if err := add(c); err != nil {
+			return err
+		}
+		info := &c.sourceInfos[len(c.sourceInfos)-1]
+		info.Name = sc.Name
+		info.Optional = sc.Optional
+		return nil
+	}
+}
+
+// NewFromConfig creates a new Conflex instance from config. It is equivalent to calling NewStrict with
+// one option per source, the schema, one WithBinding per binding and then config.Options, but errors
+// name the part of config they come from, such as "sources[1]: unknown source type". All parts are
+// checked, and any errors are returned together with a nil instance.
+func NewFromConfig(config Config) (*Conflex, error) {
+	c, _ := New()
+
+	var errs error
+	apply := func(field string, option Option) {
+		if err := option(c); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("%s: %w", field, err))
+		}
+	}
+
+	for i, sc := range config.Sources {
+		apply(fmt.Sprintf("sources[%d]", i), sc.option())
+	}
+
+	switch {
+	case config.Schema != "" && config.SchemaFile != "":
+		errs = errors.Join(errs, errors.New("schema and schema_file cannot both be set"))
+	case config.Schema != "":
+		apply("schema", WithJSONSchema([]byte(config.Schema)))
+	case config.SchemaFile != "":
+		schema, err := os.ReadFile(config.SchemaFile)
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("schema_file: %w", err))
+		} else {
+			apply("schema_file", WithJSONSchema(schema))
+		}
+	}
+
+	for i, b := range config.Bindings {
+		apply(fmt.Sprintf("bindings[%d]", i), WithBinding(b))
+	}
+	for i, option := range config.Options {
+		if option == nil {
+			continue // Skip nil options, like New
+		}
+		apply(fmt.Sprintf("options[%d] (%s)", i, optionName(option)), option)
+	}
+
+	if errs != nil {
+		return nil, errs
+	}
+	return c, nil
+}
@@ -0,0 +1,156 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.companyinfo.dev/conflex/codec"
+)
+
+type NewFromConfigTestSuite struct {
+	suite.Suite
+}
+
+func TestNewFromConfigTestSuite(t *testing.T) {
+	suite.Run(t, new(NewFromConfigTestSuite))
+}
+
+func (s *NewFromConfigTestSuite) TestSourcesAndBindings() {
+	dir := s.T().TempDir()
+	path := filepath.Join(dir, "app.yaml")
+	s.Require().NoError(os.WriteFile(path, []byte("foo: from-file\nport: 8080\n"), 0o600))
+	s.T().Setenv("FROMCONFIG_PORT", "9090")
+
+	var cfg validatingBindStruct
+	c, err := NewFromConfig(Config{
+		Sources: []SourceConfig{
+			{Name: "defaults", Type: "content", Content: `{"foo": "default", "bar": 1}`, Codec: codec.TypeJSON},
+			{Type: "file", Path: path, Codec: codec.TypeYAML},
+			{Name: "env", Type: "env", Prefix: "FROMCONFIG_"},
+		},
+		Bindings: []any{&cfg},
+	})
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	s.Equal("from-file", cfg.Foo)
+	s.Equal(1, c.GetInt("bar"))
+	s.Equal(9090, c.GetInt("port"))
+
+	sources := c.Sources()
+	s.Require().Len(sources, 3)
+	s.Equal("defaults", sources[0].Name)
+	s.Equal("source[1]", sources[1].Name)
+	s.Equal("file", sources[1].Type)
+	s.Equal("env", sources[2].Name)
+}
+
+func (s *NewFromConfigTestSuite) TestFromYAMLWiring() {
+	wiringYAML := []byte(`
+sources:
+  - name: base
+    type: content
+    codec: yaml
+    content: |
+      foo: bar
+schema: '{"type": "object", "required": ["foo"]}'
+`)
+	var wiring Config
+	boot, err := NewStrict(WithContentSource(wiringYAML, codec.TypeYAML), WithBinding(&wiring))
+	s.Require().NoError(err)
+	s.Require().NoError(boot.Load(context.Background()))
+
+	var cfg validatingBindStruct
+	wiring.Bindings = []any{&cfg}
+	c, err := NewFromConfig(wiring)
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	s.Equal("bar", cfg.Foo)
+	s.NotNil(c.JSONSchema())
+	s.Equal("base", c.Sources()[0].Name)
+}
+
+func (s *NewFromConfigTestSuite) TestSchemaFile() {
+	path := filepath.Join(s.T().TempDir(), "schema.json")
+	s.Require().NoError(os.WriteFile(path, []byte(`{"type": "object", "required": ["foo"]}`), 0o600))
+
+	c, err := NewFromConfig(Config{
+		Sources:    []SourceConfig{{Type: "content", Content: `{}`, Codec: codec.TypeJSON}},
+		SchemaFile: path,
+	})
+	s.Require().NoError(err)
+	s.Error(c.Load(context.Background()))
+}
+
+func (s *NewFromConfigTestSuite) TestOptions() {
+	var called bool
+	c, err := NewFromConfig(Config{
+		Sources: []SourceConfig{{Type: "content", Content: `{"foo": "bar"}`, Codec: codec.TypeJSON}},
+		Options: []Option{nil, WithValidator(func(map[string]any) error {
+			called = true
+			return nil
+		})},
+	})
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+	s.True(called)
+}
+
+func (s *NewFromConfigTestSuite) TestErrors() {
+	c, err := NewFromConfig(Config{
+		Sources: []SourceConfig{
+			{Type: "ftp"},
+			{},
+			{Type: "file", Path: "app.conf", Codec: "ini"},
+			{Name: "a", Type: "env"},
+			{Name: "a", Type: "env"},
+		},
+		Schema:     `{}`,
+		SchemaFile: "schema.json",
+		Bindings:   []any{nil},
+		Options:    []Option{WithPollInterval(-1)},
+	})
+	s.Require().Error(err)
+	s.Nil(c)
+
+	s.Contains(err.Error(), `sources[0]: unknown source type "ftp"`)
+	s.Contains(err.Error(), "sources[1]: source type cannot be empty")
+	s.Contains(err.Error(), "sources[2]: ")
+	s.Contains(err.Error(), `sources[4]: duplicate source name "a"`)
+	s.NotContains(err.Error(), "sources[3]")
+	s.Contains(err.Error(), "schema and schema_file cannot both be set")
+	s.Contains(err.Error(), "bindings[0]: ")
+	s.Contains(err.Error(), "options[0] (WithPollInterval): ")
+}
+
+func (s *NewFromConfigTestSuite) TestOptionalSource() {
+	c, err := NewFromConfig(Config{
+		Sources: []SourceConfig{
+			{Type: "content", Content: `{"foo": "bar"}`, Codec: codec.TypeJSON},
+			{Type: "file", Path: filepath.Join(s.T().TempDir(), "missing.json"), Codec: codec.TypeJSON, Optional: true},
+		},
+	})
+	s.Require().NoError(err)
+	s.True(c.Sources()[1].Optional)
+	s.Equal("source[1]", c.Sources()[1].Name)
+	s.Require().NoError(c.Load(context.Background()))
+	s.Equal("bar", c.GetString("foo"))
+}
@@ -19,14 +19,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"math/rand"
+	"log/slog"
+	"net/url"
+	"os"
 	"reflect"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
-	"bytes"
-
 	"dario.cat/mergo"
 	"github.com/go-viper/mapstructure/v2"
 	"github.com/santhosh-tekuri/jsonschema/v6"
@@ -34,6 +35,7 @@ import (
 	"go.companyinfo.dev/conflex/codec"
 	"go.companyinfo.dev/conflex/dumper"
 	"go.companyinfo.dev/conflex/source"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Option is a functional option that can be used to configure a Conflex instance.
@@ -44,14 +46,75 @@ type Option func(c *Conflex) error
 // The sources field is a slice of Source instances that are used to load the configuration data.
 // The mu field is a sync.RWMutex that is used to synchronize access to the configuration data.
 type Conflex struct {
-	values             *map[string]any
-	sources            []Source
-	dumpers            []Dumper
-	binding            any
-	mu                 sync.RWMutex
-	jsonSchema         string
-	jsonSchemaCompiled *jsonschema.Schema
-	customValidators   []func(map[string]any) error
+	values              *map[string]any
+	sources             []Source
+	dumpers             []Dumper
+	dumperNames         []string
+	tracerProvider      trace.TracerProvider
+	hooks               []Hooks
+	checksumMu          sync.Mutex
+	checksumVersion     uint64
+	checksums           map[checksumOptions]string
+	loadReport          LoadReport
+	bindings            []binding
+	mu                  sync.RWMutex
+	loadMu              sync.Mutex // Serializes loads, from reading the cached results to applying the result
+	jsonSchema          string
+	jsonSchemaCompiled  *jsonschema.Schema
+	customValidators    []func(map[string]any) error
+	structValidator     StructValidator
+	requiredKeys        []string
+	defaults            []tagDefault
+	deprecatedKeys      []deprecatedKey
+	deprecationsWarned  map[string]bool
+	pollInterval        time.Duration
+	changeHandlers      []*changeHandler
+	diffHandlers        []func(changes []ChangeEvent)
+	rebindHandlers      []func(values map[string]any) error
+	subscriptions       []*subscription
+	restartKeys         []string
+	restartHandlers     []func(keys []string)
+	restartPending      string
+	loaded              bool
+	sensitivityRules    []sensitivityRule
+	logger              *slog.Logger
+	mergeConflictPolicy MergeConflictPolicy
+	watchMu             sync.Mutex
+	watchRun            *watchRun
+	watchDebounce       time.Duration
+	watchBackoff        *Backoff
+	watchErrorHandlers  []func(err error, retryIn time.Duration)
+	autoReloadInterval  time.Duration
+	roundTrip           bool
+	caseSensitiveKeys   bool
+	sliceSeparator      string
+	envBindings         []envBinding
+	envExpansion        bool
+	keyReferences       bool
+	secretProviders     map[string]SecretProvider
+	secretLeaseMu       sync.Mutex
+	secretExpiry        time.Time
+	secretRefreshAt     time.Time
+	secretLeaseChanged  chan struct{}
+	secretRefreshOnce   sync.Once
+	redactedKeys        []string
+	sourceResults       []map[string]any
+	keyOrigins          map[string]string
+	version             uint64
+	sourceInfos         []SourceInfo
+	profiles            []string
+	sourceValidations   map[int]sourceValidation
+	lazySources         map[int]*lazySource
+	envKeyReplacer      func(string) string
+	expvars             []expvarRequest
+	autoReloadOnce      sync.Once
+	lifecycleMu         sync.Mutex
+	closed              bool
+	frozen              bool
+	done                chan struct{}
+	background          sync.WaitGroup
+	reloadErrorHandlers []func(err error)
+	reloadStatus        ReloadStatus
 	// Performance optimizations
 	decoderConfig *mapstructure.DecoderConfig
 	decoderOnce   sync.Once
@@ -63,7 +126,7 @@ func WithSource(loader Source) Option {
 		if loader == nil {
 			return errors.New("source cannot be nil")
 		}
-		c.sources = append(c.sources, loader)
+		c.addSource(loader, describeCustomSource(loader))
 		return nil
 	}
 }
@@ -74,7 +137,7 @@ func WithDumper(dumper Dumper) Option {
 		if dumper == nil {
 			return errors.New("dumper cannot be nil")
 		}
-		c.dumpers = append(c.dumpers, dumper)
+		c.addDumper(dumper, "")
 		return nil
 	}
 }
@@ -87,7 +150,7 @@ func WithFileDumper(path string, codecType codec.Type) Option {
 			return NewConfigError("file-dumper", "get-encoder", err)
 		}
 
-		c.dumpers = append(c.dumpers, dumper.NewFile(path, encoder))
+		c.addDumper(dumper.NewFile(path, encoder), "")
 		return nil
 	}
 }
@@ -100,7 +163,11 @@ func WithFileSource(path string, codecType codec.Type) Option {
 			return NewConfigError("file-source", "get-decoder", err)
 		}
 
-		c.sources = append(c.sources, source.NewFile(path, decoder))
+		c.addSource(source.NewFile(path, decoder), SourceInfo{
+			Type:    "file",
+			Target:  path,
+			Options: map[string]string{"codec": string(codecType)},
+		})
 		return nil
 	}
 }
@@ -113,16 +180,68 @@ func WithContentSource(data []byte, codecType codec.Type) Option {
 			return NewConfigError("content-source", "get-decoder", err)
 		}
 
-		c.sources = append(c.sources, source.NewFileContent(data, decoder))
+		c.addSource(source.NewFileContent(data, decoder), SourceInfo{
+			Type:    "content",
+			Options: map[string]string{"codec": string(codecType), "size": strconv.Itoa(len(data))},
+		})
 		return nil
 	}
 }
 
 // WithOSEnvVarSource returns an Option that configures the Conflex instance to load configuration data from environment variables.
 // The prefix parameter specifies the prefix for the environment variables to be loaded.
+// Variable names are mapped to keys with the function given to WithEnvKeyReplacer, if any.
 func WithOSEnvVarSource(prefix string) Option {
 	return func(c *Conflex) error {
-		c.sources = append(c.sources, source.NewOSEnvVar(prefix))
+		src := source.NewOSEnvVar(prefix)
+		if c.envKeyReplacer != nil {
+			src.WithKeyReplacer(c.envKeyReplacer)
+		}
+		c.addSource(src, SourceInfo{Type: "env", Target: prefix})
+		return nil
+	}
+}
+
+// WithEnvKeyReplacer sets the function that maps variable names, without the prefix, to dot-separated key paths
+// for the sources added with WithOSEnvVarSource, before or after this option; see source.OSEnvVar.WithKeyReplacer.
+// To nest on double underscores only, so that DATABASE__MAX_CONNS becomes database.max_conns:
+//
+//	conflex.WithEnvKeyReplacer(strings.NewReplacer("__", ".").Replace)
+func WithEnvKeyReplacer(replacer func(string) string) Option {
+	return func(c *Conflex) error {
+		if replacer == nil {
+			return errors.New("env key replacer cannot be nil")
+		}
+		c.envKeyReplacer = replacer
+		for i, info := range c.sourceInfos {
+			if src, ok := c.sources[i].(*source.OSEnvVar); ok && info.Type == "env" {
+				src.WithKeyReplacer(replacer)
+			}
+		}
+		return nil
+	}
+}
+
+// WithHTTPSource returns an Option that configures the Conflex instance to load configuration data from an HTTP(S)
+// URL. Loads are conditional requests, so polling a document that did not change neither downloads nor decodes it
+// again (see source.HTTP). Credentials in the URL are not included in the description returned by Sources.
+func WithHTTPSource(rawURL string, codecType codec.Type) Option {
+	return func(c *Conflex) error {
+		decoder, err := codec.GetDecoder(codecType)
+		if err != nil {
+			return NewConfigError("http-source", "get-decoder", err)
+		}
+
+		l, err := source.NewHTTP(rawURL, decoder, nil)
+		if err != nil {
+			return NewConfigError("http-source", "create-client", err)
+		}
+
+		target := rawURL
+		if u, err := url.Parse(rawURL); err == nil {
+			target = u.Redacted()
+		}
+		c.addSource(l, SourceInfo{Type: "http", Target: target, Options: map[string]string{"codec": string(codecType)}})
 		return nil
 	}
 }
@@ -145,50 +264,95 @@ func WithConsulSource(path string, codecType codec.Type) Option {
 			return NewConfigError("consul-source", "create-client", err)
 		}
 
-		c.sources = append(c.sources, l)
+		options := map[string]string{"codec": string(codecType)}
+		if addr := os.Getenv("CONSUL_HTTP_ADDR"); addr != "" {
+			options["address"] = addr
+		}
+		c.addSource(l, SourceInfo{Type: "consul", Target: path, Options: options})
 
 		return nil
 	}
 }
 
 // WithBinding returns an Option that configures the Conflex instance to bind configuration data to a struct.
+// It can be used several times, for example with one struct per subsystem: every binding is validated and
+// decoded on each Load, and a Load only succeeds if all of them accept the configuration.
 func WithBinding(v any) Option {
 	return func(c *Conflex) error {
-		if v == nil {
-			return errors.New("binding target cannot be nil")
-		}
-		if reflect.TypeOf(v).Kind() != reflect.Ptr {
-			return errors.New("binding target must be a pointer")
-		}
-		c.binding = v
-		return nil
+		return c.addBinding("", v)
 	}
 }
 
-// WithJSONSchema adds a JSON Schema for validation.
-func WithJSONSchema(schema []byte) Option {
+// WithBindingAt is like WithBinding, but binds only the subtree below prefix, so that a subsystem package
+// can own the type of its configuration without a root struct that knows every subsystem:
+// WithBindingAt("server", &serverConfig) decodes server.port into the field tagged "port".
+// Keys derived from struct tags, such as required keys and defaults, are below the prefix as well.
+// If there is no value at prefix, the target is decoded from an empty map.
+func WithBindingAt(prefix string, v any) Option {
 	return func(c *Conflex) error {
-		// Use a unique schema name to avoid caching issues
-		schemaName := fmt.Sprintf("inline_%d.json", rand.Int())
-		compiler := jsonschema.NewCompiler()
-
-		jsonSchema, err := jsonschema.UnmarshalJSON(bytes.NewReader(schema))
-		if err != nil {
-			return err
+		if strings.TrimSpace(prefix) == "" {
+			return errors.New("binding prefix cannot be empty")
 		}
+		return c.addBinding(prefix, v)
+	}
+}
 
-		if err := compiler.AddResource(schemaName, jsonSchema); err != nil {
-			return err
-		}
-		s, err := compiler.Compile(schemaName)
+// addBinding adds v as a binding of the values below prefix, or of all values if prefix is empty.
+func (c *Conflex) addBinding(prefix string, v any) error {
+	if v == nil {
+		return errors.New("binding target cannot be nil")
+	}
+	t := reflect.TypeOf(v)
+	if t.Kind() != reflect.Ptr {
+		return errors.New("binding target must be a pointer")
+	}
+	rules, err := sensitivityRulesFromTags(t, prefix)
+	if err != nil {
+		return NewConfigError("binding", "parse-tags", err)
+	}
+	defaults, err := defaultsFromTags(t, prefix)
+	if err != nil {
+		return NewConfigError("binding", "parse-tags", err)
+	}
+	c.bindings = append(c.bindings, binding{target: v, prefix: prefix})
+	c.sensitivityRules = append(c.sensitivityRules, rules...)
+	c.redactedKeys = append(c.redactedKeys, secretKeysFromTags(t, prefix)...)
+	c.requiredKeys = append(c.requiredKeys, requiredKeysFromTags(t, prefix)...)
+	c.defaults = append(c.defaults, defaults...)
+	return nil
+}
+
+// WithJSONSchema adds a JSON Schema for validation. The schema is compiled with CompileJSONSchema,
+// using the given options.
+func WithJSONSchema(schema []byte, options ...SchemaOption) Option {
+	return func(c *Conflex) error {
+		s, err := CompileJSONSchema(schema, options...)
 		if err != nil {
 			return err
 		}
+		c.jsonSchema = string(schema)
 		c.jsonSchemaCompiled = s
 		return nil
 	}
 }
 
+// WithCompiledJSONSchema adds an already compiled JSON Schema for validation.
+// Use it together with CompileJSONSchema to compile a schema once and reuse it across instances.
+func WithCompiledJSONSchema(schema *jsonschema.Schema) Option {
+	return func(c *Conflex) error {
+		if schema == nil {
+			return errors.New("schema cannot be nil")
+		}
+		c.jsonSchemaCompiled = schema
+		return nil
+	}
+}
+
+// JSONSchema returns the compiled JSON Schema used for validation, or nil if none is configured.
+func (c *Conflex) JSONSchema() *jsonschema.Schema {
+	return c.jsonSchemaCompiled
+}
+
 // WithValidator adds a custom validation function.
 func WithValidator(fn func(map[string]any) error) Option {
 	return func(c *Conflex) error {
@@ -197,27 +361,70 @@ func WithValidator(fn func(map[string]any) error) Option {
 	}
 }
 
+// WithLogger sets the logger used to report background activity, such as reloads triggered by ReloadOnSignal.
+// If no logger is configured, slog.Default() is used.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *Conflex) error {
+		if logger == nil {
+			return errors.New("logger cannot be nil")
+		}
+		c.logger = logger
+		return nil
+	}
+}
+
+// log returns the configured logger, or slog.Default() if none is set.
+func (c *Conflex) log() *slog.Logger {
+	if c.logger != nil {
+		return c.logger
+	}
+	return slog.Default()
+}
+
 // New creates a new Conflex instance with the provided options.
 // It iterates through the options and applies each one to the Conflex instance.
-// If any of the options return an error, the errors are collected and returned.
+// If any of the options return an error, the instance is still returned together with
+// a *PartialInitError listing the failed options.
 func New(options ...Option) (*Conflex, error) {
-	var errs error
 	c := &Conflex{
 		values:  &map[string]any{},
 		sources: []Source{},
 	}
 
-	for _, option := range options {
+	var failed []*OptionError
+	for i, option := range options {
 		if option == nil {
 			continue // Skip nil options
 		}
-		err := option(c)
-		if err != nil {
-			errs = errors.Join(errs, err)
+		requested := len(c.expvars)
+		if err := option(c); err != nil {
+			failed = append(failed, &OptionError{Index: i, Name: optionName(option), Err: err})
+			c.expvars = c.expvars[:requested]
+			continue
 		}
+		for j := requested; j < len(c.expvars); j++ {
+			c.expvars[j].index, c.expvars[j].option = i, optionName(option)
+		}
+	}
+
+	// Expvar variables cannot be unpublished, so they are only published by a successful construction.
+	if len(failed) == 0 && len(c.expvars) > 0 {
+		failed = c.publishExpvars()
 	}
 
-	return c, errs
+	if len(failed) > 0 {
+		return c, &PartialInitError{Options: failed}
+	}
+	return c, nil
+}
+
+// NewStrict is like New, but returns a nil instance if any of the options fail.
+func NewStrict(options ...Option) (*Conflex, error) {
+	c, err := New(options...)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
 }
 
 // Validator is an interface for structs that can validate their own configuration.
@@ -228,21 +435,49 @@ type Validator interface {
 // getDecoderConfig returns a cached decoder configuration to reduce reflection overhead.
 func (c *Conflex) getDecoderConfig() *mapstructure.DecoderConfig {
 	c.decoderOnce.Do(func() {
-		c.decoderConfig = &mapstructure.DecoderConfig{
-			TagName:          "conflex",
-			Squash:           true,
-			WeaklyTypedInput: true,
-			DecodeHook: mapstructure.ComposeDecodeHookFunc(
-				mapstructure.StringToTimeDurationHookFunc(),
-				mapstructure.StringToSliceHookFunc(","),
-				mapstructure.StringToTimeHookFunc(time.RFC3339),
-				mapstructure.StringToURLHookFunc(),
-			),
-		}
+		c.decoderConfig = newDecoderConfig(c.sliceSeparator)
 	})
 	return c.decoderConfig
 }
 
+// newDecoderConfig returns the decoder configuration used to bind configuration data to structs.
+// If sliceSeparator is set, strings are split on it into slices of any type; otherwise only []string
+// targets are split, on commas.
+func newDecoderConfig(sliceSeparator string) *mapstructure.DecoderConfig {
+	splitHook := mapstructure.StringToSliceHookFunc(",")
+	if sliceSeparator != "" {
+		splitHook = splitStringHookFunc(sliceSeparator)
+	}
+
+	return &mapstructure.DecoderConfig{
+		TagName:          "conflex",
+		Squash:           true,
+		WeaklyTypedInput: true,
+		DecodeHook: mapstructure.ComposeDecodeHookFunc(
+			mapstructure.StringToTimeDurationHookFunc(),
+			splitHook,
+			unixTimeHookFunc(),
+			byteSizeHookFunc(),
+			indexedMapHookFunc(),
+			omittedFieldsHookFunc(),
+			stdTypesHookFunc(),
+			mapstructure.StringToTimeHookFunc(time.RFC3339),
+			mapstructure.StringToURLHookFunc(),
+		),
+	}
+}
+
+// WithCaseSensitiveKeys disables the lowercasing of configuration keys, for configurations in which keys that
+// differ only in case are distinct, such as Kubernetes annotations. Keys from sources are kept as they are,
+// and lookups, defaults, deprecations and other keys given to options must match them exactly.
+// Struct binding still matches field names case-insensitively when there is no exact match.
+func WithCaseSensitiveKeys() Option {
+	return func(c *Conflex) error {
+		c.caseSensitiveKeys = true
+		return nil
+	}
+}
+
 // normalizeMapKeys recursively converts all map keys to lowercase for case-insensitive merging
 func normalizeMapKeys(m map[string]any) map[string]any {
 	if m == nil {
@@ -260,35 +495,118 @@ func normalizeMapKeys(m map[string]any) map[string]any {
 	return normalized
 }
 
-// loadSourcesSequential loads configuration data from all sources sequentially to avoid race conditions.
-func (c *Conflex) loadSourcesSequential(ctx context.Context) (map[string]any, error) {
-	if len(c.sources) == 0 {
-		return make(map[string]any), nil
+// normalizeKey normalizes a key path for lookups in the values map.
+// Keys are stored in lowercase, so lookups are case-insensitive, unless WithCaseSensitiveKeys is used.
+func (c *Conflex) normalizeKey(key string) string {
+	if c.caseSensitiveKeys {
+		return key
 	}
+	return strings.ToLower(key)
+}
 
-	// Merge in order to maintain precedence
-	newValues := make(map[string]any)
+// sourceName returns the name of the source at index i, as used in errors and by Reload.
+// Sources added with WithNamedSource use their name; the others are named after their index.
+func (c *Conflex) sourceName(i int) string {
+	if i < len(c.sourceInfos) && c.sourceInfos[i].Name != "" {
+		return c.sourceInfos[i].Name
+	}
+	return fmt.Sprintf("source[%d]", i)
+}
+
+// loadSources loads configuration data from all sources sequentially to avoid race conditions.
+// The results are returned in source order, with keys normalized. If cached is not nil, sources with a
+// non-nil cached result are not loaded again and their cached result is used instead. If report is not nil,
+// the outcome of every source is added to it.
+func (c *Conflex) loadSources(ctx context.Context, cached []map[string]any, report *LoadReport) ([]map[string]any, error) {
+	results := make([]map[string]any, len(c.sources))
 	for i, source := range c.sources {
 		if ctx.Err() != nil {
 			return nil, ctx.Err()
 		}
 
-		conf, err := source.Load(ctx)
+		if c.skipLazySource(i) {
+			continue
+		}
+
+		if i < len(cached) && cached[i] != nil {
+			results[i] = cached[i]
+			c.recordSourceLoad(report, i, SourceLoad{Size: -1, Cached: true}, cached[i])
+			continue
+		}
+
+		sourceCtx, span := c.startSourceSpan(ctx, i)
+		start := time.Now()
+		conf, err := source.Load(sourceCtx)
+		load := SourceLoad{Duration: time.Since(start), Size: -1, Err: err}
+		if sized, ok := source.(sizedSource); ok && err == nil {
+			load.Size = sized.Size()
+			span.SetAttributes(AttributeSourceBytes.Int(load.Size))
+		}
+		endSpan(span, err)
+		c.callSourceLoadedHooks(ctx, i, load.Duration, err)
 		if err != nil {
-			return nil, NewConfigError(fmt.Sprintf("source[%d]", i), "load", err)
+			if !c.sourceInfos[i].Optional || ctx.Err() != nil {
+				c.recordSourceLoad(report, i, load, nil)
+				return nil, NewConfigError(c.sourceName(i), "load", err)
+			}
+			// An unavailable optional source keeps its last successful result. Without one, the result stays
+			// nil, so the source is loaded again by the next Reload of any source.
+			c.log().Warn("optional configuration source failed to load", "source", c.sourceName(i), "error", err)
+			results[i] = c.previousSourceResult(i)
+			c.recordSourceLoad(report, i, load, results[i])
+			continue
 		}
 
 		// Ensure we always have a valid map, even if source returns nil
 		if conf == nil {
 			conf = make(map[string]any)
 		}
+		conf = c.mountLazySource(i, conf)
+
+		// Normalize keys to lowercase for case-insensitive merging. Case-sensitive keys are copied instead,
+		// so later stages never modify the map the source returned.
+		if c.caseSensitiveKeys {
+			results[i] = copyMap(conf)
+		} else {
+			results[i] = normalizeMapKeys(conf)
+		}
 
-		// Normalize keys to lowercase for case-insensitive merging
-		normalizedConf := normalizeMapKeys(conf)
+		if err := c.validateSource(i, results[i]); err != nil {
+			load.Err = err
+			c.recordSourceLoad(report, i, load, results[i])
+			return nil, err
+		}
+		c.recordSourceLoad(report, i, load, results[i])
+	}
+
+	return results, nil
+}
+
+// previousSourceResult returns the result of source i from the last successful load, or nil if there is none.
+func (c *Conflex) previousSourceResult(i int) map[string]any {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if i < len(c.sourceResults) {
+		return c.sourceResults[i]
+	}
+	return nil
+}
+
+// mergeSources merges the results of loadSources in order, so later sources take precedence.
+// The results are copied before merging and are left untouched.
+func (c *Conflex) mergeSources(results []map[string]any) (map[string]any, error) {
+	newValues := make(map[string]any)
+	for i, result := range results {
+		conf := copyMap(result)
+
+		if err := c.resolveMergeConflicts(newValues, conf); err != nil {
+			return nil, NewConfigError(c.sourceName(i), "merge", err)
+		}
 
 		// Use mergo to merge configuration maps with override behavior
-		if err := mergo.Map(&newValues, normalizedConf, mergo.WithOverride); err != nil {
-			return nil, NewConfigError(fmt.Sprintf("source[%d]", i), "merge", err)
+		if err := mergo.Map(&newValues, conf, mergo.WithOverride); err != nil {
+			return nil, NewConfigError(c.sourceName(i), "merge", err)
 		}
 	}
 
@@ -297,25 +615,198 @@ func (c *Conflex) loadSourcesSequential(ctx context.Context) (map[string]any, er
 
 // Load loads configuration data from the registered sources and merges it into the internal values map.
 // The method validates the configuration data (including binding validation) before acquiring a write lock
-// to atomically update the values map. If any of the sources fail to load or validate, it returns an error.
+// to atomically update the values map. If any of the sources fail to load or validate, it returns an error
+// and the current configuration, including the bound struct, is left untouched.
+// The outcome is recorded in the status returned by ReloadStatus.
 func (c *Conflex) Load(ctx context.Context) error {
+	if c.Frozen() {
+		return ErrFrozen
+	}
+	if ctx == nil {
+		return errors.New("context cannot be nil")
+	}
+
+	ctx, span := c.startSpan(ctx, "conflex.Load")
+	err := c.runLoad(ctx, nil)
+	endSpan(span, err)
+	return err
+}
+
+// Reload loads the source with the given name again and merges its result with the results of the other
+// sources from the previous load, without loading those again. This avoids hitting slow remote backends
+// when only a local file changed. Sources added with WithNamedSource are addressed by their name; the others
+// are named "source[0]", "source[1]", and so on, after their position among all sources.
+// Sources that have not been loaded successfully before are loaded as well.
+// Like Load, Reload validates the merged configuration and leaves the current one untouched on failure.
+// Loads and reloads, including those started by Watch, auto reload and secret refreshes, run one at a time, so
+// a reload always starts from the results applied by the previous one and never reverts a newer result.
+func (c *Conflex) Reload(ctx context.Context, sourceName string) error {
+	if c.Frozen() {
+		return ErrFrozen
+	}
 	if ctx == nil {
 		return errors.New("context cannot be nil")
 	}
 
-	newValues, err := c.loadSourcesSequential(ctx)
+	index := -1
+	for i := range c.sources {
+		if c.sourceName(i) == sourceName {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return fmt.Errorf("unknown source %q", sourceName)
+	}
+
+	ctx, span := c.startSpan(ctx, "conflex.Reload", AttributeSourceName.String(sourceName))
+	err := c.runLoad(ctx, func() []map[string]any {
+		cached := c.cachedResults()
+		cached[index] = nil
+		return cached
+	})
+	endSpan(span, err)
+	return err
+}
+
+// finishLoad records the outcome of a load and starts the auto reload timer after the first successful one.
+func (c *Conflex) finishLoad(err error) error {
+	c.recordLoad(err)
+	if err == nil {
+		c.startAutoReload()
+	}
+	return err
+}
+
+// load implements Load and Reload. If cached is not nil, it returns the results to reuse, as passed to resolve;
+// sources with a result there are not loaded again. The outcome of every source is added to report, if it is
+// not nil. Change and rebind handlers are called once the result is applied and the next load may start.
+func (c *Conflex) load(ctx context.Context, cached func() []map[string]any, report *LoadReport) error {
+	res, oldValues, err := c.loadAndApply(ctx, cached, report)
 	if err != nil {
 		return err
 	}
 
-	// Ensure newValues is never nil
-	if newValues == nil {
-		newValues = make(map[string]any)
+	c.scheduleSecretRefresh(res.secretTTL)
+	c.notifyChange(oldValues, res.values)
+	c.notifyRebind(res.values)
+
+	return nil
+}
+
+// loadAndApply resolves and applies the configuration for load, returning the resolution and the previous
+// values. It holds loadMu, so loads run one at a time: the cached results are read only after the previous
+// load applied its own, and a slow load cannot overwrite the result of a load that started after it.
+func (c *Conflex) loadAndApply(ctx context.Context, cached func() []map[string]any, report *LoadReport) (*resolution, map[string]any, error) {
+	c.loadMu.Lock()
+	defer c.loadMu.Unlock()
+
+	var results []map[string]any
+	if cached != nil {
+		results = cached()
+	}
+	res, err := c.resolve(ctx, results, true, report)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := c.deferRestartRequired(res); err != nil {
+		return nil, nil, err
+	}
+
+	oldValues, err := c.apply(res)
+	if err != nil {
+		return nil, nil, err
+	}
+	return res, oldValues, nil
+}
+
+// cachedResults returns a copy of the per-source results of the last load, for loads that reuse them.
+// The caller must hold loadMu, so the results are not replaced before its own are applied.
+func (c *Conflex) cachedResults() []map[string]any {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	cached := make([]map[string]any, len(c.sources))
+	copy(cached, c.sourceResults)
+	return cached
+}
+
+// Validate loads all sources and runs all validation, exactly like Load, but does not apply the result:
+// the current values, the binding, the reload status and the change handlers are left untouched.
+// It is meant for pre-flight checks, such as validating configuration in CI or in an admission webhook
+// before rolling it out. Restart-required keys are not checked, since nothing is reloaded.
+func (c *Conflex) Validate(ctx context.Context) error {
+	_, err := c.resolve(ctx, nil, false, nil)
+	return err
+}
+
+// resolution is the outcome of resolve: a configuration ready to be applied.
+type resolution struct {
+	values    map[string]any    // The merged and validated values
+	results   []map[string]any  // The per-source results
+	origins   map[string]string // The origin of every leaf value, as returned by Origin
+	secretTTL time.Duration     // Time until the first leased secret expires, or 0 if none does
+}
+
+// resolve loads and merges the sources, applies deprecations and defaults, and validates the result.
+// It returns the new values and the per-source results without applying them. Sources with a result in
+// cached are not loaded again. Warnings about deprecated keys are only logged if warn is set. The outcome of
+// every source is added to report, if it is not nil.
+func (c *Conflex) resolve(ctx context.Context, cached []map[string]any, warn bool, report *LoadReport) (*resolution, error) {
+	if ctx == nil {
+		return nil, errors.New("context cannot be nil")
+	}
+
+	results, err := c.loadSources(ctx, cached, report)
+	if err != nil {
+		return nil, err
+	}
+
+	newValues, err := c.mergeSources(results)
+	if err != nil {
+		return nil, err
+	}
+	envKeys := c.applyEnvBindings(newValues)
+	c.applyDeprecations(newValues, warn)
+	c.applyDefaults(newValues)
+	origins := c.valueOrigins(results, newValues, envKeys)
+
+	if c.envExpansion {
+		newValues = expandEnv(newValues)
+	}
+	if c.keyReferences {
+		if newValues, err = c.resolveReferences(newValues); err != nil {
+			return nil, err
+		}
+	}
+	var secretTTL time.Duration
+	if c.hasSecretProviders() {
+		if newValues, secretTTL, err = c.resolveSecrets(ctx, newValues); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.roundTrip {
+		newValues = canonicalValues(newValues)
 	}
 
+	if err := c.validate(newValues, origins); err != nil {
+		return nil, err
+	}
+
+	return &resolution{values: newValues, results: results, origins: origins, secretTTL: secretTTL}, nil
+}
+
+// validate runs the JSON Schema, the custom validators and the binding validation on values without
+// modifying any state. Every validator runs, so all problems are reported at once: the errors are joined,
+// each one a ConfigError naming the validator that reported it. The ValidationErrors they carry are
+// attributed to the origin of the offending value, as found in origins.
+func (c *Conflex) validate(values map[string]any, origins map[string]string) error {
+	var errs []error
+
 	if c.jsonSchemaCompiled != nil {
-		if err := c.jsonSchemaCompiled.Validate(newValues); err != nil {
-			return NewConfigError("json-schema", "validate", err)
+		if err := c.jsonSchemaCompiled.Validate(values); err != nil {
+			errs = append(errs, NewConfigError("json-schema", "validate", withDetails(err, schemaValidationErrors(err, values))))
 		}
 	}
 
@@ -324,51 +815,114 @@ func (c *Conflex) Load(ctx context.Context) error {
 		if fn == nil {
 			continue // Skip nil validators
 		}
-		var validatorErr error
-		func() {
-			defer func() {
-				if r := recover(); r != nil {
-					validatorErr = fmt.Errorf("validator panic: %v", r)
+		if validatorErr := callValidator(fn, values); validatorErr != nil {
+			errs = append(errs, NewConfigError(fmt.Sprintf("custom-validator[%d]", i), "validate", validatorErr))
+		}
+	}
+
+	if len(c.bindings) > 0 {
+		if err := c.checkRequiredKeys(values); err != nil {
+			errs = append(errs, NewConfigError("binding", "validate", err))
+		} else {
+			for i, b := range c.bindings {
+				if err := c.bindAndValidate(values, b); err != nil {
+					errs = append(errs, NewConfigError(c.bindingName(i), "validate", err))
 				}
-			}()
-			validatorErr = fn(newValues)
-		}()
-		if validatorErr != nil {
-			return NewConfigError(fmt.Sprintf("custom-validator[%d]", i), "validate", validatorErr)
+			}
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	for _, detail := range ValidationErrors(errors.Join(errs...)) {
+		if detail.Source != "" {
+			continue
+		}
+		// Slices are leaves, so the source of an element is the source of its slice.
+		for path := pointerSegments(detail.Path); len(path) > 0 && detail.Source == ""; path = path[:len(path)-1] {
+			detail.Source = origins[joinKey(path)]
 		}
 	}
 
+	// A single error is returned as is, so it can be inspected without unwrapping the join.
+	if len(errs) == 1 {
+		return errs[0]
+	}
+	return errors.Join(errs...)
+}
+
+// callValidator calls a validation function, turning a panic into an error.
+func callValidator(fn func(map[string]any) error, values map[string]any) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("validator panic: %v", r)
+		}
+	}()
+	return fn(values)
+}
+
+// apply binds the resolved values and swaps them in as the current values, returning the previous values.
+// The per-source results are kept for Reload. The binding has already been validated by validate,
+// so binding only fails in exceptional cases.
+func (c *Conflex) apply(res *resolution) (map[string]any, error) {
+	newValues := res.values
+
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	if c.binding != nil {
-		// Validate binding without modifying shared state
-		if err := c.bindAndValidate(newValues); err != nil {
-			return NewConfigError("binding", "validate", err)
-		}
-		// Now safely update the actual binding struct
-		if err := c.bind(&newValues); err != nil {
-			return NewConfigError("binding", "bind", err)
+	// A load that was already running when the instance was frozen must not change it.
+	if c.frozen {
+		return nil, ErrFrozen
+	}
+
+	// The bindings were validated by validate; now update the actual binding structs
+	for i, b := range c.bindings {
+		if err := c.decode(c.bindingValues(newValues, b), b.target); err != nil {
+			return nil, NewConfigError(c.bindingName(i), "bind", err)
 		}
 	}
 
+	var oldValues map[string]any
+	if c.values != nil {
+		oldValues = *c.values
+	}
 	c.values = &newValues
+	c.sourceResults = res.results
+	c.keyOrigins = res.origins
+	c.loaded = true
+	c.version++
 
-	return nil
+	return oldValues, nil
 }
 
 // Dump writes the current configuration values to the registered dumpers.
+// The values of keys registered with WithRedactedKeys are replaced with RedactedValue.
+// The dumpers run concurrently, and Dump waits for all of them. If any fail, the returned error joins a
+// *ConfigError for each failed dumper, naming it by the name given to WithNamedDumper or by its position,
+// such as "dumper[1]".
 func (c *Conflex) Dump(ctx context.Context) error {
 	if ctx == nil {
 		return errors.New("context cannot be nil")
 	}
 
+	ctx, span := c.startSpan(ctx, "conflex.Dump")
+	err := c.dump(ctx)
+	endSpan(span, err)
+	return err
+}
+
+// dump implements Dump.
+func (c *Conflex) dump(ctx context.Context) error {
 	// Get a copy of the values to avoid holding locks during dumper calls
 	var valuesCopy map[string]any
 	func() {
 		c.mu.RLock()
 		defer c.mu.RUnlock()
-		if c.values != nil {
+		if c.values != nil && c.hasSecrets() {
+			valuesCopy = redactValues(*c.values, c.isRedacted)
+		} else if c.values != nil {
 			// Use shallow copy for better performance
 			valuesCopy = make(map[string]any, len(*c.values))
 			for k, v := range *c.values {
@@ -379,54 +933,93 @@ func (c *Conflex) Dump(ctx context.Context) error {
 		}
 	}()
 
-	for _, d := range c.dumpers {
-		if err := d.Dump(ctx, &valuesCopy); err != nil {
-			return err
+	errs := make([]error, len(c.dumpers))
+	var wg sync.WaitGroup
+	for i, d := range c.dumpers {
+		// Each dumper gets its own top-level map, so one that adds or removes keys does not race with the others.
+		values := make(map[string]any, len(valuesCopy))
+		for k, v := range valuesCopy {
+			values[k] = v
 		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			dumpCtx, span := c.startSpan(ctx, "conflex.dumper.dump", AttributeDumperName.String(c.dumperName(i)))
+			err := d.Dump(dumpCtx, &values)
+			endSpan(span, err)
+			if err != nil {
+				errs[i] = NewConfigError(c.dumperName(i), "dump", err)
+			}
+		}()
 	}
+	wg.Wait()
 
-	return nil
+	return errors.Join(errs...)
+}
+
+// binding is a struct that the values below prefix, or all values if prefix is empty, are decoded into.
+type binding struct {
+	target any
+	prefix string
 }
 
-func (c *Conflex) bind(values *map[string]any) error {
-	// Get the decoder config and set the result target
-	config := c.getDecoderConfig()
-	config.Result = c.binding
+// bindingValues returns the input to decode the binding b from.
+func (c *Conflex) bindingValues(values map[string]any, b binding) any {
+	if b.prefix == "" {
+		return &values
+	}
+	if value := c.lookup(values, b.prefix); value != nil {
+		return value
+	}
+	return map[string]any{}
+}
+
+// bindingName returns the name of the binding at index i, as used in errors. A single binding is named
+// "binding"; when there are several, they are named "binding[0]", "binding[1]", and so on.
+func (c *Conflex) bindingName(i int) string {
+	if len(c.bindings) == 1 {
+		return "binding"
+	}
+	return fmt.Sprintf("binding[%d]", i)
+}
+
+// decode decodes input into target with the same decoder settings as the binding.
+// It uses its own copy of the decoder configuration, so it is safe to call concurrently with Load.
+func (c *Conflex) decode(input, target any) error {
+	config := *c.getDecoderConfig()
+	config.Result = target
 
-	decoder, err := mapstructure.NewDecoder(config)
+	decoder, err := mapstructure.NewDecoder(&config)
 	if err != nil {
 		return fmt.Errorf("failed to create decoder: %w", err)
 	}
 
-	if err := decoder.Decode(values); err != nil {
+	if err := decoder.Decode(input); err != nil {
 		return fmt.Errorf("failed to decode configuration: %w", err)
 	}
 
 	return nil
 }
 
-// bindAndValidate performs binding and validation on the provided values without modifying shared state.
-// This method is used during Load to validate configuration before atomically updating c.values.
-func (c *Conflex) bindAndValidate(values map[string]any) error {
+// bindAndValidate performs binding and validation of binding on the provided values without modifying shared
+// state. This method is used during Load to validate configuration before atomically updating c.values.
+// It does not need the lock: decoding uses its own copy of the decoder configuration.
+func (c *Conflex) bindAndValidate(values map[string]any, b binding) error {
 	// Create a temporary copy of the binding struct to avoid race conditions
 	// when multiple goroutines call Load() concurrently
-	bindingType := reflect.TypeOf(c.binding)
+	bindingType := reflect.TypeOf(b.target)
 	if bindingType.Kind() == reflect.Ptr {
 		bindingType = bindingType.Elem()
 	}
 	tempBinding := reflect.New(bindingType).Interface()
 
-	// Get the decoder config and set the result target
-	config := c.getDecoderConfig()
-	config.Result = tempBinding
-
-	decoder, err := mapstructure.NewDecoder(config)
-	if err != nil {
-		return fmt.Errorf("failed to create decoder: %w", err)
+	if err := c.decode(c.bindingValues(values, b), tempBinding); err != nil {
+		return withDetails(err, prefixDetails(decodeValidationErrors(err), c.normalizeKey(b.prefix)))
 	}
 
-	if err := decoder.Decode(&values); err != nil {
-		return fmt.Errorf("failed to decode configuration: %w", err)
+	if err := c.validateStruct(tempBinding, c.normalizeKey(b.prefix)); err != nil {
+		return err
 	}
 
 	// Run validation if the binding implements Validator interface
@@ -439,24 +1032,82 @@ func (c *Conflex) bindAndValidate(values map[string]any) error {
 	return nil
 }
 
-// Values returns a pointer to the internal values map of the Conflex instance.
-// The map is protected by a read lock, which is acquired and released within this method.
-// This method is used to safely access the internal values map.
+// Values returns a pointer to a deep copy of the configuration values. It returns a pointer to an empty map
+// before the first Load.
+//
+// Earlier versions returned a pointer to the live internal map, so changes made through it silently altered
+// the configuration and raced with concurrent loads. The copy keeps the signature for compatibility, but
+// changes made to it no longer affect the instance.
+//
+// Deprecated: Use AllSettings, which returns the same copy without the pointer, or Get and Snapshot to read
+// single values without copying the whole configuration.
 func (c *Conflex) Values() *map[string]any {
+	values := c.AllSettings()
+	return &values
+}
+
+// AllKeys returns the dot-separated path of every leaf value in the configuration, in sorted order.
+// Maps are descended into; slices and empty maps are leaves. It returns an empty slice before the first Load.
+// Dots inside keys are escaped as described for Get, so every returned key can be passed to Get.
+func (c *Conflex) AllKeys() []string {
+	keys := []string{}
+	if c == nil {
+		return keys
+	}
+
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
 	if c.values == nil {
-		emptyMap := make(map[string]any)
-		return &emptyMap
+		return keys
 	}
+	walkLeaves(*c.values, func(path []string, _ any) {
+		keys = append(keys, joinKey(path))
+	})
+	return keys
+}
 
-	return c.values
+// AllSettings returns a deep copy of the configuration. Unlike Values, the result can be modified
+// freely without affecting the instance. It returns an empty map before the first Load.
+func (c *Conflex) AllSettings() map[string]any {
+	if c == nil {
+		return map[string]any{}
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.values == nil {
+		return map[string]any{}
+	}
+	return copyMap(*c.values)
+}
+
+// AllSettingsFlat returns the configuration as a flat map from dot-separated key paths to values, for systems that
+// only understand flat key/value pairs, such as Consul KV or Java properties. Maps are descended into, and list
+// elements are keyed by their index, so a list of servers yields "servers.0.host", "servers.1.host" and so on.
+// Empty maps and lists are kept as values, and dots inside keys are escaped as described for Get. The values can be modified freely without affecting the instance.
+func (c *Conflex) AllSettingsFlat() map[string]any {
+	flat := map[string]any{}
+	if c == nil {
+		return flat
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.values == nil {
+		return flat
+	}
+	for k, v := range *c.values {
+		flattenInto(flat, escapeKeySegment(k), v)
+	}
+	return flat
 }
 
 // getValueFromMap retrieves the value associated with the given path from the internal values map.
 // The path is a dot-separated string that represents the nested structure of the map.
-// If the path is valid and the final value is found, it is returned. Otherwise, nil is returned.
+// If the path is valid and the final value is found, a deep copy of it is returned. Otherwise, nil is returned.
 // Keys are case-insensitive since they are stored in lowercase.
 func (c *Conflex) getValueFromMap(path string) any {
 	c.mu.RLock()
@@ -466,38 +1117,79 @@ func (c *Conflex) getValueFromMap(path string) any {
 		return nil
 	}
 
-	// Work with a copy of the current map to avoid race conditions during traversal
-	current := *c.values
+	return copyValue(c.lookup(*c.values, path))
+}
 
-	// Normalize the path to lowercase for case-insensitive lookup
-	normalizedPath := strings.ToLower(path)
+// lookup returns the value at path in values, or nil if there is none.
+// Numeric segments index into lists, so "servers.0.host" is the host of the first server.
+func (c *Conflex) lookup(values map[string]any, path string) any {
+	val, _ := lookupKey(values, c.normalizeKey(path), false)
+	return val
+}
+
+// Lookup returns the value at key in values, a merged configuration such as the one passed to the functions
+// registered with WithValidator, and whether it was found. Keys are resolved like Get resolves them: segments
+// are separated by dots, a dot that is part of a segment is escaped with a backslash, and numeric segments index
+// into lists. A segment without an exact match is matched in lowercase, the way keys are stored without
+// WithCaseSensitiveKeys, so "Server.Port" is found with and without that option.
+func Lookup(values map[string]any, key string) (any, bool) {
+	return lookupKey(values, key, true)
+}
 
+// lookupKey returns the value at path in values and whether it was found. If fold is set, a key that is not
+// found is looked up again in lowercase.
+func lookupKey(values map[string]any, path string, fold bool) (any, bool) {
 	// 1. Check for direct key match first
-	if val, ok := current[normalizedPath]; ok {
-		return val
+	if val, ok := mapEntry(values, path, fold); ok {
+		return val, true
 	}
 
 	// 2. Fallback to dot notation traversal
-	segments := strings.Split(normalizedPath, ".")
-	for i, segment := range segments {
-		if currentMap, ok := current[segment]; ok {
-			if i == len(segments)-1 {
-				return currentMap
+	var current any = values
+	for _, segment := range splitKey(path) {
+		switch node := current.(type) {
+		case map[string]any:
+			val, ok := mapEntry(node, segment, fold)
+			if !ok {
+				return nil, false
 			}
-			if nestedMap, ok := currentMap.(map[string]any); ok {
-				current = nestedMap
-			} else {
-				return nil
+			current = val
+		case []any:
+			i, ok := sliceIndex(segment, len(node))
+			if !ok {
+				return nil, false
 			}
-		} else {
-			return nil
+			current = node[i]
+		default:
+			rv := reflect.ValueOf(node)
+			if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+				return nil, false
+			}
+			i, ok := sliceIndex(segment, rv.Len())
+			if !ok {
+				return nil, false
+			}
+			current = rv.Index(i).Interface()
 		}
 	}
-	return nil
+	return current, true
+}
+
+// mapEntry returns the value of key in m. If fold is set, a key that is not found is looked up again in lowercase.
+func mapEntry(m map[string]any, key string, fold bool) (any, bool) {
+	val, ok := m[key]
+	if !ok && fold {
+		if lower := strings.ToLower(key); lower != key {
+			val, ok = m[lower]
+		}
+	}
+	return val, ok
 }
 
 // Get returns the value associated with the given key as an any type.
-// If the key is not found, it returns nil.
+// If the key is not found, it returns nil. The segments of a key are separated by dots; a dot that is part of
+// a segment is escaped with a backslash, as in "labels.app\.kubernetes\.io/name".
+// Maps and lists are returned as deep copies, so modifying them does not affect the configuration.
 func (c *Conflex) Get(key string) any {
 	if c == nil {
 		return nil
@@ -505,9 +1197,54 @@ func (c *Conflex) Get(key string) any {
 	if key == "" {
 		return nil
 	}
+	c.loadLazySources(key)
 	return c.getValueFromMap(key)
 }
 
+// GetMany returns the values associated with the given keys, keyed by the keys as passed.
+// All values are read from the same configuration snapshot, under a single lock, so they are consistent
+// with each other even if a reload happens concurrently. Keys that are not found are omitted from the result.
+// Like Get, it returns deep copies of maps and lists.
+func (c *Conflex) GetMany(keys ...string) map[string]any {
+	values, _ := c.GetManyE(keys...)
+	return values
+}
+
+// GetManyE is like GetMany, but returns an error listing the keys that were not found,
+// together with the values of the keys that were.
+func (c *Conflex) GetManyE(keys ...string) (map[string]any, error) {
+	result := make(map[string]any, len(keys))
+	if c == nil {
+		return result, fmt.Errorf("conflex instance is nil")
+	}
+	for _, key := range keys {
+		if key != "" {
+			c.loadLazySources(key)
+		}
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var missing []string
+	for _, key := range keys {
+		var value any
+		if key != "" && c.values != nil {
+			value = c.lookup(*c.values, key)
+		}
+		if value == nil {
+			missing = append(missing, fmt.Sprintf("%q", key))
+			continue
+		}
+		result[key] = copyValue(value)
+	}
+
+	if len(missing) > 0 {
+		return result, fmt.Errorf("keys not found: %s", strings.Join(missing, ", "))
+	}
+	return result, nil
+}
+
 // GetString returns the value associated with the given key as a string.
 // If the value is not found or cannot be converted to a string, an empty string is returned.
 func (c *Conflex) GetString(key string) string {
@@ -703,19 +1440,23 @@ func (c *Conflex) GetFloat64E(key string) (float64, error) {
 }
 
 // GetTime returns the value associated with the given key as a time.Time.
+// Integer values, and strings of digits, are interpreted as Unix timestamps in seconds,
+// or in milliseconds if they are too large to be seconds.
 // If the value is not found or cannot be converted to a time.Time, the zero value is returned.
 func (c *Conflex) GetTime(key string) time.Time {
-	return cast.ToTime(c.Get(key))
+	t, _ := toTimeE(c.Get(key))
+	return t
 }
 
 // GetTimeE returns the value associated with the given key as a time.Time.
+// Unix timestamps are interpreted as described for GetTime.
 // If the value is not found or cannot be converted to a time.Time, it returns an error.
 func (c *Conflex) GetTimeE(key string) (time.Time, error) {
 	val := c.Get(key)
 	if val == nil {
 		return time.Time{}, fmt.Errorf("key %q not found", key)
 	}
-	return cast.ToTimeE(val)
+	return toTimeE(val)
 }
 
 // GetDuration returns the value associated with the given key as a time.Duration.
@@ -734,6 +1475,24 @@ func (c *Conflex) GetDurationE(key string) (time.Duration, error) {
 	return cast.ToDurationE(val)
 }
 
+// GetSizeInBytes returns the value associated with the given key as a number of bytes.
+// Strings such as "512KB" or "10MiB" are parsed with ParseByteSize; numbers are taken to be bytes.
+// If the value is not found or cannot be converted, 0 is returned.
+func (c *Conflex) GetSizeInBytes(key string) int64 {
+	n, _ := toByteSizeE(c.Get(key))
+	return n
+}
+
+// GetSizeInBytesE returns the value associated with the given key as a number of bytes.
+// If the value is not found or cannot be converted, it returns an error.
+func (c *Conflex) GetSizeInBytesE(key string) (int64, error) {
+	val := c.Get(key)
+	if val == nil {
+		return 0, fmt.Errorf("key %q not found", key)
+	}
+	return toByteSizeE(val)
+}
+
 // GetIntSlice returns the value associated with the given key as a slice of integers.
 // If the value is not found or cannot be converted to a slice of integers, an empty slice is returned.
 func (c *Conflex) GetIntSlice(key string) []int {
@@ -768,6 +1527,7 @@ func (c *Conflex) GetStringSliceE(key string) ([]string, error) {
 
 // GetStringMap returns the value associated with the given key as a map[string]any.
 // If the value is not found or cannot be converted to a map[string]any, the zero value is returned.
+// The map is a deep copy, as with Get.
 func (c *Conflex) GetStringMap(key string) map[string]any {
 	return cast.ToStringMap(c.Get(key))
 }
@@ -813,3 +1573,70 @@ func (c *Conflex) GetStringMapStringSliceE(key string) (map[string][]string, err
 	}
 	return cast.ToStringMapStringSliceE(val)
 }
+
+// GetStringMapInt returns the value associated with the given key as a map[string]int.
+// If the value is not found or cannot be converted to a map[string]int, the zero value is returned.
+func (c *Conflex) GetStringMapInt(key string) map[string]int {
+	return cast.ToStringMapInt(c.Get(key))
+}
+
+// GetStringMapIntE returns the value associated with the given key as a map[string]int.
+// If the value is not found or cannot be converted to a map[string]int, it returns an error.
+func (c *Conflex) GetStringMapIntE(key string) (map[string]int, error) {
+	val := c.Get(key)
+	if val == nil {
+		return map[string]int{}, fmt.Errorf("key %q not found", key)
+	}
+	return cast.ToStringMapIntE(val)
+}
+
+// GetStringMapBool returns the value associated with the given key as a map[string]bool.
+// If the value is not found or cannot be converted to a map[string]bool, the zero value is returned.
+func (c *Conflex) GetStringMapBool(key string) map[string]bool {
+	return cast.ToStringMapBool(c.Get(key))
+}
+
+// GetStringMapBoolE returns the value associated with the given key as a map[string]bool.
+// If the value is not found or cannot be converted to a map[string]bool, it returns an error.
+func (c *Conflex) GetStringMapBoolE(key string) (map[string]bool, error) {
+	val := c.Get(key)
+	if val == nil {
+		return map[string]bool{}, fmt.Errorf("key %q not found", key)
+	}
+	return cast.ToStringMapBoolE(val)
+}
+
+// GetStringMapDuration returns the value associated with the given key as a map[string]time.Duration.
+// If the value is not found or cannot be converted to a map[string]time.Duration, the zero value is returned.
+func (c *Conflex) GetStringMapDuration(key string) map[string]time.Duration {
+	m, err := c.GetStringMapDurationE(key)
+	if err != nil {
+		return map[string]time.Duration{}
+	}
+	return m
+}
+
+// GetStringMapDurationE returns the value associated with the given key as a map[string]time.Duration.
+// Values are converted like GetDuration does, so both "5s" and a number of nanoseconds are accepted.
+// If the value is not found or cannot be converted to a map[string]time.Duration, it returns an error
+// naming the entry that could not be converted.
+func (c *Conflex) GetStringMapDurationE(key string) (map[string]time.Duration, error) {
+	val := c.Get(key)
+	if val == nil {
+		return map[string]time.Duration{}, fmt.Errorf("key %q not found", key)
+	}
+	m, err := cast.ToStringMapE(val)
+	if err != nil {
+		return map[string]time.Duration{}, err
+	}
+
+	durations := make(map[string]time.Duration, len(m))
+	for k, v := range m {
+		d, err := cast.ToDurationE(v)
+		if err != nil {
+			return map[string]time.Duration{}, fmt.Errorf("entry %q: %w", k, err)
+		}
+		durations[k] = d
+	}
+	return durations, nil
+}
@@ -18,7 +18,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -165,6 +168,96 @@ func (s *ConflexTestSuite) TestDump_ErrorPropagates() {
 	s.Error(c.Dump(context.Background()))
 }
 
+func (s *ConflexTestSuite) TestWithBinding_Multiple() {
+	type ServerConfig struct {
+		Port int `conflex:"port,default=8080"`
+	}
+	type DatabaseConfig struct {
+		Host string `conflex:"host,required"`
+	}
+	type Config struct {
+		Server   ServerConfig   `conflex:"server"`
+		Database DatabaseConfig `conflex:"database"`
+	}
+	type LoggingConfig struct {
+		Logging struct {
+			Level string `conflex:"level"`
+		} `conflex:"logging"`
+	}
+
+	src := &mockSyncSource{conf: map[string]any{
+		"database": map[string]any{"host": "db.internal"},
+		"logging":  map[string]any{"level": "debug"},
+	}}
+	var app Config
+	var logging LoggingConfig
+	var foo validatingBindStruct
+	c, err := New(WithSource(src), WithBinding(&app), WithBinding(&logging))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	s.Equal(8080, app.Server.Port)
+	s.Equal("db.internal", app.Database.Host)
+	s.Equal("debug", logging.Logging.Level)
+
+	// Required keys of every binding are checked.
+	src.set(map[string]any{"logging": map[string]any{"level": "info"}}, nil)
+	s.ErrorIs(c.Load(context.Background()), ErrRequiredKeyMissing)
+	s.Equal("debug", logging.Logging.Level)
+
+	// A binding that rejects the configuration fails the load for all of them.
+	c, err = New(WithSource(src), WithBinding(&logging), WithBinding(&foo))
+	s.Require().NoError(err)
+	err = c.Load(context.Background())
+	s.ErrorContains(err, "foo cannot be empty")
+	var configErr *ConfigError
+	s.Require().ErrorAs(err, &configErr)
+	s.Equal("binding[1]", configErr.Source)
+	s.Equal("debug", logging.Logging.Level)
+}
+
+func (s *ConflexTestSuite) TestWithBindingAt() {
+	type ServerConfig struct {
+		Host    string        `conflex:"host,required"`
+		Port    int           `conflex:"port,default=8080"`
+		Timeout time.Duration `conflex:"timeout"`
+	}
+	type CacheConfig struct {
+		Size int `conflex:"size"`
+	}
+
+	src := &mockSyncSource{conf: map[string]any{
+		"server": map[string]any{"host": "localhost", "timeout": "5s"},
+		"name":   "api",
+	}}
+	var server ServerConfig
+	var cache CacheConfig
+	c, err := New(WithSource(src), WithBindingAt("Server", &server), WithBindingAt("cache", &cache))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	s.Equal(ServerConfig{Host: "localhost", Port: 8080, Timeout: 5 * time.Second}, server)
+	s.Equal(CacheConfig{}, cache)
+	s.Equal(8080, c.GetInt("server.port"))
+
+	// Required keys and decode errors are reported with their full key.
+	src.set(map[string]any{"server": map[string]any{"port": 9090}}, nil)
+	err = c.Load(context.Background())
+	s.ErrorContains(err, "server.host")
+	s.Equal("/server/host", ValidationErrors(err)[0].Path)
+
+	src.set(map[string]any{"server": map[string]any{"host": "localhost", "port": "high"}}, nil)
+	err = c.Load(context.Background())
+	s.Require().Error(err)
+	s.Equal("/server/port", ValidationErrors(err)[0].Path)
+	s.Equal(8080, server.Port)
+
+	_, err = NewStrict(WithBindingAt("", &server))
+	s.ErrorContains(err, "binding prefix cannot be empty")
+	_, err = NewStrict(WithBindingAt("server", server))
+	s.ErrorContains(err, "binding target must be a pointer")
+}
+
 func (s *ConflexTestSuite) TestWithBinding_NonPointer() {
 	src := &mockSource{conf: map[string]any{"foo": "bar"}}
 	var bind bindStruct
@@ -232,6 +325,38 @@ func (s *ConflexTestSuite) TestWithBinding_Nil() {
 	s.Contains(err.Error(), "binding target cannot be nil")
 }
 
+func (s *ConflexTestSuite) TestNew_PartialInitError() {
+	src := &mockSource{conf: map[string]any{"foo": "bar"}}
+	c, err := New(WithSource(src), WithDumper(nil), nil, WithBinding(nil))
+	s.Require().Error(err)
+	s.NotNil(c, "New should still return a usable instance")
+
+	var initErr *PartialInitError
+	s.Require().ErrorAs(err, &initErr)
+	s.Require().Len(initErr.Options, 2)
+	s.Equal(1, initErr.Options[0].Index)
+	s.Equal("WithDumper", initErr.Options[0].Name)
+	s.Equal(3, initErr.Options[1].Index)
+	s.Equal("WithBinding", initErr.Options[1].Name)
+
+	s.NoError(c.Load(context.Background()))
+	s.Equal("bar", c.GetString("foo"))
+}
+
+func (s *ConflexTestSuite) TestNewStrict() {
+	src := &mockSource{conf: map[string]any{"foo": "bar"}}
+	c, err := NewStrict(WithSource(src), WithDumper(nil))
+	s.Error(err)
+	s.Nil(c)
+
+	var initErr *PartialInitError
+	s.ErrorAs(err, &initErr)
+
+	c, err = NewStrict(WithSource(src))
+	s.NoError(err)
+	s.NotNil(c)
+}
+
 func (s *ConflexTestSuite) TestNew_NoOptions() {
 	c, err := New()
 	s.NoError(err)
@@ -329,6 +454,49 @@ func (s *ConflexTestSuite) TestCustomValidator_Fails() {
 	s.Error(c.Load(context.Background()))
 }
 
+func (s *ConflexTestSuite) TestValidationErrors_Aggregated() {
+	schema := []byte(`{"type":"object","properties":{"bar":{"type":"integer"}}}`)
+	src := &mockSource{conf: map[string]any{"foo": "bar", "bar": "notanint"}}
+	var binding struct {
+		Port int `conflex:"port,required"`
+	}
+	c, err := New(
+		WithSource(src),
+		WithJSONSchema(schema),
+		WithValidator(func(map[string]any) error { return errors.New("first validator failed") }),
+		WithValidator(func(map[string]any) error { return nil }),
+		WithValidator(func(map[string]any) error { return errors.New("third validator failed") }),
+		WithBinding(&binding),
+	)
+	s.Require().NoError(err)
+
+	err = c.Load(context.Background())
+	s.Require().Error(err)
+	joined, ok := err.(interface{ Unwrap() []error })
+	s.Require().True(ok, "expected a joined error, got %T", err)
+
+	var sources []string
+	for _, e := range joined.Unwrap() {
+		var configErr *ConfigError
+		s.Require().ErrorAs(e, &configErr)
+		sources = append(sources, configErr.Source)
+	}
+	s.Equal([]string{"json-schema", "custom-validator[0]", "custom-validator[2]", "binding"}, sources)
+	s.ErrorIs(err, ErrRequiredKeyMissing)
+	s.Contains(err.Error(), "first validator failed")
+	s.Contains(err.Error(), "third validator failed")
+}
+
+func (s *ConflexTestSuite) TestValidationErrors_SingleNotJoined() {
+	src := &mockSource{conf: map[string]any{"foo": "bar"}}
+	c, err := New(WithSource(src), WithValidator(func(map[string]any) error { return errors.New("invalid") }))
+	s.Require().NoError(err)
+
+	err = c.Load(context.Background())
+	s.Require().Error(err)
+	s.IsType(&ConfigError{}, err)
+}
+
 func (s *ConflexTestSuite) TestCustomValidator_Succeeds() {
 	src := &mockSource{conf: map[string]any{"foo": "baz"}}
 	c, err := New(WithSource(src), WithValidator(func(cfg map[string]any) error {
@@ -468,6 +636,164 @@ func (s *ConflexTestSuite) TestNilAndEmptyConfigMap() {
 	s.NoError(c2.Load(context.Background()))
 }
 
+func (s *ConflexTestSuite) TestAllKeys() {
+	src := &mockSource{conf: map[string]any{
+		"server": map[string]any{"host": "localhost", "port": 8080},
+		"db":     map[string]any{"primary": map[string]any{"host": "db1"}, "options": map[string]any{}},
+		"tags":   []any{"a", "b"},
+	}}
+	c, err := New(WithSource(src))
+	s.Require().NoError(err)
+	s.Empty(c.AllKeys())
+
+	s.Require().NoError(c.Load(context.Background()))
+	s.Equal([]string{"db.options", "db.primary.host", "server.host", "server.port", "tags"}, c.AllKeys())
+
+	var nilConflex *Conflex
+	s.Empty(nilConflex.AllKeys())
+}
+
+func (s *ConflexTestSuite) TestAllSettings() {
+	src := &mockSource{conf: map[string]any{
+		"server": map[string]any{"host": "localhost"},
+		"tags":   []any{"a", "b"},
+	}}
+	c, err := New(WithSource(src))
+	s.Require().NoError(err)
+	s.Empty(c.AllSettings())
+
+	s.Require().NoError(c.Load(context.Background()))
+	settings := c.AllSettings()
+	s.Equal(map[string]any{"server": map[string]any{"host": "localhost"}, "tags": []any{"a", "b"}}, settings)
+
+	settings["server"].(map[string]any)["host"] = "changed"
+	settings["tags"].([]any)[0] = "changed"
+	s.Equal("localhost", c.GetString("server.host"))
+	s.Equal([]string{"a", "b"}, c.GetStringSlice("tags"))
+
+	var nilConflex *Conflex
+	s.Empty(nilConflex.AllSettings())
+}
+
+func (s *ConflexTestSuite) TestAllSettingsFlat() {
+	src := &mockSource{conf: map[string]any{
+		"server": map[string]any{"host": "localhost", "tls": map[string]any{"enabled": true}},
+		"servers": []any{
+			map[string]any{"host": "a", "ports": []any{80, 443}},
+			map[string]any{"host": "b"},
+		},
+		"ids":    []int{7, 8},
+		"key":    []byte("secret"),
+		"empty":  map[string]any{},
+		"none":   []any{},
+		"labels": map[string]any{"team": "core"},
+	}}
+	c, err := New(WithSource(src))
+	s.Require().NoError(err)
+	s.Empty(c.AllSettingsFlat())
+
+	s.Require().NoError(c.Load(context.Background()))
+	flat := c.AllSettingsFlat()
+	s.Equal(map[string]any{
+		"server.host":        "localhost",
+		"server.tls.enabled": true,
+		"servers.0.host":     "a",
+		"servers.0.ports.0":  80,
+		"servers.0.ports.1":  443,
+		"servers.1.host":     "b",
+		"ids.0":              7,
+		"ids.1":              8,
+		"key":                []byte("secret"),
+		"empty":              map[string]any{},
+		"none":               []any{},
+		"labels.team":        "core",
+	}, flat)
+
+	// Every flat key addresses its value.
+	for key, value := range flat {
+		s.Equal(value, c.Get(key), key)
+	}
+
+	flat["empty"].(map[string]any)["x"] = 1
+	s.Empty(c.GetStringMap("empty"))
+
+	var nilConflex *Conflex
+	s.Empty(nilConflex.AllSettingsFlat())
+}
+
+func (s *ConflexTestSuite) TestEscapedDots() {
+	src := &mockSource{conf: map[string]any{
+		"labels": map[string]any{
+			"app.kubernetes.io/name": "billing",
+			"team":                   "core",
+		},
+		"paths": map[string]any{`c:\data`: "windows"},
+	}}
+	c, err := New(WithSource(src))
+	s.Require().NoError(err)
+	s.T().Setenv("CONFLEX_TEST_TIER", "backend")
+	s.Require().NoError(c.BindEnv(`labels.tier\.level`, "CONFLEX_TEST_TIER"))
+	s.Require().NoError(c.Load(context.Background()))
+
+	s.Equal("billing", c.GetString(`labels.app\.kubernetes\.io/name`))
+	s.Nil(c.Get("labels.app.kubernetes.io/name"))
+	s.Equal("billing", c.Sub("labels").GetString(`app\.kubernetes\.io/name`))
+	s.Equal("windows", c.GetString(`paths.c:\data`))
+	s.Equal("windows", c.GetString(`paths.c:\\data`))
+	s.Equal("backend", c.GetString(`labels.tier\.level`))
+
+	s.Equal([]string{`labels.app\.kubernetes\.io/name`, "labels.team", `labels.tier\.level`, `paths.c:\\data`}, c.AllKeys())
+	for key := range c.AllSettingsFlat() {
+		s.NotNil(c.Get(key), key)
+	}
+
+	origin, ok := c.Origin(`labels.app\.kubernetes\.io/name`)
+	s.True(ok)
+	s.Equal("source[0]", origin)
+}
+
+func (s *ConflexTestSuite) TestSplitKey() {
+	s.Equal([]string{"a", "b"}, splitKey("a.b"))
+	s.Equal([]string{"a", "b.c", "d"}, splitKey(`a.b\.c.d`))
+	s.Equal([]string{`a\`, "b"}, splitKey(`a\\.b`))
+	s.Equal([]string{`a\b`}, splitKey(`a\b`))
+	s.Equal([]string{"a."}, splitKey(`a\.`))
+
+	for _, path := range [][]string{{"a", "b.c"}, {`x\`, "y"}, {`c:\data`, "."}} {
+		s.Equal(path, splitKey(joinKey(path)))
+	}
+}
+
+func (s *ConflexTestSuite) TestGetMany() {
+	src := &mockSource{conf: map[string]any{
+		"server": map[string]any{"host": "localhost", "port": 8080},
+		"db":     map[string]any{"primary": map[string]any{"host": "db1"}},
+	}}
+	c, err := New(WithSource(src))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	s.Equal(map[string]any{
+		"server.host":     "localhost",
+		"Server.Port":     8080,
+		"db.primary.host": "db1",
+	}, c.GetMany("server.host", "Server.Port", "db.primary.host", "missing"))
+
+	values, err := c.GetManyE("server.host", "missing", "")
+	s.Equal(map[string]any{"server.host": "localhost"}, values)
+	s.Require().Error(err)
+	s.Equal(`keys not found: "missing", ""`, err.Error())
+
+	values, err = c.GetManyE("server.port")
+	s.NoError(err)
+	s.Equal(map[string]any{"server.port": 8080}, values)
+
+	var nilConflex *Conflex
+	s.Empty(nilConflex.GetMany("server.host"))
+	_, err = nilConflex.GetManyE("server.host")
+	s.Error(err)
+}
+
 func (s *ConflexTestSuite) TestGet_DeeplyNestedDotNotation() {
 	src := &mockSource{conf: map[string]any{
 		"a": map[string]any{"b": map[string]any{"c": map[string]any{"d": 1}}},
@@ -775,11 +1101,56 @@ func (s *ConflexTestSuite) TestGet_DotNotationWithKeyContainingDot() {
 	// Should return nested value if direct key does not exist
 	v := c.Get("a.b")
 	s.Equal(2, v)
-	// Direct key with dot
+	// Values returns a copy, so changing it does not affect the configuration
 	m := c.Values()
 	(*m)["a.b"] = 3
-	v2 := c.Get("a.b")
-	s.Equal(3, v2)
+	(*m)["a"].(map[string]any)["b"] = 4
+	s.Equal(2, c.Get("a.b"))
+	s.Nil(c.Get(`a\.b`))
+}
+
+func (s *ConflexTestSuite) TestLookup() {
+	values := map[string]any{
+		"Server":  map[string]any{"Port": 8080},
+		"db":      map[string]any{"host": "db.internal"},
+		"labels":  map[string]any{"app.kubernetes.io/name": "web"},
+		"servers": []any{map[string]any{"port": 80}},
+		"a.b":     1,
+	}
+	for key, want := range map[string]any{
+		"Server.Port":                     8080,
+		"DB.Host":                         "db.internal",
+		`labels.app\.kubernetes\.io/name`: "web",
+		"servers.0.port":                  80,
+		"a.b":                             1,
+	} {
+		got, ok := Lookup(values, key)
+		s.True(ok, key)
+		s.Equal(want, got, key)
+	}
+
+	for _, key := range []string{"server.port", "servers.1.port", "db.host.name", "missing"} {
+		_, ok := Lookup(values, key)
+		s.False(ok, key)
+	}
+}
+
+func (s *ConflexTestSuite) TestGet_ReturnsCopies() {
+	src := &mockSource{conf: map[string]any{
+		"db":    map[string]any{"host": "db.internal"},
+		"hosts": []any{"a", "b"},
+	}}
+	c, err := New(WithSource(src))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	c.Get("db").(map[string]any)["host"] = "x"
+	c.Get("hosts").([]any)[0] = "x"
+	c.GetStringMap("db")["host"] = "y"
+	c.GetMany("db")["db"].(map[string]any)["host"] = "z"
+
+	s.Equal("db.internal", c.GetString("db.host"))
+	s.Equal([]string{"a", "b"}, c.GetStringSlice("hosts"))
 }
 
 func (s *ConflexTestSuite) TestWithFileDumper() {
@@ -817,6 +1188,26 @@ func (s *ConflexTestSuite) TestWithOSEnvVarSource() {
 	s.Len(c.sources, 1)
 }
 
+func (s *ConflexTestSuite) TestWithEnvKeyReplacer() {
+	s.T().Setenv("REPLACER_DATABASE__MAX_CONNS", "10")
+	s.T().Setenv("REPLACER_LOG_LEVEL", "debug")
+
+	replacer := strings.NewReplacer("__", ".").Replace
+	for _, opts := range [][]Option{
+		{WithOSEnvVarSource("REPLACER_"), WithEnvKeyReplacer(replacer)},
+		{WithEnvKeyReplacer(replacer), WithOSEnvVarSource("REPLACER_")},
+	} {
+		c, err := New(opts...)
+		s.Require().NoError(err)
+		s.Require().NoError(c.Load(context.Background()))
+		s.Equal("10", c.GetString("database.max_conns"))
+		s.Equal("debug", c.GetString("log_level"))
+	}
+
+	_, err := New(WithEnvKeyReplacer(nil))
+	s.ErrorContains(err, "env key replacer cannot be nil")
+}
+
 func (s *ConflexTestSuite) TestWithConsulSource() {
 	// This will fail if Consul is not available, so just test error on invalid codec
 	c, err := New(WithConsulSource("some/path", "notacodec"))
@@ -828,6 +1219,23 @@ func (s *ConflexTestSuite) TestWithConsulSource() {
 	s.NotNil(c2)
 }
 
+func (s *ConflexTestSuite) TestWithHTTPSource() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"foo": "bar"}`))
+	}))
+	defer server.Close()
+
+	c, err := New(WithHTTPSource(server.URL, codec.TypeJSON))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+	s.Equal("bar", c.GetString("foo"))
+
+	_, err = New(WithHTTPSource(server.URL, "notacodec"))
+	s.Error(err)
+	_, err = New(WithHTTPSource("ftp://config.internal/app.json", codec.TypeJSON))
+	s.ErrorContains(err, "unsupported url scheme")
+}
+
 func (s *ConflexTestSuite) TestConfigError() {
 	// Test ConfigError formatting
 	baseErr := errors.New("base error")
@@ -1135,4 +1543,59 @@ func (s *ConflexTestSuite) TestConsistentReturnTypes() {
 	s.Error(err)
 	s.NotNil(stringMapStringSlice)
 	s.Len(stringMapStringSlice, 0)
+
+	stringMapInt, err := c.GetStringMapIntE("nonexistent")
+	s.Error(err)
+	s.NotNil(stringMapInt)
+	s.Len(stringMapInt, 0)
+
+	stringMapBool, err := c.GetStringMapBoolE("nonexistent")
+	s.Error(err)
+	s.NotNil(stringMapBool)
+	s.Len(stringMapBool, 0)
+
+	stringMapDuration, err := c.GetStringMapDurationE("nonexistent")
+	s.Error(err)
+	s.NotNil(stringMapDuration)
+	s.Len(stringMapDuration, 0)
+}
+
+func (s *ConflexTestSuite) TestTypedMapGetters() {
+	src := &mockSource{conf: map[string]any{
+		"limits":   map[string]any{"read": 100, "write": "20"},
+		"features": map[string]any{"search": true, "export": "false"},
+		"timeouts": map[string]any{"connect": "5s", "idle": "2m", "raw": 1000},
+		"broken":   map[string]any{"a": "soon"},
+		"scalar":   "x",
+	}}
+	c, err := New(WithSource(src))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	s.Equal(map[string]int{"read": 100, "write": 20}, c.GetStringMapInt("limits"))
+	limits, err := c.GetStringMapIntE("limits")
+	s.NoError(err)
+	s.Equal(20, limits["write"])
+
+	s.Equal(map[string]bool{"search": true, "export": false}, c.GetStringMapBool("features"))
+	features, err := c.GetStringMapBoolE("features")
+	s.NoError(err)
+	s.True(features["search"])
+
+	timeouts := map[string]time.Duration{"connect": 5 * time.Second, "idle": 2 * time.Minute, "raw": time.Microsecond}
+	s.Equal(timeouts, c.GetStringMapDuration("timeouts"))
+	d, err := c.GetStringMapDurationE("timeouts")
+	s.NoError(err)
+	s.Equal(timeouts, d)
+
+	_, err = c.GetStringMapDurationE("broken")
+	s.ErrorContains(err, `entry "a"`)
+	s.Empty(c.GetStringMapDuration("broken"))
+	_, err = c.GetStringMapDurationE("scalar")
+	s.Error(err)
+	_, err = c.GetStringMapIntE("scalar")
+	s.Error(err)
+
+	s.Equal(map[string]int{"read": 100, "write": 20}, c.Sub("").GetStringMapInt("limits"))
+	s.Equal(timeouts, c.Snapshot().GetStringMapDuration("timeouts"))
 }
@@ -0,0 +1,112 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// Handler returns an http.Handler serving the state of c as a JSON document, for mounting under a debug path:
+//
+//	mux.Handle("/debug/config", conflex.Handler(cfg))
+//
+// The document holds the effective configuration, the source that supplied every leaf value (see Origin),
+// the configured sources and the outcome of the last load (see ReloadStatus). Values of keys classified as
+// secret are replaced with RedactedValue, as in SafeValues. Internal values are shown, so keep the handler on
+// an internal listener. For a browsable HTML page, see DocsHandler.
+func Handler(c *Conflex) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(c.debugDocument()); err != nil {
+			c.log().Error("failed to write configuration debug document", "error", err)
+		}
+	})
+}
+
+// debugDocument is the JSON document served by Handler.
+type debugDocument struct {
+	Version uint64            `json:"version"`
+	Status  debugStatus       `json:"status"`
+	Sources []debugSource     `json:"sources"`
+	Values  map[string]any    `json:"values"`
+	Origins map[string]string `json:"origins"`
+}
+
+// debugStatus is the JSON form of ReloadStatus.
+type debugStatus struct {
+	Healthy     bool       `json:"healthy"`
+	LastAttempt *time.Time `json:"last_attempt,omitempty"`
+	LastSuccess *time.Time `json:"last_success,omitempty"`
+	LastError   string     `json:"last_error,omitempty"`
+	Successes   uint64     `json:"successes"`
+	Failures    uint64     `json:"failures"`
+}
+
+// debugSource is the JSON form of SourceInfo.
+type debugSource struct {
+	Name     string            `json:"name"`
+	Type     string            `json:"type"`
+	Target   string            `json:"target,omitempty"`
+	Options  map[string]string `json:"options,omitempty"`
+	Optional bool              `json:"optional,omitempty"`
+}
+
+// debugDocument collects the data served by Handler.
+func (c *Conflex) debugDocument() debugDocument {
+	status := c.ReloadStatus()
+	doc := debugDocument{
+		Status: debugStatus{
+			Healthy:   status.Healthy(),
+			Successes: status.Successes,
+			Failures:  status.Failures,
+		},
+		Sources: []debugSource{},
+		Values:  c.SafeValues(),
+		Origins: map[string]string{},
+	}
+	if !status.LastAttempt.IsZero() {
+		doc.Status.LastAttempt = &status.LastAttempt
+	}
+	if !status.LastSuccess.IsZero() {
+		doc.Status.LastSuccess = &status.LastSuccess
+	}
+	if status.LastError != nil {
+		doc.Status.LastError = status.LastError.Error()
+	}
+
+	for _, info := range c.Sources() {
+		doc.Sources = append(doc.Sources, debugSource(info))
+	}
+
+	c.mu.RLock()
+	doc.Version = c.version
+	for key, origin := range c.keyOrigins {
+		doc.Origins[key] = origin
+	}
+	c.mu.RUnlock()
+
+	return doc
+}
@@ -0,0 +1,103 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type DebugHandlerTestSuite struct {
+	suite.Suite
+}
+
+func TestDebugHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(DebugHandlerTestSuite))
+}
+
+// get serves a GET request with Handler(c) and decodes the response.
+func (s *DebugHandlerTestSuite) get(c *Conflex) map[string]any {
+	rec := httptest.NewRecorder()
+	Handler(c).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/config", nil))
+	s.Require().Equal(http.StatusOK, rec.Code)
+	s.Equal("application/json", rec.Header().Get("Content-Type"))
+	s.Equal("no-store", rec.Header().Get("Cache-Control"))
+
+	var doc map[string]any
+	s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &doc))
+	return doc
+}
+
+func (s *DebugHandlerTestSuite) TestHandler() {
+	c, err := New(
+		WithSource(&mockSource{conf: map[string]any{"server": map[string]any{"port": 8080}, "database": map[string]any{"password": "secret"}}}),
+		WithNamedSource("overrides", &mockSource{conf: map[string]any{"server": map[string]any{"port": 9090}}}),
+		WithRedactedKeys("database.password"),
+	)
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	doc := s.get(c)
+	s.Equal(float64(1), doc["version"])
+	s.Equal(map[string]any{
+		"server":   map[string]any{"port": float64(9090)},
+		"database": map[string]any{"password": RedactedValue},
+	}, doc["values"])
+	s.Equal(map[string]any{"server.port": "overrides", "database.password": "source[0]"}, doc["origins"])
+	s.Equal([]any{
+		map[string]any{"name": "source[0]", "type": "*conflex.mockSource"},
+		map[string]any{"name": "overrides", "type": "*conflex.mockSource"},
+	}, doc["sources"])
+
+	status := doc["status"].(map[string]any)
+	s.Equal(true, status["healthy"])
+	s.Equal(float64(1), status["successes"])
+	s.NotEmpty(status["last_attempt"])
+	s.NotEmpty(status["last_success"])
+	s.NotContains(status, "last_error")
+}
+
+func (s *DebugHandlerTestSuite) TestHandler_Failed() {
+	src := &mockSyncSource{err: errors.New("connection refused")}
+	c, err := New(WithSource(src))
+	s.Require().NoError(err)
+
+	doc := s.get(c)
+	s.Equal(map[string]any{}, doc["values"])
+	s.Equal(map[string]any{"healthy": false, "successes": float64(0), "failures": float64(0)}, doc["status"])
+
+	s.Require().Error(c.Load(context.Background()))
+	status := s.get(c)["status"].(map[string]any)
+	s.Equal(false, status["healthy"])
+	s.Equal(float64(1), status["failures"])
+	s.Contains(status["last_error"], "connection refused")
+	s.NotContains(status, "last_success")
+}
+
+func (s *DebugHandlerTestSuite) TestHandler_MethodNotAllowed() {
+	c, err := New()
+	s.Require().NoError(err)
+
+	rec := httptest.NewRecorder()
+	Handler(c).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/debug/config", nil))
+	s.Equal(http.StatusMethodNotAllowed, rec.Code)
+	s.Equal("GET, HEAD", rec.Header().Get("Allow"))
+}
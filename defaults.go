@@ -0,0 +1,99 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/go-viper/mapstructure/v2"
+)
+
+// tagDefault is a default value declared with the "default" tag option, e.g. `conflex:"timeout,default=30s"`.
+type tagDefault struct {
+	key   string
+	value any
+}
+
+// defaultsFromTags returns the default values declared with the "default" tag option on the fields of
+// the struct type t. Each default is parsed into the type of its field, so an invalid default is reported
+// when the binding is configured rather than on the first load.
+func defaultsFromTags(t reflect.Type, prefix string) ([]tagDefault, error) {
+	var defaults []tagDefault
+	var errs error
+	walkFields(t, prefix, func(path string, field reflect.StructField, tag fieldTag) {
+		raw, ok := tag.options["default"]
+		if !ok {
+			return
+		}
+		value, err := parseDefault(raw, field.Type)
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("field %s: invalid default %q: %w", path, raw, err))
+			return
+		}
+		defaults = append(defaults, tagDefault{key: path, value: value})
+	})
+	return defaults, errs
+}
+
+// parseDefault decodes raw into a value of type t. Booleans and numbers are returned as plain bool, int,
+// uint and float64 values, like values decoded from a configuration file; other values, such as durations
+// and strings, are returned as the original string and converted when the configuration is bound.
+func parseDefault(raw string, t reflect.Type) (any, error) {
+	target := reflect.New(t)
+	config := newDecoderConfig("")
+	config.Result = target.Interface()
+	decoder, err := mapstructure.NewDecoder(config)
+	if err != nil {
+		return nil, err
+	}
+	if err := decoder.Decode(raw); err != nil {
+		return nil, err
+	}
+
+	value := target.Elem()
+	for value.Kind() == reflect.Ptr && !value.IsNil() {
+		value = value.Elem()
+	}
+	if value.Type() == reflect.TypeOf(time.Duration(0)) {
+		return raw, nil
+	}
+	switch value.Kind() {
+	case reflect.Bool:
+		return value.Bool(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return int(value.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return uint(value.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), nil
+	default:
+		return raw, nil
+	}
+}
+
+// applyDefaults sets the tag defaults for keys that have no value in values. A key set to null counts as
+// having no value. Defaults are the lowest-precedence layer: any source that sets a key overrides them.
+func (c *Conflex) applyDefaults(values map[string]any) {
+	for _, d := range c.defaults {
+		if c.lookup(values, d.key) != nil {
+			continue
+		}
+		// If a scalar is configured where the default expects a nested key, it is left to binding to report.
+		setValue(values, splitKey(c.normalizeKey(d.key)), d.value)
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type defaultsConfig struct {
+	Name    string        `conflex:"name,default=app"`
+	Port    int           `conflex:"port,default=8080"`
+	Ratio   float64       `conflex:"ratio,default=0.5"`
+	Debug   bool          `conflex:"debug,default=true"`
+	Timeout time.Duration `conflex:"timeout,default=30s"`
+	Retries *uint         `conflex:"retries,default=3"`
+	Server  struct {
+		Host string `conflex:"host,default=localhost,required"`
+	} `conflex:"server"`
+}
+
+type DefaultsTestSuite struct {
+	suite.Suite
+}
+
+func TestDefaultsTestSuite(t *testing.T) {
+	suite.Run(t, new(DefaultsTestSuite))
+}
+
+func (s *DefaultsTestSuite) TestDefaultsApplied() {
+	var cfg defaultsConfig
+	c, err := New(
+		WithSource(&mockSource{conf: map[string]any{"port": 9090, "debug": nil}}),
+		WithBinding(&cfg),
+	)
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	s.Equal("app", cfg.Name)
+	s.Equal(9090, cfg.Port, "sources override defaults")
+	s.Equal(0.5, cfg.Ratio)
+	s.True(cfg.Debug, "null counts as unset")
+	s.Equal(30*time.Second, cfg.Timeout)
+	s.Require().NotNil(cfg.Retries)
+	s.Equal(uint(3), *cfg.Retries)
+	s.Equal("localhost", cfg.Server.Host, "defaults satisfy required fields")
+
+	s.Equal(30*time.Second, c.GetDuration("timeout"))
+	s.Equal("localhost", c.GetString("server.host"))
+	s.Equal(true, c.Get("debug"))
+}
+
+func (s *DefaultsTestSuite) TestInvalidDefault() {
+	type config struct {
+		Port    int           `conflex:"port,default=http"`
+		Timeout time.Duration `conflex:"timeout,default=soon"`
+	}
+
+	_, err := NewStrict(WithBinding(&config{}))
+	s.Require().Error(err)
+	s.Contains(err.Error(), `field port: invalid default "http"`)
+	s.Contains(err.Error(), `field timeout: invalid default "soon"`)
+}
+
+func (s *DefaultsTestSuite) TestScalarInPlaceOfNestedDefault() {
+	c, err := New()
+	s.Require().NoError(err)
+	c.defaults = []tagDefault{{key: "server.host", value: "localhost"}}
+
+	values := map[string]any{"server": "localhost"}
+	c.applyDefaults(values)
+	s.Equal(map[string]any{"server": "localhost"}, values)
+}
+
+func (s *DefaultsTestSuite) TestParseDefault() {
+	for _, tc := range []struct {
+		raw  string
+		typ  any
+		want any
+	}{
+		{"42", int8(0), 42},
+		{"42", uint16(0), uint(42)},
+		{"1.5", float32(0), 1.5},
+		{"false", false, false},
+		{"1m", time.Duration(0), "1m"},
+		{"text", "", "text"},
+	} {
+		got, err := parseDefault(tc.raw, reflect.TypeOf(tc.typ))
+		s.NoError(err, tc.raw)
+		s.Equal(tc.want, got, tc.raw)
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"errors"
+)
+
+// deprecatedKey describes a key registered with WithDeprecatedKey.
+type deprecatedKey struct {
+	key         string
+	replacement string
+	message     string
+}
+
+// WithDeprecatedKey marks key as deprecated. When a load finds a value for it, a warning is logged with the
+// given message, once until the key disappears again. If replacement is not empty and has no value of its
+// own, the deprecated value is also copied to replacement, so code that already reads the new key keeps
+// working while configuration is migrated. The deprecated key itself is left in place.
+//
+//	conflex.WithDeprecatedKey("db.host", "database.host", "use database.host instead")
+func WithDeprecatedKey(key, replacement, message string) Option {
+	return func(c *Conflex) error {
+		if key == "" {
+			return errors.New("deprecated key cannot be empty")
+		}
+		if c.normalizeKey(key) == c.normalizeKey(replacement) {
+			return errors.New("deprecated key cannot be its own replacement")
+		}
+		c.deprecatedKeys = append(c.deprecatedKeys, deprecatedKey{key: key, replacement: replacement, message: message})
+		return nil
+	}
+}
+
+// applyDeprecations copies the values of deprecated keys present in values to their replacements.
+// If warn is set, it also warns about deprecated keys that have just appeared.
+func (c *Conflex) applyDeprecations(values map[string]any, warn bool) {
+	for _, d := range c.deprecatedKeys {
+		value := c.lookup(values, d.key)
+		if warn && c.trackDeprecation(d.key, value != nil) {
+			c.log().Warn("deprecated configuration key", "key", d.key, "replacement", d.replacement, "message", d.message)
+		}
+
+		if value != nil && d.replacement != "" && c.lookup(values, d.replacement) == nil {
+			setValue(values, splitKey(c.normalizeKey(d.replacement)), copyValue(value))
+		}
+	}
+}
+
+// trackDeprecation records whether a deprecated key is present and reports whether it was just found,
+// so each appearance is only warned about once.
+func (c *Conflex) trackDeprecation(key string, present bool) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !present {
+		delete(c.deprecationsWarned, key)
+		return false
+	}
+	if c.deprecationsWarned[key] {
+		return false
+	}
+	if c.deprecationsWarned == nil {
+		c.deprecationsWarned = make(map[string]bool)
+	}
+	c.deprecationsWarned[key] = true
+	return true
+}
@@ -0,0 +1,107 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type DeprecatedTestSuite struct {
+	suite.Suite
+	logs *bytes.Buffer
+}
+
+func TestDeprecatedTestSuite(t *testing.T) {
+	suite.Run(t, new(DeprecatedTestSuite))
+}
+
+func (s *DeprecatedTestSuite) SetupTest() {
+	s.logs = &bytes.Buffer{}
+}
+
+func (s *DeprecatedTestSuite) newConflex(src Source, options ...Option) *Conflex {
+	options = append([]Option{
+		WithSource(src),
+		WithLogger(slog.New(slog.NewTextHandler(s.logs, nil))),
+	}, options...)
+	c, err := New(options...)
+	s.Require().NoError(err)
+	return c
+}
+
+func (s *DeprecatedTestSuite) TestMapsValueAndWarns() {
+	var cfg struct {
+		Database struct {
+			Host string `conflex:"host"`
+		} `conflex:"database"`
+	}
+	c := s.newConflex(
+		&mockSource{conf: map[string]any{"db": map[string]any{"host": "db.internal"}}},
+		WithDeprecatedKey("db.host", "database.host", "use database.host instead"),
+		WithBinding(&cfg),
+	)
+	s.Require().NoError(c.Load(context.Background()))
+
+	s.Equal("db.internal", cfg.Database.Host)
+	s.Equal("db.internal", c.GetString("database.host"))
+	s.Equal("db.internal", c.GetString("db.host"), "the deprecated key is kept")
+	s.Contains(s.logs.String(), "deprecated configuration key")
+	s.Contains(s.logs.String(), "use database.host instead")
+	s.NotContains(s.logs.String(), "db.internal", "values are not logged")
+}
+
+func (s *DeprecatedTestSuite) TestReplacementTakesPrecedence() {
+	c := s.newConflex(
+		&mockSource{conf: map[string]any{"timeout": "10s", "http_timeout": "30s"}},
+		WithDeprecatedKey("timeout", "http_timeout", ""),
+	)
+	s.Require().NoError(c.Load(context.Background()))
+	s.Equal("30s", c.GetString("http_timeout"))
+}
+
+func (s *DeprecatedTestSuite) TestWarnsOncePerAppearance() {
+	src := &mockSyncSource{conf: map[string]any{"legacy": true}}
+	c := s.newConflex(src, WithDeprecatedKey("legacy", "", "legacy mode is going away"))
+
+	s.Require().NoError(c.Load(context.Background()))
+	s.Require().NoError(c.Load(context.Background()))
+	s.Equal(1, strings.Count(s.logs.String(), "deprecated configuration key"))
+
+	src.set(map[string]any{}, nil)
+	s.Require().NoError(c.Load(context.Background()))
+	src.set(map[string]any{"legacy": true}, nil)
+	s.Require().NoError(c.Load(context.Background()))
+	s.Equal(2, strings.Count(s.logs.String(), "deprecated configuration key"))
+}
+
+func (s *DeprecatedTestSuite) TestAbsentKey() {
+	c := s.newConflex(&mockSource{conf: map[string]any{"other": 1}}, WithDeprecatedKey("legacy", "modern", ""))
+	s.Require().NoError(c.Load(context.Background()))
+	s.Empty(s.logs.String())
+	s.Nil(c.Get("modern"))
+}
+
+func (s *DeprecatedTestSuite) TestInvalid() {
+	_, err := NewStrict(WithDeprecatedKey("", "new", ""))
+	s.Error(err)
+	_, err = NewStrict(WithDeprecatedKey("Key", "key", ""))
+	s.Error(err)
+}
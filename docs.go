@@ -0,0 +1,221 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// DocsHandler returns an http.Handler serving a browsable HTML page that documents the configuration:
+// the configured sources, the last reload, and for every key its schema type, description and default,
+// its current value and the source that supplied it. It is meant as an internal config explorer for
+// on-call engineers and is typically mounted under a debug path:
+//
+//	mux.Handle("/debug/config/", cfg.DocsHandler())
+//
+// Values of keys classified as secret (see WithSensitivity) are replaced with RedactedValue.
+// Internal values are shown, so the handler must not be exposed outside the organization.
+func (c *Conflex) DocsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+		if err := docsTemplate.Execute(w, c.docsPage()); err != nil {
+			c.log().Error("failed to render configuration docs", "error", err)
+		}
+	})
+}
+
+// docsPage holds the data rendered by DocsHandler.
+type docsPage struct {
+	Sources []SourceInfo
+	Status  ReloadStatus
+	Keys    []docsKey
+}
+
+// LastAttempt formats the time of the last load for display.
+func (p docsPage) LastAttempt() string {
+	if p.Status.LastAttempt.IsZero() {
+		return "never"
+	}
+	return p.Status.LastAttempt.Format(time.RFC3339)
+}
+
+// docsKey documents a single configuration key.
+type docsKey struct {
+	Key         string
+	Type        string
+	Description string
+	Default     string
+	Value       string
+	Set         bool
+	Source      string
+	Sensitivity Sensitivity
+	Deprecated  bool
+}
+
+// docsPage collects the data for the documentation page.
+func (c *Conflex) docsPage() docsPage {
+	page := docsPage{
+		Sources: c.Sources(),
+		Status:  c.ReloadStatus(),
+	}
+
+	c.mu.RLock()
+	var values map[string]any
+	if c.values != nil {
+		values = leafValues(*c.values)
+	}
+	origins := c.keyOrigins
+	c.mu.RUnlock()
+
+	keys := make(map[string]*docsKey)
+	if schema := c.jsonSchemaCompiled; schema != nil {
+		walkSchema(schema, "", map[*jsonschema.Schema]bool{}, func(path string, s *jsonschema.Schema) {
+			key := &docsKey{Key: c.normalizeKey(path), Description: s.Description, Deprecated: s.Deprecated}
+			if s.Types != nil {
+				key.Type = strings.Join(s.Types.ToStrings(), ", ")
+			}
+			if s.Default != nil {
+				key.Default = formatDocsValue(*s.Default)
+			}
+			keys[key.Key] = key
+		})
+	}
+
+	for path, value := range values {
+		key, ok := keys[path]
+		if !ok {
+			key = &docsKey{Key: path}
+			keys[path] = key
+		}
+		key.Set = true
+		key.Value = formatDocsValue(value)
+		key.Source = origins[path]
+	}
+
+	for _, key := range keys {
+		key.Sensitivity = c.Sensitivity(key.Key)
+		if key.Sensitivity == SensitivitySecret {
+			if key.Set {
+				key.Value = RedactedValue
+			}
+			if key.Default != "" {
+				key.Default = RedactedValue
+			}
+		}
+		page.Keys = append(page.Keys, *key)
+	}
+	sort.Slice(page.Keys, func(i, j int) bool { return page.Keys[i].Key < page.Keys[j].Key })
+
+	return page
+}
+
+// walkSchema calls fn for every leaf property of the object schema s, passing its dot-separated path.
+// References are followed; visited guards against recursive schemas.
+func walkSchema(s *jsonschema.Schema, prefix string, visited map[*jsonschema.Schema]bool, fn func(path string, s *jsonschema.Schema)) {
+	for s.Ref != nil && len(s.Properties) == 0 {
+		s = s.Ref
+	}
+	if visited[s] {
+		return
+	}
+	visited[s] = true
+	defer delete(visited, s)
+
+	for name, prop := range s.Properties {
+		path := name
+		if prefix != "" {
+			path = prefix + "." + name
+		}
+		target := prop
+		for target.Ref != nil && len(target.Properties) == 0 {
+			target = target.Ref
+		}
+		if len(target.Properties) > 0 {
+			walkSchema(target, path, visited, fn)
+			continue
+		}
+		if prop.Description == "" {
+			// Keep the description of the referencing property, but fall back to the referenced one.
+			prop = target
+		}
+		fn(path, prop)
+	}
+}
+
+// formatDocsValue formats a configuration value for display.
+func formatDocsValue(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return val
+	case fmt.Stringer:
+		return val.String()
+	}
+	if data, err := json.Marshal(v); err == nil {
+		return string(data)
+	}
+	return fmt.Sprint(v)
+}
+
+var docsTemplate = template.Must(template.New("docs").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Configuration</title>
+<style>
+body { font-family: system-ui, sans-serif; margin: 2em; color: #222; }
+table { border-collapse: collapse; width: 100%; margin-bottom: 2em; }
+th, td { border: 1px solid #ddd; padding: 4px 8px; text-align: left; vertical-align: top; }
+th { background: #f4f4f4; }
+td.value, td.default { font-family: monospace; white-space: pre-wrap; word-break: break-all; }
+tr.unset td.value { color: #999; }
+tr.deprecated td.key { text-decoration: line-through; }
+#filter { width: 100%; padding: 6px; margin-bottom: 1em; box-sizing: border-box; }
+</style>
+</head>
+<body>
+<h1>Configuration</h1>
+<p>Last load: {{.LastAttempt}}{{if .Status.LastError}} &mdash; failed: {{.Status.LastError}}{{end}} ({{.Status.Successes}} succeeded, {{.Status.Failures}} failed)</p>
+<h2>Sources</h2>
+<p>Sources are merged in order; later sources override earlier ones.</p>
+<table>
+<tr><th>Name</th><th>Type</th><th>Target</th><th>Options</th></tr>
+{{range .Sources}}<tr><td>{{.Name}}</td><td>{{.Type}}</td><td>{{.Target}}</td><td>{{range $k, $v := .Options}}{{$k}}={{$v}} {{end}}</td></tr>
+{{end}}</table>
+<h2>Keys</h2>
+<input id="filter" type="search" placeholder="Filter keys" oninput="for (const row of document.querySelectorAll('tr[data-key]')) row.hidden = !row.dataset.key.toLowerCase().includes(this.value.toLowerCase())">
+<table>
+<tr><th>Key</th><th>Type</th><th>Description</th><th>Default</th><th>Value</th><th>Source</th><th>Sensitivity</th></tr>
+{{range .Keys}}<tr data-key="{{.Key}}"{{if not .Set}} class="unset"{{else if .Deprecated}} class="deprecated"{{end}}><td class="key">{{.Key}}</td><td>{{.Type}}</td><td>{{.Description}}</td><td class="default">{{.Default}}</td><td class="value">{{if .Set}}{{.Value}}{{else}}(not set){{end}}</td><td>{{.Source}}</td><td>{{.Sensitivity}}</td></tr>
+{{end}}</table>
+</body>
+</html>
+`))
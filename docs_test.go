@@ -0,0 +1,118 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type DocsTestSuite struct {
+	suite.Suite
+}
+
+func TestDocsTestSuite(t *testing.T) {
+	suite.Run(t, new(DocsTestSuite))
+}
+
+const docsSchema = `{
+	"type": "object",
+	"properties": {
+		"server": {
+			"type": "object",
+			"properties": {
+				"port": {"type": "integer", "description": "Port to listen on", "default": 8080},
+				"host": {"type": "string", "default": "localhost"}
+			}
+		},
+		"database": {"$ref": "#/$defs/database"}
+	},
+	"$defs": {
+		"database": {
+			"type": "object",
+			"properties": {
+				"password": {"type": "string", "description": "Database password"}
+			}
+		}
+	}
+}`
+
+func (s *DocsTestSuite) newConflex() *Conflex {
+	c, err := New(
+		WithSource(&mockSource{conf: map[string]any{"server": map[string]any{"port": 8080}, "database": map[string]any{"password": "p<ss>"}}}),
+		WithSource(&mockSource{conf: map[string]any{"server": map[string]any{"port": 9090}, "extra": []any{1, 2}}}),
+		WithJSONSchema([]byte(docsSchema)),
+		WithSensitivity(SensitivitySecret, "database.password"),
+	)
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+	return c
+}
+
+func (s *DocsTestSuite) TestDocsPage() {
+	page := s.newConflex().docsPage()
+
+	s.Len(page.Sources, 2)
+	s.True(page.Status.Healthy())
+	s.Equal([]docsKey{
+		{Key: "database.password", Type: "string", Description: "Database password", Value: RedactedValue, Set: true, Source: "source[0]", Sensitivity: SensitivitySecret},
+		{Key: "extra", Value: "[1,2]", Set: true, Source: "source[1]"},
+		{Key: "server.host", Type: "string", Default: "localhost"},
+		{Key: "server.port", Type: "integer", Description: "Port to listen on", Default: "8080", Value: "9090", Set: true, Source: "source[1]"},
+	}, page.Keys)
+}
+
+func (s *DocsTestSuite) TestDocsHandler() {
+	handler := s.newConflex().DocsHandler()
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/config/", nil))
+
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal("text/html; charset=utf-8", rec.Header().Get("Content-Type"))
+	body := rec.Body.String()
+	s.Contains(body, "Port to listen on")
+	s.Contains(body, "source[1]")
+	s.Contains(body, RedactedValue)
+	s.NotContains(body, "p<ss>")
+	s.NotContains(body, "p&lt;ss&gt;")
+}
+
+func (s *DocsTestSuite) TestDocsHandlerEscapesValues() {
+	c, err := New(WithSource(&mockSource{conf: map[string]any{"banner": "<script>alert(1)</script>"}}))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	rec := httptest.NewRecorder()
+	c.DocsHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	s.NotContains(rec.Body.String(), "<script>alert(1)</script>")
+	s.Contains(rec.Body.String(), "&lt;script&gt;")
+}
+
+func (s *DocsTestSuite) TestDocsHandlerMethodNotAllowed() {
+	c, err := New()
+	s.Require().NoError(err)
+
+	rec := httptest.NewRecorder()
+	c.DocsHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", nil))
+
+	s.Equal(http.StatusMethodNotAllowed, rec.Code)
+	s.Equal("GET, HEAD", rec.Header().Get("Allow"))
+}
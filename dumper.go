@@ -15,9 +15,162 @@
 // Package conflex provides a flexible configuration package.
 package conflex
 
-import "context"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/spf13/cast"
+)
 
 // Dumper is an interface that defines a method for dumping a map of string to any values.
 type Dumper interface {
 	Dump(ctx context.Context, values *map[string]any) error
 }
+
+// WithNamedDumper adds a dumper under the given name. The name is used instead of the dumper's position,
+// such as "dumper[2]", in the errors returned by Dump. Names must be unique.
+func WithNamedDumper(name string, dumper Dumper) Option {
+	return func(c *Conflex) error {
+		if dumper == nil {
+			return errors.New("dumper cannot be nil")
+		}
+		if strings.TrimSpace(name) == "" {
+			return errors.New("dumper name cannot be empty")
+		}
+		if strings.HasPrefix(name, "dumper[") {
+			return fmt.Errorf("dumper name %q is reserved for unnamed dumpers", name)
+		}
+		for i := range c.dumpers {
+			if c.dumperName(i) == name {
+				return fmt.Errorf("duplicate dumper name %q", name)
+			}
+		}
+		c.addDumper(dumper, name)
+		return nil
+	}
+}
+
+// addDumper registers dumper under name, which is empty for unnamed dumpers.
+func (c *Conflex) addDumper(dumper Dumper, name string) {
+	c.dumpers = append(c.dumpers, dumper)
+	c.dumperNames = append(c.dumperNames, name)
+}
+
+// dumperName returns the name of the i-th dumper, or its position if it has no name.
+func (c *Conflex) dumperName(i int) string {
+	if i < len(c.dumperNames) && c.dumperNames[i] != "" {
+		return c.dumperNames[i]
+	}
+	return fmt.Sprintf("dumper[%d]", i)
+}
+
+// WithScopedDumper adds a dumper that only receives the configuration under the given key prefix.
+// The values keep their full paths, so dumping "metrics" passes {"metrics": {...}} to the dumper, and the
+// output can be loaded back as a source without moving keys. If nothing is configured under the prefix,
+// the dumper receives an empty map. This allows sending each section of the configuration to a different
+// destination during a single Dump call, and keeping sensitive sections out of every dumper.
+func WithScopedDumper(prefix string, dumper Dumper) Option {
+	return func(c *Conflex) error {
+		if dumper == nil {
+			return errors.New("dumper cannot be nil")
+		}
+		prefix = strings.Trim(prefix, ".")
+		if prefix == "" {
+			return errors.New("dumper prefix cannot be empty")
+		}
+		c.addDumper(&scopedDumper{path: splitKey(c.normalizeKey(prefix)), dumper: dumper}, "")
+		return nil
+	}
+}
+
+// scopedDumper passes only the values under path to the wrapped dumper.
+type scopedDumper struct {
+	path   []string
+	dumper Dumper
+}
+
+// Dump implements Dumper.
+func (d *scopedDumper) Dump(ctx context.Context, values *map[string]any) error {
+	scoped := map[string]any{}
+	if values != nil {
+		scoped = extractPath(*values, d.path)
+	}
+	return d.dumper.Dump(ctx, &scoped)
+}
+
+// WithOverridesDumper adds a dumper that only receives the values that differ from their defaults, as declared
+// with the "default" tag option on bound structs. Keys that are set to their default value, or only have it
+// because nothing else set them, are left out, so the dump is a minimal override file that can be checked into
+// an environment repository and loaded on top of the defaults to reproduce the configuration.
+//
+// Values are compared after converting both to text, so a port of 8080 read from JSON as a float still equals
+// a default of 8080; durations and sizes are compared as written, so "60s" is not considered equal to "1m".
+func WithOverridesDumper(dumper Dumper) Option {
+	return func(c *Conflex) error {
+		if dumper == nil {
+			return errors.New("dumper cannot be nil")
+		}
+		c.addDumper(&overridesDumper{c: c, dumper: dumper}, "")
+		return nil
+	}
+}
+
+// overridesDumper passes only the values that differ from the tag defaults to the wrapped dumper.
+type overridesDumper struct {
+	c      *Conflex
+	dumper Dumper
+}
+
+// Dump implements Dumper.
+func (d *overridesDumper) Dump(ctx context.Context, values *map[string]any) error {
+	overrides := map[string]any{}
+	if values != nil {
+		overrides = d.c.withoutDefaults(*values)
+	}
+	return d.dumper.Dump(ctx, &overrides)
+}
+
+// withoutDefaults returns a copy of values without the keys that hold their default value.
+// Maps that only held such keys are removed as well.
+func (c *Conflex) withoutDefaults(values map[string]any) map[string]any {
+	overrides := copyMap(values)
+	for _, d := range c.defaults {
+		path := splitKey(c.normalizeKey(d.key))
+		if value, ok := lookupPath(overrides, path); ok && equalsDefault(value, d.value) {
+			deleteAndPrune(overrides, path)
+		}
+	}
+	return overrides
+}
+
+// equalsDefault reports whether value equals the default def, either exactly or in text form.
+func equalsDefault(value, def any) bool {
+	if reflect.DeepEqual(value, def) {
+		return true
+	}
+	v, err := cast.ToStringE(value)
+	if err != nil {
+		return false
+	}
+	d, err := cast.ToStringE(def)
+	return err == nil && v == d
+}
+
+// deleteAndPrune removes the value at path from m, together with the maps that become empty as a result.
+func deleteAndPrune(m map[string]any, path []string) {
+	if len(path) == 1 {
+		delete(m, path[0])
+		return
+	}
+	next, ok := m[path[0]].(map[string]any)
+	if !ok {
+		return
+	}
+	deleteAndPrune(next, path[1:])
+	if len(next) == 0 {
+		delete(m, path[0])
+	}
+}
@@ -0,0 +1,24 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dumper provides functionality for dumping configuration data to a target.
+package dumper
+
+import "context"
+
+// Dumper writes configuration values to a target. It has the same method as conflex.Dumper,
+// so dumpers of either package can be used with the other.
+type Dumper interface {
+	Dump(ctx context.Context, values *map[string]any) error
+}
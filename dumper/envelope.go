@@ -0,0 +1,215 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dumper
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"go.companyinfo.dev/conflex/codec"
+)
+
+// EnvelopeVersion is the format version of envelopes. OpenEnvelope rejects envelopes of any other version.
+const EnvelopeVersion = 2
+
+// EnvelopeAlgorithm is the algorithm used to encrypt the data of envelopes with their data key.
+const EnvelopeAlgorithm = "AES256_GCM"
+
+// DataKeyProvider generates data keys with a key management service, such as AWS KMS or GCP KMS.
+type DataKeyProvider interface {
+	// GenerateDataKey returns a new 256-bit data key, in plaintext and wrapped (encrypted) with the master key.
+	// With AWS KMS this is a single GenerateDataKey call; with GCP KMS the key is generated locally and
+	// wrapped with an Encrypt call.
+	GenerateDataKey(ctx context.Context) (plaintext, wrapped []byte, err error)
+}
+
+// DataKeyDecrypter unwraps data keys with a key management service, to open envelopes.
+type DataKeyDecrypter interface {
+	// DecryptDataKey returns the plaintext of a data key wrapped by a DataKeyProvider.
+	DecryptDataKey(ctx context.Context, wrapped []byte) ([]byte, error)
+}
+
+// Envelope is data encrypted with a data key, stored alongside the data key wrapped with a master key that
+// never leaves the key management service. It is written as JSON, with the binary fields in base64.
+type Envelope struct {
+	Version    int    `json:"version"`     // Format version, EnvelopeVersion
+	Algorithm  string `json:"algorithm"`   // Algorithm encrypting the data, EnvelopeAlgorithm
+	WrappedKey []byte `json:"wrapped_key"` // Data key wrapped with the master key
+	Nonce      []byte `json:"nonce"`       // Nonce used to encrypt the data
+	Ciphertext []byte `json:"ciphertext"`  // Encrypted data, including the authentication tag
+}
+
+// SealEnvelope encrypts plaintext with a new data key from provider and returns the JSON-encoded Envelope.
+// The version, algorithm and wrapped key are authenticated along with the ciphertext, so none of them can
+// be swapped without OpenEnvelope failing.
+func SealEnvelope(ctx context.Context, provider DataKeyProvider, plaintext []byte) ([]byte, error) {
+	envelope, err := sealEnvelope(ctx, provider, plaintext)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(envelope)
+}
+
+// sealEnvelope encrypts plaintext with a new data key from provider.
+func sealEnvelope(ctx context.Context, provider DataKeyProvider, plaintext []byte) (*Envelope, error) {
+	key, wrapped, err := provider.GenerateDataKey(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate data key: %w", err)
+	}
+
+	gcm, err := newEnvelopeCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	envelope := &Envelope{
+		Version:    EnvelopeVersion,
+		Algorithm:  EnvelopeAlgorithm,
+		WrappedKey: wrapped,
+		Nonce:      nonce,
+	}
+	envelope.Ciphertext = gcm.Seal(nil, nonce, plaintext, envelope.additionalData())
+	return envelope, nil
+}
+
+// OpenEnvelope decrypts a JSON-encoded Envelope written by SealEnvelope, unwrapping its data key with decrypter.
+func OpenEnvelope(ctx context.Context, decrypter DataKeyDecrypter, data []byte) ([]byte, error) {
+	var envelope Envelope
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return nil, fmt.Errorf("failed to decode envelope: %w", err)
+	}
+	if envelope.Version != EnvelopeVersion || envelope.Algorithm != EnvelopeAlgorithm {
+		return nil, fmt.Errorf("unsupported envelope version %d with algorithm %q", envelope.Version, envelope.Algorithm)
+	}
+
+	key, err := decrypter.DecryptDataKey(ctx, envelope.WrappedKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt data key: %w", err)
+	}
+	gcm, err := newEnvelopeCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(envelope.Nonce) != gcm.NonceSize() {
+		return nil, errors.New("invalid envelope nonce")
+	}
+
+	plaintext, err := gcm.Open(nil, envelope.Nonce, envelope.Ciphertext, envelope.additionalData())
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt envelope: %w", err)
+	}
+	return plaintext, nil
+}
+
+// OpenEnvelopeValues decrypts an Envelope passed to another dumper by an EnvelopeDumper, such as a YAML
+// document loaded back into a map, unwrapping its data key with decrypter.
+func OpenEnvelopeValues(ctx context.Context, decrypter DataKeyDecrypter, values map[string]any) ([]byte, error) {
+	data, err := json.Marshal(values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode envelope: %w", err)
+	}
+	return OpenEnvelope(ctx, decrypter, data)
+}
+
+// additionalData returns the header of the envelope, which is authenticated along with the ciphertext:
+// the version and algorithm, each followed by a NUL byte, then the wrapped key.
+func (e *Envelope) additionalData() []byte {
+	aad := strconv.AppendInt(nil, int64(e.Version), 10)
+	aad = append(aad, 0)
+	aad = append(aad, e.Algorithm...)
+	aad = append(aad, 0)
+	return append(aad, e.WrappedKey...)
+}
+
+// values returns the envelope as a map, with the binary fields in base64 as in its JSON encoding.
+func (e *Envelope) values() map[string]any {
+	return map[string]any{
+		"version":     e.Version,
+		"algorithm":   e.Algorithm,
+		"wrapped_key": base64.StdEncoding.EncodeToString(e.WrappedKey),
+		"nonce":       base64.StdEncoding.EncodeToString(e.Nonce),
+		"ciphertext":  base64.StdEncoding.EncodeToString(e.Ciphertext),
+	}
+}
+
+// EnvelopeDumper is a dumper that encodes the values, encrypts them with a new data key on every dump, and
+// passes the resulting Envelope to another dumper, so any target can hold the encrypted configuration.
+// Use OpenEnvelopeValues, or OpenEnvelope for a target written as JSON, to decrypt it.
+type EnvelopeDumper struct {
+	next     Dumper
+	encoder  codec.Encoder
+	provider DataKeyProvider
+}
+
+// NewEnvelope creates an EnvelopeDumper that encodes the values with encoder, encrypts them with data keys
+// from provider and passes the envelope to next.
+func NewEnvelope(next Dumper, encoder codec.Encoder, provider DataKeyProvider) (*EnvelopeDumper, error) {
+	if next == nil {
+		return nil, errors.New("dumper cannot be nil")
+	}
+	if encoder == nil {
+		return nil, errors.New("encoder cannot be nil")
+	}
+	if provider == nil {
+		return nil, errors.New("data key provider cannot be nil")
+	}
+	return &EnvelopeDumper{next: next, encoder: encoder, provider: provider}, nil
+}
+
+// Dump encodes and encrypts the values, and passes the envelope to the next dumper.
+// The values are passed through Normalize before they are encoded.
+func (d *EnvelopeDumper) Dump(ctx context.Context, values *map[string]any) error {
+	if values != nil {
+		normalized := Normalize(*values)
+		values = &normalized
+	}
+
+	data, err := d.encoder.Encode(values)
+	if err != nil {
+		return fmt.Errorf("failed to encode values: %w", err)
+	}
+
+	envelope, err := sealEnvelope(ctx, d.provider, data)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt values: %w", err)
+	}
+
+	encrypted := envelope.values()
+	return d.next.Dump(ctx, &encrypted)
+}
+
+// newEnvelopeCipher returns the AES-256-GCM cipher for a data key.
+func newEnvelopeCipher(key []byte) (cipher.AEAD, error) {
+	if len(key) != 32 {
+		return nil, fmt.Errorf("data key must be 32 bytes, got %d", len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,216 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dumper
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.companyinfo.dev/conflex/codec"
+)
+
+// localKMS wraps data keys with a local master key, standing in for a key management service.
+type localKMS struct {
+	master cipher.AEAD
+	err    error
+}
+
+func newLocalKMS() *localKMS {
+	key := make([]byte, 32)
+	_, _ = rand.Read(key)
+	block, _ := aes.NewCipher(key)
+	master, _ := cipher.NewGCM(block)
+	return &localKMS{master: master}
+}
+
+func (k *localKMS) GenerateDataKey(context.Context) ([]byte, []byte, error) {
+	if k.err != nil {
+		return nil, nil, k.err
+	}
+	key := make([]byte, 32)
+	_, _ = rand.Read(key)
+	nonce := make([]byte, k.master.NonceSize())
+	_, _ = rand.Read(nonce)
+	return key, k.master.Seal(nonce, nonce, key, nil), nil
+}
+
+func (k *localKMS) DecryptDataKey(_ context.Context, wrapped []byte) ([]byte, error) {
+	size := k.master.NonceSize()
+	return k.master.Open(nil, wrapped[:size], wrapped[size:], nil)
+}
+
+type EnvelopeTestSuite struct {
+	suite.Suite
+}
+
+func TestEnvelopeTestSuite(t *testing.T) {
+	suite.Run(t, new(EnvelopeTestSuite))
+}
+
+func (s *EnvelopeTestSuite) TestSealAndOpen() {
+	kms := newLocalKMS()
+	sealed, err := SealEnvelope(context.Background(), kms, []byte("port: 8080\n"))
+	s.Require().NoError(err)
+
+	var envelope Envelope
+	s.Require().NoError(json.Unmarshal(sealed, &envelope))
+	s.Equal(EnvelopeVersion, envelope.Version)
+	s.Equal(EnvelopeAlgorithm, envelope.Algorithm)
+	s.NotEmpty(envelope.WrappedKey)
+	s.NotContains(string(sealed), "8080")
+
+	plaintext, err := OpenEnvelope(context.Background(), kms, sealed)
+	s.Require().NoError(err)
+	s.Equal("port: 8080\n", string(plaintext))
+
+	_, err = OpenEnvelope(context.Background(), newLocalKMS(), sealed)
+	s.Error(err, "another master key cannot unwrap the data key")
+}
+
+func (s *EnvelopeTestSuite) TestTampered() {
+	kms := newLocalKMS()
+	sealed, err := SealEnvelope(context.Background(), kms, []byte("secret"))
+	s.Require().NoError(err)
+
+	var envelope Envelope
+	s.Require().NoError(json.Unmarshal(sealed, &envelope))
+	envelope.Ciphertext[0] ^= 1
+	tampered, err := json.Marshal(envelope)
+	s.Require().NoError(err)
+
+	_, err = OpenEnvelope(context.Background(), kms, tampered)
+	s.ErrorContains(err, "failed to decrypt envelope")
+
+	for _, version := range []int{1, 3} {
+		envelope.Version = version
+		downgraded, err := json.Marshal(envelope)
+		s.Require().NoError(err)
+		_, err = OpenEnvelope(context.Background(), kms, downgraded)
+		s.ErrorContains(err, "unsupported envelope version", version)
+	}
+}
+
+func (s *EnvelopeTestSuite) TestTamperedHeader() {
+	kms := newLocalKMS()
+	sealed, err := SealEnvelope(context.Background(), kms, []byte("secret"))
+	s.Require().NoError(err)
+
+	var envelope Envelope
+	s.Require().NoError(json.Unmarshal(sealed, &envelope))
+	key, err := kms.DecryptDataKey(context.Background(), envelope.WrappedKey)
+	s.Require().NoError(err)
+	nonce := make([]byte, kms.master.NonceSize())
+	_, _ = rand.Read(nonce)
+
+	// The same data key wrapped again still unwraps, so only the authenticated header catches the swap.
+	envelope.WrappedKey = kms.master.Seal(nonce, nonce, key, nil)
+	data, err := json.Marshal(envelope)
+	s.Require().NoError(err)
+
+	_, err = OpenEnvelope(context.Background(), kms, data)
+	s.ErrorContains(err, "failed to decrypt envelope")
+}
+
+// recordingDumper keeps the values of the last dump.
+type recordingDumper struct {
+	values map[string]any
+}
+
+func (d *recordingDumper) Dump(_ context.Context, values *map[string]any) error {
+	d.values = *values
+	return nil
+}
+
+func (s *EnvelopeTestSuite) TestEnvelopeDumper() {
+	kms := newLocalKMS()
+	next := &recordingDumper{}
+	d, err := NewEnvelope(next, codec.YAMLCodec{}, kms)
+	s.Require().NoError(err)
+
+	s.Require().NoError(d.Dump(context.Background(), &map[string]any{"password": "s3cret"}))
+	s.Equal(EnvelopeVersion, next.values["version"])
+	s.Equal(EnvelopeAlgorithm, next.values["algorithm"])
+	s.NotContains(next.values["ciphertext"], "s3cret")
+
+	plaintext, err := OpenEnvelopeValues(context.Background(), kms, next.values)
+	s.Require().NoError(err)
+	s.Equal("password: s3cret\n", string(plaintext))
+}
+
+func (s *EnvelopeTestSuite) TestEnvelopeDumperYAMLFile() {
+	kms := newLocalKMS()
+	path := filepath.Join(s.T().TempDir(), "config.enc.yaml")
+	d, err := NewEnvelope(NewFile(path, codec.YAMLCodec{}), codec.JSONCodec{}, kms)
+	s.Require().NoError(err)
+	s.Require().NoError(d.Dump(context.Background(), &map[string]any{"port": 8080}))
+
+	data, err := os.ReadFile(path)
+	s.Require().NoError(err)
+	var values map[string]any
+	s.Require().NoError(codec.YAMLCodec{}.Decode(data, &values))
+
+	plaintext, err := OpenEnvelopeValues(context.Background(), kms, values)
+	s.Require().NoError(err)
+	s.JSONEq(`{"port": 8080}`, string(plaintext))
+}
+
+func (s *EnvelopeTestSuite) TestNewEnvelopeErrors() {
+	kms := newLocalKMS()
+	_, err := NewEnvelope(nil, codec.JSONCodec{}, kms)
+	s.ErrorContains(err, "dumper cannot be nil")
+	_, err = NewEnvelope(&recordingDumper{}, nil, kms)
+	s.ErrorContains(err, "encoder cannot be nil")
+	_, err = NewEnvelope(&recordingDumper{}, codec.JSONCodec{}, nil)
+	s.ErrorContains(err, "data key provider cannot be nil")
+
+	kms.err = errors.New("access denied")
+	d, err := NewEnvelope(&recordingDumper{}, codec.JSONCodec{}, kms)
+	s.Require().NoError(err)
+	s.ErrorIs(d.Dump(context.Background(), &map[string]any{"a": "b"}), kms.err)
+}
+
+func (s *EnvelopeTestSuite) TestFileDumper() {
+	kms := newLocalKMS()
+	path := filepath.Join(s.T().TempDir(), "config.enc")
+	d := NewFileWithPermissions(path, codec.YAMLCodec{}, 0o600).WithEnvelopeEncryption(kms)
+
+	s.Require().NoError(d.Dump(context.Background(), &map[string]any{"password": "s3cret"}))
+
+	data, err := os.ReadFile(path)
+	s.Require().NoError(err)
+	s.NotContains(string(data), "s3cret")
+
+	plaintext, err := OpenEnvelope(context.Background(), kms, data)
+	s.Require().NoError(err)
+	s.Equal("password: s3cret\n", string(plaintext))
+}
+
+func (s *EnvelopeTestSuite) TestFileDumperKeyError() {
+	kms := newLocalKMS()
+	kms.err = errors.New("access denied")
+	path := filepath.Join(s.T().TempDir(), "config.enc")
+	d := NewFile(path, codec.YAMLCodec{}).WithEnvelopeEncryption(kms)
+
+	s.ErrorIs(d.Dump(context.Background(), &map[string]any{"a": "b"}), kms.err)
+	s.NoFileExists(path)
+}
@@ -0,0 +1,168 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package etcd provides a dumper that writes the configuration to etcd.
+//
+// It is a separate module, so programs that do not dump to etcd do not depend on the etcd client and its gRPC
+// dependencies.
+package etcd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.companyinfo.dev/conflex/codec"
+	"go.companyinfo.dev/conflex/dumper"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// KV is the part of the etcd client used by Dumper. *clientv3.Client and clientv3.KV implement it.
+type KV interface {
+	Put(ctx context.Context, key, val string, opts ...clientv3.OpOption) (*clientv3.PutResponse, error)
+	Txn(ctx context.Context) clientv3.Txn
+}
+
+// Dumper is a dumper that writes the configuration to etcd, so a snapshot of it can be shared across a cluster.
+// It writes either the encoded configuration to a single key, or one key per value below a prefix.
+type Dumper struct {
+	kv      KV
+	key     string
+	encoder codec.Encoder
+}
+
+// New creates a Dumper that encodes the configuration with encoder and writes it to key.
+func New(kv KV, key string, encoder codec.Encoder) (*Dumper, error) {
+	if kv == nil {
+		return nil, errors.New("etcd client cannot be nil")
+	}
+	if key == "" {
+		return nil, errors.New("key cannot be empty")
+	}
+	if encoder == nil {
+		return nil, errors.New("encoder cannot be nil")
+	}
+	return &Dumper{kv: kv, key: key, encoder: encoder}, nil
+}
+
+// NewTree creates a Dumper that writes every value to its own key below prefix, with the key path
+// joined by slashes: {"server": {"port": 8080}} is written to "<prefix>/server/port" as "8080". List elements
+// are keyed by their index. Strings are written as they are, other scalars in their usual text form, and empty
+// maps and lists as JSON.
+//
+// Every dump replaces the whole tree in a single transaction, so keys that are no longer in the configuration
+// are removed and readers never see a mix of two dumps. The transaction holds one operation per value, which
+// must stay within the server's --max-txn-ops limit (128 by default).
+func NewTree(kv KV, prefix string) (*Dumper, error) {
+	if kv == nil {
+		return nil, errors.New("etcd client cannot be nil")
+	}
+	prefix = strings.TrimSuffix(prefix, "/")
+	if prefix == "" {
+		return nil, errors.New("prefix cannot be empty")
+	}
+	return &Dumper{kv: kv, key: prefix}, nil
+}
+
+// Dump writes the provided values to etcd. The values are passed through dumper.Normalize first.
+func (e *Dumper) Dump(ctx context.Context, values *map[string]any) error {
+	var normalized map[string]any
+	if values != nil {
+		normalized = dumper.Normalize(*values)
+	}
+
+	if e.encoder == nil {
+		return e.dumpTree(ctx, normalized)
+	}
+
+	data, err := e.encoder.Encode(&normalized)
+	if err != nil {
+		return fmt.Errorf("failed to encode values: %w", err)
+	}
+	if _, err := e.kv.Put(ctx, e.key, string(data)); err != nil {
+		return fmt.Errorf("failed to write etcd key %s: %w", e.key, err)
+	}
+	return nil
+}
+
+// dumpTree replaces the keys below the prefix with one key per value.
+func (e *Dumper) dumpTree(ctx context.Context, values map[string]any) error {
+	leaves := make(map[string]string)
+	for k, v := range values {
+		if err := appendLeaves(leaves, e.key+"/"+k, v); err != nil {
+			return err
+		}
+	}
+
+	keys := make([]string, 0, len(leaves))
+	for k := range leaves {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	ops := make([]clientv3.Op, 0, len(keys)+1)
+	ops = append(ops, clientv3.OpDelete(e.key+"/", clientv3.WithPrefix()))
+	for _, k := range keys {
+		ops = append(ops, clientv3.OpPut(k, leaves[k]))
+	}
+
+	if _, err := e.kv.Txn(ctx).Then(ops...).Commit(); err != nil {
+		return fmt.Errorf("failed to write etcd keys below %s: %w", e.key, err)
+	}
+	return nil
+}
+
+// appendLeaves adds the text form of value to leaves under key, descending into non-empty maps and lists.
+func appendLeaves(leaves map[string]string, key string, value any) error {
+	switch v := value.(type) {
+	case map[string]any:
+		if len(v) > 0 {
+			for k, item := range v {
+				if err := appendLeaves(leaves, key+"/"+k, item); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	case []any:
+		if len(v) > 0 {
+			for i, item := range v {
+				if err := appendLeaves(leaves, key+"/"+strconv.Itoa(i), item); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	case string:
+		leaves[key] = v
+		return nil
+	case nil:
+		leaves[key] = ""
+		return nil
+	case bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		leaves[key] = fmt.Sprint(v)
+		return nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode value of %s: %w", key, err)
+	}
+	leaves[key] = string(data)
+	return nil
+}
@@ -0,0 +1,161 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcd
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.companyinfo.dev/conflex/codec"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// mockKV is an in-memory KV.
+type mockKV struct {
+	data map[string]string
+	err  error
+	txns int
+}
+
+func newMockKV() *mockKV {
+	return &mockKV{data: make(map[string]string)}
+}
+
+func (m *mockKV) Put(_ context.Context, key, val string, _ ...clientv3.OpOption) (*clientv3.PutResponse, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.data[key] = val
+	return &clientv3.PutResponse{}, nil
+}
+
+func (m *mockKV) Txn(context.Context) clientv3.Txn {
+	return &mockTxn{kv: m}
+}
+
+type mockTxn struct {
+	kv  *mockKV
+	ops []clientv3.Op
+}
+
+func (t *mockTxn) If(...clientv3.Cmp) clientv3.Txn  { return t }
+func (t *mockTxn) Else(...clientv3.Op) clientv3.Txn { return t }
+
+func (t *mockTxn) Then(ops ...clientv3.Op) clientv3.Txn {
+	t.ops = append(t.ops, ops...)
+	return t
+}
+
+func (t *mockTxn) Commit() (*clientv3.TxnResponse, error) {
+	if t.kv.err != nil {
+		return nil, t.kv.err
+	}
+	t.kv.txns++
+	for _, op := range t.ops {
+		key := string(op.KeyBytes())
+		switch {
+		case op.IsDelete():
+			for k := range t.kv.data {
+				if strings.HasPrefix(k, key) {
+					delete(t.kv.data, k)
+				}
+			}
+		case op.IsPut():
+			t.kv.data[key] = string(op.ValueBytes())
+		}
+	}
+	return &clientv3.TxnResponse{Succeeded: true}, nil
+}
+
+type DumperTestSuite struct {
+	suite.Suite
+}
+
+func TestDumperTestSuite(t *testing.T) {
+	suite.Run(t, new(DumperTestSuite))
+}
+
+func (s *DumperTestSuite) TestSingleKey() {
+	kv := newMockKV()
+	d, err := New(kv, "config/app", codec.JSONCodec{})
+	s.Require().NoError(err)
+
+	values := map[string]any{"server": map[string]any{"port": 8080}, "timeout": 5 * time.Second}
+	s.Require().NoError(d.Dump(context.Background(), &values))
+	s.JSONEq(`{"server": {"port": 8080}, "timeout": "5s"}`, kv.data["config/app"])
+}
+
+func (s *DumperTestSuite) TestTree() {
+	kv := newMockKV()
+	kv.data["config/app/stale"] = "old"
+	kv.data["config/other"] = "kept"
+	d, err := NewTree(kv, "config/app/")
+	s.Require().NoError(err)
+
+	values := map[string]any{
+		"server": map[string]any{"host": "localhost", "port": 8080, "tls": true},
+		"hosts":  []any{"a", "b"},
+		"ratio":  0.5,
+		"empty":  map[string]any{},
+		"none":   nil,
+		"wait":   time.Minute,
+	}
+	s.Require().NoError(d.Dump(context.Background(), &values))
+
+	s.Equal(map[string]string{
+		"config/app/server/host": "localhost",
+		"config/app/server/port": "8080",
+		"config/app/server/tls":  "true",
+		"config/app/hosts/0":     "a",
+		"config/app/hosts/1":     "b",
+		"config/app/ratio":       "0.5",
+		"config/app/empty":       "{}",
+		"config/app/none":        "",
+		"config/app/wait":        "1m0s",
+		"config/other":           "kept",
+	}, kv.data)
+	s.Equal(1, kv.txns)
+}
+
+func (s *DumperTestSuite) TestErrors() {
+	kv := newMockKV()
+	kv.err = errors.New("etcdserver: request timed out")
+
+	d, err := New(kv, "config/app", codec.JSONCodec{})
+	s.Require().NoError(err)
+	s.ErrorContains(d.Dump(context.Background(), &map[string]any{}), "failed to write etcd key config/app")
+
+	d, err = NewTree(kv, "config/app")
+	s.Require().NoError(err)
+	s.ErrorContains(d.Dump(context.Background(), &map[string]any{"a": 1}), "failed to write etcd keys below config/app")
+
+	_, err = New(nil, "k", codec.JSONCodec{})
+	s.Error(err)
+	_, err = New(kv, "", codec.JSONCodec{})
+	s.Error(err)
+	_, err = New(kv, "k", nil)
+	s.Error(err)
+	_, err = NewTree(nil, "k")
+	s.Error(err)
+	_, err = NewTree(kv, "/")
+	s.Error(err)
+}
+
+// Compile-time check that the etcd client satisfies KV.
+var _ KV = (*clientv3.Client)(nil)
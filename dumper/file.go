@@ -28,6 +28,7 @@ type File struct {
 	path        string
 	encoder     codec.Encoder
 	permissions os.FileMode
+	envelope    DataKeyProvider
 }
 
 const (
@@ -54,13 +55,33 @@ func NewFileWithPermissions(path string, encoder codec.Encoder, permissions os.F
 	}
 }
 
+// WithEnvelopeEncryption encrypts the encoded values with a new data key from provider on every dump, and writes
+// them as an Envelope that stores the wrapped data key alongside the ciphertext. Use OpenEnvelope to read the file.
+// It returns f, so it can be chained with NewFile. Use NewEnvelope to encrypt the values for other dumpers.
+func (f *File) WithEnvelopeEncryption(provider DataKeyProvider) *File {
+	f.envelope = provider
+	return f
+}
+
 // Dump writes the provided values to the file specified by the File instance.
-func (f *File) Dump(_ context.Context, values *map[string]any) error {
+// The values are passed through Normalize before they are encoded.
+func (f *File) Dump(ctx context.Context, values *map[string]any) error {
+	if values != nil {
+		normalized := Normalize(*values)
+		values = &normalized
+	}
+
 	data, err := f.encoder.Encode(values)
 	if err != nil {
 		return fmt.Errorf("failed to encode values: %w", err)
 	}
 
+	if f.envelope != nil {
+		if data, err = SealEnvelope(ctx, f.envelope, data); err != nil {
+			return fmt.Errorf("failed to encrypt values: %w", err)
+		}
+	}
+
 	if err := os.WriteFile(f.path, data, f.permissions); err != nil {
 		return fmt.Errorf("failed to write file: %w", err)
 	}
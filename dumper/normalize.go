@@ -0,0 +1,142 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dumper provides functionality for dumping configuration data to a target.
+package dumper
+
+import (
+	"encoding/base64"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// Normalizer converts a value that encoders may not support into one they do.
+// It returns the converted value and true if it handled v, or false to leave v to the next normalizer.
+type Normalizer func(v any) (any, bool)
+
+var (
+	normalizersMu sync.RWMutex
+	normalizers   []Normalizer
+)
+
+// RegisterNormalizer registers a normalizer for custom types.
+// Registered normalizers run before the built-in ones, in registration order, and the first one
+// that handles a value wins.
+func RegisterNormalizer(normalizer Normalizer) {
+	if normalizer == nil {
+		return
+	}
+
+	normalizersMu.Lock()
+	defer normalizersMu.Unlock()
+
+	normalizers = append(normalizers, normalizer)
+}
+
+// Normalize returns a copy of values in which every value is converted into a form that all codecs can encode.
+// Registered normalizers are applied first; then time.Duration values become duration strings ("1m30s"),
+// time.Time values become RFC3339 strings, and []byte values become base64 strings.
+// Nested maps and slices are normalized recursively.
+func Normalize(values map[string]any) map[string]any {
+	if values == nil {
+		return nil
+	}
+
+	normalizersMu.RLock()
+	custom := normalizers
+	normalizersMu.RUnlock()
+
+	return normalizeMap(values, custom)
+}
+
+func normalizeMap(m map[string]any, custom []Normalizer) map[string]any {
+	out := make(map[string]any, len(m))
+	for k, v := range m {
+		out[k] = normalizeValue(v, custom)
+	}
+	return out
+}
+
+func normalizeValue(v any, custom []Normalizer) any {
+	if v == nil {
+		return nil
+	}
+
+	for _, normalizer := range custom {
+		if normalized, ok := normalizer(v); ok {
+			return normalized
+		}
+	}
+
+	switch val := v.(type) {
+	case time.Duration:
+		return val.String()
+	case time.Time:
+		return val.Format(time.RFC3339Nano)
+	case []byte:
+		return base64.StdEncoding.EncodeToString(val)
+	case map[string]any:
+		return normalizeMap(val, custom)
+	case []any:
+		out := make([]any, len(val))
+		for i, item := range val {
+			out[i] = normalizeValue(item, custom)
+		}
+		return out
+	}
+
+	// Typed containers, such as []time.Duration or map[string]time.Time, are normalized element by element.
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		if isPlainType(rv.Type().Elem()) {
+			return v
+		}
+		out := make([]any, rv.Len())
+		for i := range out {
+			out[i] = normalizeValue(rv.Index(i).Interface(), custom)
+		}
+		return out
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String || isPlainType(rv.Type().Elem()) {
+			return v
+		}
+		out := make(map[string]any, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			out[iter.Key().String()] = normalizeValue(iter.Value().Interface(), custom)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// isPlainType reports whether t is an unnamed boolean, numeric, or string type,
+// which every encoder supports and no normalizer needs to see.
+func isPlainType(t reflect.Type) bool {
+	if t.PkgPath() != "" {
+		return false
+	}
+	switch t.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dumper
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.companyinfo.dev/conflex/codec"
+)
+
+type NormalizeTestSuite struct {
+	suite.Suite
+	saved []Normalizer
+}
+
+func TestNormalizeTestSuite(t *testing.T) {
+	suite.Run(t, new(NormalizeTestSuite))
+}
+
+func (s *NormalizeTestSuite) SetupTest() {
+	normalizersMu.Lock()
+	s.saved = normalizers
+	normalizersMu.Unlock()
+}
+
+func (s *NormalizeTestSuite) TearDownTest() {
+	normalizersMu.Lock()
+	normalizers = s.saved
+	normalizersMu.Unlock()
+}
+
+func (s *NormalizeTestSuite) TestNormalize_BuiltIn() {
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	values := map[string]any{
+		"timeout": 90 * time.Second,
+		"created": ts,
+		"key":     []byte("hi"),
+		"plain":   []string{"a", "b"},
+		"nested": map[string]any{
+			"list": []any{time.Minute, "x"},
+		},
+		"typed": map[string]time.Duration{"read": time.Second},
+		"slice": []time.Duration{time.Millisecond},
+	}
+
+	s.Equal(map[string]any{
+		"timeout": "1m30s",
+		"created": "2024-01-02T03:04:05Z",
+		"key":     "aGk=",
+		"plain":   []string{"a", "b"},
+		"nested": map[string]any{
+			"list": []any{"1m0s", "x"},
+		},
+		"typed": map[string]any{"read": "1s"},
+		"slice": []any{"1ms"},
+	}, Normalize(values))
+
+	// The input is left untouched.
+	s.Equal(90*time.Second, values["timeout"])
+	s.Nil(Normalize(nil))
+}
+
+func (s *NormalizeTestSuite) TestRegisterNormalizer() {
+	RegisterNormalizer(func(v any) (any, bool) {
+		ip, ok := v.(net.IP)
+		if !ok {
+			return nil, false
+		}
+		return ip.String(), true
+	})
+	RegisterNormalizer(nil)
+
+	s.Equal(map[string]any{"ip": "10.0.0.1"}, Normalize(map[string]any{"ip": net.ParseIP("10.0.0.1")}))
+}
+
+func (s *NormalizeTestSuite) TestFileDump_Normalizes() {
+	path := filepath.Join(s.T().TempDir(), "out.toml")
+	encoder, err := codec.GetEncoder(codec.TypeTOML)
+	s.Require().NoError(err)
+
+	values := map[string]any{"server": map[string]any{"timeout": 5 * time.Second}}
+	s.Require().NoError(NewFile(path, encoder).Dump(context.Background(), &values))
+
+	data, err := os.ReadFile(path)
+	s.Require().NoError(err)
+	s.Contains(string(data), `timeout = "5s"`)
+}
@@ -0,0 +1,244 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dumper
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SOPSVersion is the SOPS format version written to the metadata of encrypted documents.
+const SOPSVersion = "3.8.1"
+
+// SOPSUnencryptedSuffix marks keys whose values are stored in plaintext, as in SOPS.
+const SOPSUnencryptedSuffix = "_unencrypted"
+
+// SOPSKey protects the data key of a SOPS document for one recipient, such as an age public key or a KMS key.
+// Implementations typically wrap filippo.io/age or a cloud KMS client.
+type SOPSKey interface {
+	// Group returns the name of the SOPS metadata section listing keys of this type, e.g. "age" or "kms".
+	Group() string
+	// Encrypt encrypts the data key and returns the metadata entry SOPS needs to decrypt it,
+	// e.g. {"recipient": "age1...", "enc": "-----BEGIN AGE ENCRYPTED FILE-----..."} for age.
+	Encrypt(ctx context.Context, dataKey []byte) (map[string]any, error)
+}
+
+// SOPS is a dumper that encrypts the values the way SOPS does before passing them on to another dumper,
+// typically a File with the YAML or JSON encoder, so the dump can be decrypted and edited with the sops tool.
+// Every value is encrypted with AES-256-GCM under a random data key, which is in turn encrypted for each
+// configured key; the document is authenticated with a MAC over all values. Values under keys ending in
+// SOPSUnencryptedSuffix are left in plaintext.
+type SOPS struct {
+	next Dumper
+	keys []SOPSKey
+	now  func() time.Time
+}
+
+// NewSOPS creates a SOPS dumper that passes the encrypted document to next.
+// At least one key is required, or the data key could never be recovered.
+func NewSOPS(next Dumper, keys ...SOPSKey) (*SOPS, error) {
+	if next == nil {
+		return nil, errors.New("dumper cannot be nil")
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("at least one SOPS key is required")
+	}
+	for i, key := range keys {
+		if key == nil {
+			return nil, fmt.Errorf("SOPS key %d cannot be nil", i)
+		}
+	}
+	return &SOPS{next: next, keys: keys, now: time.Now}, nil
+}
+
+// Dump encrypts the values and passes the encrypted document, including its "sops" metadata, to the next dumper.
+// The values are passed through Normalize first.
+func (s *SOPS) Dump(ctx context.Context, values *map[string]any) error {
+	var plain map[string]any
+	if values != nil {
+		plain = Normalize(*values)
+	}
+
+	dataKey := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return fmt.Errorf("failed to generate data key: %w", err)
+	}
+
+	enc := &sopsEncrypter{key: dataKey, mac: sha512.New()}
+	encrypted, err := enc.encryptMap(plain, nil, false)
+	if err != nil {
+		return err
+	}
+
+	metadata, err := s.metadata(ctx, enc)
+	if err != nil {
+		return err
+	}
+	encrypted["sops"] = metadata
+
+	return s.next.Dump(ctx, &encrypted)
+}
+
+// metadata returns the "sops" metadata section, with the data key encrypted for every key and the encrypted MAC.
+func (s *SOPS) metadata(ctx context.Context, enc *sopsEncrypter) (map[string]any, error) {
+	lastModified := s.now().UTC().Format(time.RFC3339)
+	mac, err := enc.encrypt(fmt.Sprintf("%X", enc.mac.Sum(nil)), lastModified)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := map[string]any{
+		"lastmodified":       lastModified,
+		"mac":                mac,
+		"unencrypted_suffix": SOPSUnencryptedSuffix,
+		"version":            SOPSVersion,
+	}
+	for _, key := range s.keys {
+		entry, err := key.Encrypt(ctx, enc.key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt data key for %s: %w", key.Group(), err)
+		}
+		group, _ := metadata[key.Group()].([]any)
+		metadata[key.Group()] = append(group, entry)
+	}
+	return metadata, nil
+}
+
+// sopsEncrypter encrypts the values of a document with the data key, feeding them to the MAC in document order.
+type sopsEncrypter struct {
+	key []byte
+	mac hash.Hash
+}
+
+// encryptMap encrypts the values of m, whose keys are at path. Keys are visited in sorted order, the order
+// in which the JSON and YAML encoders write them, so SOPS computes the same MAC when it reads the document.
+func (e *sopsEncrypter) encryptMap(m map[string]any, path []string, unencrypted bool) (map[string]any, error) {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	out := make(map[string]any, len(m))
+	for _, k := range keys {
+		v, err := e.encryptValue(m[k], append(path, k), unencrypted || strings.HasSuffix(k, SOPSUnencryptedSuffix))
+		if err != nil {
+			return nil, err
+		}
+		out[k] = v
+	}
+	return out, nil
+}
+
+// encryptValue encrypts v, the value at path. Elements of lists share the path of the list, as in SOPS.
+func (e *sopsEncrypter) encryptValue(v any, path []string, unencrypted bool) (any, error) {
+	switch val := v.(type) {
+	case nil:
+		return nil, nil
+	case map[string]any:
+		return e.encryptMap(val, path, unencrypted)
+	case []any:
+		out := make([]any, len(val))
+		for i, item := range val {
+			encrypted, err := e.encryptValue(item, path, unencrypted)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = encrypted
+		}
+		return out, nil
+	}
+
+	plaintext, valueType, err := sopsPlaintext(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt %s: %w", strings.Join(path, "."), err)
+	}
+	e.mac.Write([]byte(plaintext))
+	if unencrypted {
+		return v, nil
+	}
+
+	encrypted, err := e.encryptTyped(plaintext, valueType, strings.Join(path, ":")+":")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt %s: %w", strings.Join(path, "."), err)
+	}
+	return encrypted, nil
+}
+
+// encrypt encrypts a string with the additional authenticated data.
+func (e *sopsEncrypter) encrypt(plaintext, additionalData string) (string, error) {
+	return e.encryptTyped(plaintext, "str", additionalData)
+}
+
+// encryptTyped encrypts plaintext with AES-256-GCM and a 32-byte nonce, returning it in the SOPS format:
+// ENC[AES256_GCM,data:<ciphertext>,iv:<nonce>,tag:<tag>,type:<type>].
+func (e *sopsEncrypter) encryptTyped(plaintext, valueType, additionalData string) (string, error) {
+	block, err := aes.NewCipher(e.key)
+	if err != nil {
+		return "", err
+	}
+	gcm, err := cipher.NewGCMWithNonceSize(block, 32)
+	if err != nil {
+		return "", err
+	}
+	iv := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, iv); err != nil {
+		return "", err
+	}
+
+	sealed := gcm.Seal(nil, iv, []byte(plaintext), []byte(additionalData))
+	data, tag := sealed[:len(sealed)-gcm.Overhead()], sealed[len(sealed)-gcm.Overhead():]
+	return fmt.Sprintf("ENC[AES256_GCM,data:%s,iv:%s,tag:%s,type:%s]",
+		base64.StdEncoding.EncodeToString(data),
+		base64.StdEncoding.EncodeToString(iv),
+		base64.StdEncoding.EncodeToString(tag),
+		valueType), nil
+}
+
+// sopsPlaintext returns the plaintext SOPS encrypts for a scalar value, and the name of its type.
+func sopsPlaintext(v any) (string, string, error) {
+	switch val := v.(type) {
+	case string:
+		return val, "str", nil
+	case bool:
+		// SOPS writes booleans the way Python does.
+		if val {
+			return "True", "bool", nil
+		}
+		return "False", "bool", nil
+	case int:
+		return strconv.Itoa(val), "int", nil
+	case int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprint(val), "int", nil
+	case float32:
+		return strconv.FormatFloat(float64(val), 'f', -1, 32), "float", nil
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64), "float", nil
+	default:
+		return "", "", fmt.Errorf("unsupported type %T", v)
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dumper
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha512"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.companyinfo.dev/conflex/codec"
+)
+
+// captureDumper records the values it is asked to dump and returns err.
+type captureDumper struct {
+	values map[string]any
+	err    error
+}
+
+func (d *captureDumper) Dump(_ context.Context, values *map[string]any) error {
+	d.values = *values
+	return d.err
+}
+
+// plainKey "encrypts" the data key by keeping it, so tests can decrypt the document.
+type plainKey struct {
+	dataKey []byte
+	err     error
+}
+
+func (k *plainKey) Group() string { return "age" }
+
+func (k *plainKey) Encrypt(_ context.Context, dataKey []byte) (map[string]any, error) {
+	if k.err != nil {
+		return nil, k.err
+	}
+	k.dataKey = dataKey
+	return map[string]any{"recipient": "age1test", "enc": "wrapped"}, nil
+}
+
+var sopsValuePattern = regexp.MustCompile(`^ENC\[AES256_GCM,data:(.*),iv:(.*),tag:(.*),type:(.*)\]$`)
+
+// decryptSOPS decrypts a value encrypted by the SOPS dumper, returning its plaintext and type.
+func decryptSOPS(key []byte, value, additionalData string) (string, string, error) {
+	match := sopsValuePattern.FindStringSubmatch(value)
+	if match == nil {
+		return "", "", fmt.Errorf("not an encrypted value: %q", value)
+	}
+	data, _ := base64.StdEncoding.DecodeString(match[1])
+	iv, _ := base64.StdEncoding.DecodeString(match[2])
+	tag, _ := base64.StdEncoding.DecodeString(match[3])
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return "", "", err
+	}
+	gcm, err := cipher.NewGCMWithNonceSize(block, len(iv))
+	if err != nil {
+		return "", "", err
+	}
+	plaintext, err := gcm.Open(nil, iv, append(data, tag...), []byte(additionalData))
+	return string(plaintext), match[4], err
+}
+
+type SOPSDumperTestSuite struct {
+	suite.Suite
+}
+
+func TestSOPSDumperTestSuite(t *testing.T) {
+	suite.Run(t, new(SOPSDumperTestSuite))
+}
+
+func (s *SOPSDumperTestSuite) TestDump() {
+	next := &captureDumper{}
+	key := &plainKey{}
+	d, err := NewSOPS(next, key)
+	s.Require().NoError(err)
+	d.now = func() time.Time { return time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC) }
+
+	values := map[string]any{
+		"database":            map[string]any{"password": "s3cret", "port": 5432, "tls": true},
+		"hosts":               []any{"a", "b"},
+		"ratio":               0.5,
+		"timeout":             time.Minute,
+		"comment_unencrypted": "visible",
+	}
+	s.Require().NoError(d.Dump(context.Background(), &values))
+
+	doc := next.values
+	database := doc["database"].(map[string]any)
+	tests := []struct {
+		value, aad, plaintext, typ string
+	}{
+		{database["password"].(string), "database:password:", "s3cret", "str"},
+		{database["port"].(string), "database:port:", "5432", "int"},
+		{database["tls"].(string), "database:tls:", "True", "bool"},
+		{doc["hosts"].([]any)[1].(string), "hosts:", "b", "str"},
+		{doc["ratio"].(string), "ratio:", "0.5", "float"},
+		{doc["timeout"].(string), "timeout:", "1m0s", "str"},
+	}
+	for _, tt := range tests {
+		plaintext, typ, err := decryptSOPS(key.dataKey, tt.value, tt.aad)
+		s.Require().NoError(err, tt.aad)
+		s.Equal(tt.plaintext, plaintext)
+		s.Equal(tt.typ, typ)
+	}
+	s.Equal("visible", doc["comment_unencrypted"])
+
+	metadata := doc["sops"].(map[string]any)
+	s.Equal("2025-01-02T03:04:05Z", metadata["lastmodified"])
+	s.Equal(SOPSVersion, metadata["version"])
+	s.Equal([]any{map[string]any{"recipient": "age1test", "enc": "wrapped"}}, metadata["age"])
+
+	// The MAC covers every value in document order and is encrypted with the last modification time.
+	mac, _, err := decryptSOPS(key.dataKey, metadata["mac"].(string), "2025-01-02T03:04:05Z")
+	s.Require().NoError(err)
+	hash := sha512.New()
+	for _, v := range []string{"visible", "s3cret", "5432", "True", "a", "b", "0.5", "1m0s"} {
+		hash.Write([]byte(v))
+	}
+	s.Equal(fmt.Sprintf("%X", hash.Sum(nil)), mac)
+
+	s.Equal("s3cret", values["database"].(map[string]any)["password"], "the values are not modified")
+}
+
+func (s *SOPSDumperTestSuite) TestKeyOrderMatchesEncoders() {
+	// The MAC is computed in sorted key order, which must be the order in which the encoders write the keys.
+	values := map[string]any{"b": 1, "a_unencrypted": 2, "B": 3, "a": 4}
+	for _, encoder := range []codec.Encoder{codec.JSONCodec{}, codec.YAMLCodec{}} {
+		data, err := encoder.Encode(values)
+		s.Require().NoError(err)
+		doc := string(data)
+		s.Less(strings.Index(doc, `B`), strings.Index(doc, `a`), doc)
+		s.Less(strings.Index(doc, `a`), strings.Index(doc, `a_unencrypted`), doc)
+		s.Less(strings.Index(doc, `a_unencrypted`), strings.Index(doc, `b`), doc)
+	}
+}
+
+func (s *SOPSDumperTestSuite) TestKeyError() {
+	keyErr := errors.New("kms unavailable")
+	next := &captureDumper{}
+	d, err := NewSOPS(next, &plainKey{err: keyErr})
+	s.Require().NoError(err)
+
+	err = d.Dump(context.Background(), &map[string]any{"a": "b"})
+	s.ErrorIs(err, keyErr)
+	s.Nil(next.values, "nothing is dumped")
+}
+
+func (s *SOPSDumperTestSuite) TestInvalid() {
+	_, err := NewSOPS(nil, &plainKey{})
+	s.Error(err)
+	_, err = NewSOPS(&captureDumper{})
+	s.Error(err)
+	_, err = NewSOPS(&captureDumper{}, nil)
+	s.Error(err)
+}
@@ -0,0 +1,58 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dumper
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"go.companyinfo.dev/conflex/internal/keypath"
+)
+
+// SubtreeDumper is a dumper that passes only the values below a key to another dumper. See Subtree.
+type SubtreeDumper struct {
+	path []string
+	next Dumper
+}
+
+// Subtree returns a dumper that passes only the values below the dot-separated key to next, so a section of the
+// configuration, such as the TLS settings a sidecar needs, can be written without exposing the rest:
+//
+//	tls, err := dumper.Subtree("server.tls", dumper.NewFile("tls.json", encoder))
+//
+// The values keep their full paths, as with conflex.WithScopedDumper, so the dump above holds
+// {"server": {"tls": {...}}} and can be loaded back without moving keys. A dot that is part of a key is escaped
+// with a backslash, as in "labels.app\.kubernetes\.io/name". Keys are matched exactly first and case-insensitively
+// otherwise. If nothing is configured below the key, next receives an empty map.
+func Subtree(key string, next Dumper) (*SubtreeDumper, error) {
+	if next == nil {
+		return nil, errors.New("dumper cannot be nil")
+	}
+	key = strings.Trim(key, ".")
+	if key == "" {
+		return nil, errors.New("subtree key cannot be empty")
+	}
+	return &SubtreeDumper{path: keypath.Split(key), next: next}, nil
+}
+
+// Dump implements Dumper.
+func (d *SubtreeDumper) Dump(ctx context.Context, values *map[string]any) error {
+	subtree := map[string]any{}
+	if values != nil {
+		subtree = keypath.Extract(*values, d.path, true)
+	}
+	return d.next.Dump(ctx, &subtree)
+}
@@ -0,0 +1,95 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dumper
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type SubtreeDumperTestSuite struct {
+	suite.Suite
+}
+
+func TestSubtreeDumperTestSuite(t *testing.T) {
+	suite.Run(t, new(SubtreeDumperTestSuite))
+}
+
+func (s *SubtreeDumperTestSuite) values() *map[string]any {
+	return &map[string]any{
+		"server": map[string]any{
+			"port": 8443,
+			"tls":  map[string]any{"cert_file": "/etc/tls/cert.pem", "key_file": "/etc/tls/key.pem"},
+		},
+		"database": map[string]any{"password": "secret"},
+	}
+}
+
+// subtree returns the Subtree dumper for key, failing the test if it cannot be created.
+func (s *SubtreeDumperTestSuite) subtree(key string, next Dumper) *SubtreeDumper {
+	d, err := Subtree(key, next)
+	s.Require().NoError(err)
+	return d
+}
+
+func (s *SubtreeDumperTestSuite) TestSubtree() {
+	next := &captureDumper{}
+	s.Require().NoError(s.subtree("server.tls", next).Dump(context.Background(), s.values()))
+	s.Equal(map[string]any{
+		"server": map[string]any{
+			"tls": map[string]any{"cert_file": "/etc/tls/cert.pem", "key_file": "/etc/tls/key.pem"},
+		},
+	}, next.values)
+}
+
+func (s *SubtreeDumperTestSuite) TestLeafAndCase() {
+	next := &captureDumper{}
+	s.Require().NoError(s.subtree("Server.Port.", next).Dump(context.Background(), s.values()))
+	s.Equal(map[string]any{"server": map[string]any{"port": 8443}}, next.values)
+}
+
+func (s *SubtreeDumperTestSuite) TestEscapedDots() {
+	values := &map[string]any{"labels": map[string]any{"app.kubernetes.io/name": "web", "team": "core"}}
+	next := &captureDumper{}
+	s.Require().NoError(s.subtree(`labels.app\.kubernetes\.io/name`, next).Dump(context.Background(), values))
+	s.Equal(map[string]any{"labels": map[string]any{"app.kubernetes.io/name": "web"}}, next.values)
+}
+
+func (s *SubtreeDumperTestSuite) TestMissing() {
+	for _, key := range []string{"tracing", "server.port.value", "server.tls.ca_file"} {
+		next := &captureDumper{}
+		s.Require().NoError(s.subtree(key, next).Dump(context.Background(), s.values()))
+		s.Empty(next.values, key)
+	}
+
+	next := &captureDumper{}
+	s.Require().NoError(s.subtree("server", next).Dump(context.Background(), nil))
+	s.Empty(next.values)
+}
+
+func (s *SubtreeDumperTestSuite) TestErrors() {
+	_, err := Subtree("server", nil)
+	s.ErrorContains(err, "dumper cannot be nil")
+	for _, key := range []string{"", "."} {
+		_, err = Subtree(key, &captureDumper{})
+		s.ErrorContains(err, "subtree key cannot be empty", key)
+	}
+
+	next := &captureDumper{err: errors.New("write failed")}
+	s.ErrorContains(s.subtree("server", next).Dump(context.Background(), s.values()), "write failed")
+}
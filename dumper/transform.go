@@ -0,0 +1,96 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dumper
+
+import (
+	"context"
+	"errors"
+)
+
+// TransformFunc re-shapes configuration values before they are dumped. It may modify values in place and return
+// them, or return a new map.
+type TransformFunc func(values map[string]any) map[string]any
+
+// TransformDumper is a dumper that passes the values through a TransformFunc before another dumper. See Transform.
+type TransformDumper struct {
+	transform TransformFunc
+	next      Dumper
+}
+
+// Transform returns a dumper that passes the values through transform before dumping them with next, so keys can
+// be renamed, moved, or dropped, for example to export the configuration in a legacy layout an old agent expects:
+//
+//	dumper.Transform(func(values map[string]any) map[string]any {
+//		values["listen_port"] = values["server"].(map[string]any)["port"]
+//		delete(values, "server")
+//		return values
+//	}, dumper.NewFile("agent.json", encoder))
+//
+// transform receives a deep copy of the values, so changing it in place does not affect the configuration or
+// other dumpers. A nil result is dumped as an empty map.
+func Transform(transform TransformFunc, next Dumper) *TransformDumper {
+	return &TransformDumper{transform: transform, next: next}
+}
+
+// Dump implements Dumper.
+func (d *TransformDumper) Dump(ctx context.Context, values *map[string]any) error {
+	if d.next == nil {
+		return errors.New("dumper cannot be nil")
+	}
+	if d.transform == nil {
+		return errors.New("transform cannot be nil")
+	}
+
+	var input map[string]any
+	if values != nil {
+		input = copyMap(*values)
+	}
+	if input == nil {
+		input = map[string]any{}
+	}
+
+	transformed := d.transform(input)
+	if transformed == nil {
+		transformed = map[string]any{}
+	}
+	return d.next.Dump(ctx, &transformed)
+}
+
+// copyMap returns a deep copy of m, copying nested maps and lists.
+func copyMap(m map[string]any) map[string]any {
+	if m == nil {
+		return nil
+	}
+	out := make(map[string]any, len(m))
+	for k, v := range m {
+		out[k] = copyValue(v)
+	}
+	return out
+}
+
+func copyValue(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		return copyMap(val)
+	case []any:
+		out := make([]any, len(val))
+		for i, item := range val {
+			out[i] = copyValue(item)
+		}
+		return out
+	default:
+		return v
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dumper
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TransformDumperTestSuite struct {
+	suite.Suite
+}
+
+func TestTransformDumperTestSuite(t *testing.T) {
+	suite.Run(t, new(TransformDumperTestSuite))
+}
+
+func (s *TransformDumperTestSuite) TestTransform() {
+	values := map[string]any{
+		"server": map[string]any{"host": "localhost", "port": 8080},
+		"hosts":  []any{map[string]any{"name": "a"}},
+	}
+	next := &captureDumper{}
+	d := Transform(func(values map[string]any) map[string]any {
+		server := values["server"].(map[string]any)
+		values["listen_port"] = server["port"]
+		delete(server, "port")
+		values["hosts"].([]any)[0].(map[string]any)["name"] = "b"
+		return values
+	}, next)
+
+	s.Require().NoError(d.Dump(context.Background(), &values))
+	s.Equal(map[string]any{
+		"server":      map[string]any{"host": "localhost"},
+		"listen_port": 8080,
+		"hosts":       []any{map[string]any{"name": "b"}},
+	}, next.values)
+
+	// The transform works on a copy, so the dumped values are unchanged.
+	s.Equal(map[string]any{
+		"server": map[string]any{"host": "localhost", "port": 8080},
+		"hosts":  []any{map[string]any{"name": "a"}},
+	}, values)
+}
+
+func (s *TransformDumperTestSuite) TestNilValues() {
+	next := &captureDumper{}
+	d := Transform(func(values map[string]any) map[string]any {
+		s.NotNil(values)
+		return nil
+	}, next)
+
+	s.Require().NoError(d.Dump(context.Background(), nil))
+	s.NotNil(next.values)
+	s.Empty(next.values)
+}
+
+func (s *TransformDumperTestSuite) TestErrors() {
+	identity := func(values map[string]any) map[string]any { return values }
+	values := map[string]any{"key": "value"}
+
+	s.ErrorContains(Transform(identity, nil).Dump(context.Background(), &values), "dumper cannot be nil")
+	s.ErrorContains(Transform(nil, &captureDumper{}).Dump(context.Background(), &values), "transform cannot be nil")
+
+	next := &captureDumper{err: errors.New("write failed")}
+	s.ErrorContains(Transform(identity, next).Dump(context.Background(), &values), "write failed")
+}
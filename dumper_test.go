@@ -0,0 +1,204 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type DumperTestSuite struct {
+	suite.Suite
+}
+
+func TestDumperTestSuite(t *testing.T) {
+	suite.Run(t, new(DumperTestSuite))
+}
+
+func (s *DumperTestSuite) TestWithScopedDumper() {
+	src := &mockSource{conf: map[string]any{
+		"metrics":     map[string]any{"enabled": true, "port": 9090},
+		"credentials": map[string]any{"password": "secret"},
+		"server":      map[string]any{"host": "localhost", "port": 8080},
+	}}
+	all := &mockDumper{}
+	metrics := &mockDumper{}
+	serverPort := &mockDumper{}
+	missing := &mockDumper{}
+	c, err := New(
+		WithSource(src),
+		WithDumper(all),
+		WithScopedDumper("Metrics", metrics),
+		WithScopedDumper("server.port.", serverPort),
+		WithScopedDumper("tracing", missing),
+	)
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+	s.Require().NoError(c.Dump(context.Background()))
+
+	s.Len(*all.values, 3)
+	s.Equal(map[string]any{"metrics": map[string]any{"enabled": true, "port": 9090}}, *metrics.values)
+	s.Equal(map[string]any{"server": map[string]any{"port": 8080}}, *serverPort.values)
+	s.True(missing.called)
+	s.Empty(*missing.values)
+}
+
+func (s *DumperTestSuite) TestWithScopedDumper_Error() {
+	src := &mockSource{conf: map[string]any{"metrics": map[string]any{"enabled": true}}}
+	c, err := New(WithSource(src), WithScopedDumper("metrics", &mockDumper{err: errors.New("dump failed")}))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+	s.ErrorContains(c.Dump(context.Background()), "dump failed")
+}
+
+func (s *DumperTestSuite) TestWithScopedDumper_Invalid() {
+	_, err := New(WithScopedDumper("metrics", nil))
+	s.ErrorContains(err, "dumper cannot be nil")
+
+	_, err = New(WithScopedDumper(".", &mockDumper{}))
+	s.ErrorContains(err, "dumper prefix cannot be empty")
+}
+
+type overridesConfig struct {
+	Server struct {
+		Host    string        `conflex:"host,default=localhost"`
+		Port    int           `conflex:"port,default=8080"`
+		Timeout time.Duration `conflex:"timeout,default=30s"`
+	} `conflex:"server"`
+	Log struct {
+		Level string `conflex:"level,default=info"`
+	} `conflex:"log"`
+	Name string `conflex:"name"`
+}
+
+func (s *DumperTestSuite) TestWithOverridesDumper() {
+	src := &mockSource{conf: map[string]any{
+		"server": map[string]any{"host": "localhost", "port": float64(9090)},
+		"log":    map[string]any{"level": "info"},
+		"name":   "billing",
+	}}
+	var cfg overridesConfig
+	all := &mockDumper{}
+	overrides := &mockDumper{}
+	c, err := New(WithSource(src), WithBinding(&cfg), WithDumper(all), WithOverridesDumper(overrides))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+	s.Require().NoError(c.Dump(context.Background()))
+
+	s.Equal(map[string]any{
+		"server": map[string]any{"port": float64(9090)},
+		"name":   "billing",
+	}, *overrides.values)
+	s.Equal("30s", c.GetString("server.timeout"))
+	s.Equal("localhost", c.GetString("server.host"))
+}
+
+func (s *DumperTestSuite) TestWithOverridesDumper_NumericDefaults() {
+	src := &mockSource{conf: map[string]any{"server": map[string]any{"port": float64(8080)}}}
+	var cfg overridesConfig
+	overrides := &mockDumper{}
+	c, err := New(WithSource(src), WithBinding(&cfg), WithOverridesDumper(overrides))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+	s.Require().NoError(c.Dump(context.Background()))
+
+	s.Empty(*overrides.values)
+}
+
+func (s *DumperTestSuite) TestWithOverridesDumper_Invalid() {
+	_, err := New(WithOverridesDumper(nil))
+	s.ErrorContains(err, "dumper cannot be nil")
+}
+
+// barrierDumper blocks until every dumper sharing its WaitGroup has started, so it only returns if the dumpers
+// run concurrently.
+type barrierDumper struct {
+	started *sync.WaitGroup
+	err     error
+}
+
+func (d *barrierDumper) Dump(ctx context.Context, _ *map[string]any) error {
+	d.started.Done()
+	done := make(chan struct{})
+	go func() {
+		d.started.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return d.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *DumperTestSuite) TestDump_Concurrent() {
+	var started sync.WaitGroup
+	started.Add(3)
+	c, err := New(
+		WithSource(&mockSource{conf: map[string]any{"key": "value"}}),
+		WithDumper(&barrierDumper{started: &started}),
+		WithNamedDumper("vault", &barrierDumper{started: &started, err: errors.New("permission denied")}),
+		WithDumper(&barrierDumper{started: &started, err: errors.New("disk full")}),
+	)
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err = c.Dump(ctx)
+	s.Require().Error(err)
+	s.NotContains(err.Error(), "deadline exceeded")
+	s.Contains(err.Error(), "config error in vault during dump: permission denied")
+	s.Contains(err.Error(), "config error in dumper[2] during dump: disk full")
+	s.NotContains(err.Error(), "dumper[0]")
+
+	var configErr *ConfigError
+	s.Require().ErrorAs(err, &configErr)
+	s.Equal("dump", configErr.Operation)
+}
+
+func (s *DumperTestSuite) TestDump_SeparateValues() {
+	src := &mockSource{conf: map[string]any{"key": "value", "other": "value"}}
+	first := &mockDumper{}
+	second := &mockDumper{}
+	c, err := New(WithSource(src), WithDumper(first), WithDumper(second))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+	s.Require().NoError(c.Dump(context.Background()))
+
+	delete(*first.values, "key")
+	s.Len(*second.values, 2)
+	s.Equal("value", c.GetString("key"))
+}
+
+func (s *DumperTestSuite) TestWithNamedDumper_Invalid() {
+	_, err := New(WithNamedDumper("vault", nil))
+	s.ErrorContains(err, "dumper cannot be nil")
+
+	_, err = New(WithNamedDumper(" ", &mockDumper{}))
+	s.ErrorContains(err, "dumper name cannot be empty")
+
+	_, err = New(WithNamedDumper("dumper[0]", &mockDumper{}))
+	s.ErrorContains(err, "reserved for unnamed dumpers")
+
+	_, err = New(WithNamedDumper("vault", &mockDumper{}), WithNamedDumper("vault", &mockDumper{}))
+	s.ErrorContains(err, `duplicate dumper name "vault"`)
+}
@@ -0,0 +1,145 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/spf13/cast"
+)
+
+// EnvironOption configures how Environ renders the configuration.
+type EnvironOption func(o *environOptions)
+
+// environOptions holds the settings applied by EnvironOption values.
+type environOptions struct {
+	includeSecrets bool
+	separator      string
+}
+
+// EnvironIncludeSecrets makes Environ export the values of secret keys, for child processes that need them.
+// Environment variables are visible to the child and everything it starts, so only pass secrets to trusted
+// processes.
+func EnvironIncludeSecrets() EnvironOption {
+	return func(o *environOptions) {
+		o.includeSecrets = true
+	}
+}
+
+// EnvironSeparator sets the string nested keys are joined with, "_" by default. A separator of "__" keeps keys
+// that contain underscores apart from nested keys: a_b.c becomes A_B__C and a.b_c becomes A__B_C. A child process
+// reads them back with WithEnvKeyReplacer(strings.NewReplacer("__", ".").Replace).
+func EnvironSeparator(separator string) EnvironOption {
+	return func(o *environOptions) {
+		o.separator = separator
+	}
+}
+
+// Environ returns the effective configuration as a list of environment variables in "PREFIX_KEY=value" form,
+// suitable for exec.Cmd.Env when launching child processes with the resolved configuration.
+// Nested keys are joined with underscores and upper-cased, following the same convention as WithOSEnvVarSource,
+// so a child process using WithOSEnvVarSource(prefix) sees the same configuration.
+// Slices of scalar values are joined with commas; other composite values are encoded as JSON.
+// The returned list is sorted by key.
+//
+// The values of keys classified as SensitivitySecret are replaced with RedactedValue, unless EnvironIncludeSecrets
+// is given. Since underscores both separate and occur in keys, different keys can map to the same variable, such
+// as a_b.c and a.b_c, which both become A_B_C. Only the first of them in sorted key order is exported, and a
+// warning is logged for the others; EnvironSeparator avoids such collisions.
+func (c *Conflex) Environ(prefix string, options ...EnvironOption) []string {
+	if c == nil {
+		return []string{}
+	}
+	o := environOptions{separator: "_"}
+	for _, option := range options {
+		option(&o)
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	env := []string{}
+	if c.values == nil {
+		return env
+	}
+
+	keys := make(map[string]string)
+	walkLeaves(*c.values, func(path []string, value any) {
+		key := joinKey(path)
+		name := prefix + envKey(path, o.separator)
+		if other, ok := keys[name]; ok {
+			c.log().Warn("configuration key not exported: its environment variable is taken by another key",
+				"key", key, "variable", name, "other", other)
+			return
+		}
+		keys[name] = key
+
+		if !o.includeSecrets && c.Sensitivity(key) == SensitivitySecret {
+			env = append(env, name+"="+RedactedValue)
+			return
+		}
+		env = append(env, name+"="+envValue(value))
+	})
+
+	return env
+}
+
+// envKey converts a key path into an environment variable name, joining the segments with separator.
+// Characters that are not valid in environment variable names are replaced with underscores.
+func envKey(path []string, separator string) string {
+	key := strings.ToUpper(strings.Join(path, separator))
+	return strings.Map(func(r rune) rune {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' {
+			return r
+		}
+		return '_'
+	}, key)
+}
+
+// envValue converts a configuration value into its environment variable representation.
+func envValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case []any:
+		parts := make([]string, 0, len(v))
+		for _, item := range v {
+			s, err := cast.ToStringE(item)
+			if err != nil {
+				return jsonValue(value)
+			}
+			parts = append(parts, s)
+		}
+		return strings.Join(parts, ",")
+	case map[string]any:
+		return jsonValue(value)
+	}
+
+	s, err := cast.ToStringE(value)
+	if err != nil {
+		return jsonValue(value)
+	}
+	return s
+}
+
+// jsonValue encodes value as JSON, falling back to an empty string if it cannot be encoded.
+func jsonValue(value any) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
@@ -0,0 +1,123 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.companyinfo.dev/conflex/codec"
+)
+
+type EnvironTestSuite struct {
+	suite.Suite
+}
+
+func TestEnvironTestSuite(t *testing.T) {
+	suite.Run(t, new(EnvironTestSuite))
+}
+
+func (s *EnvironTestSuite) TestEnviron() {
+	src := &mockSource{conf: map[string]any{
+		"server": map[string]any{
+			"host":  "localhost",
+			"port":  8080,
+			"roles": []any{"admin", "user"},
+		},
+		"debug":     true,
+		"log-level": "info",
+		"empty":     nil,
+		"objects":   []any{map[string]any{"a": 1}},
+	}}
+	c, err := New(WithSource(src))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	s.Equal([]string{
+		"APP_DEBUG=true",
+		"APP_EMPTY=",
+		"APP_LOG_LEVEL=info",
+		`APP_OBJECTS=[{"a":1}]`,
+		"APP_SERVER_HOST=localhost",
+		"APP_SERVER_PORT=8080",
+		"APP_SERVER_ROLES=admin,user",
+	}, c.Environ("APP_"))
+}
+
+func (s *EnvironTestSuite) TestEnviron_RoundTrip() {
+	src := &mockSource{conf: map[string]any{"database": map[string]any{"host": "db", "port": "5432"}}}
+	c, err := New(WithSource(src))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	child, err := New(WithContentSource([]byte(strings.Join(c.Environ(""), "\n")), codec.TypeEnvVar))
+	s.Require().NoError(err)
+	s.Require().NoError(child.Load(context.Background()))
+	s.Equal("db", child.GetString("database.host"))
+	s.Equal(5432, child.GetInt("database.port"))
+}
+
+func (s *EnvironTestSuite) TestEnviron_Secrets() {
+	src := &mockSource{conf: map[string]any{"database": map[string]any{"host": "db", "password": "hunter2"}}}
+	c, err := New(WithSource(src), WithRedactedKeys("database.password"))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	s.Equal([]string{"APP_DATABASE_HOST=db", "APP_DATABASE_PASSWORD=" + RedactedValue}, c.Environ("APP_"))
+	s.Equal([]string{"APP_DATABASE_HOST=db", "APP_DATABASE_PASSWORD=hunter2"}, c.Environ("APP_", EnvironIncludeSecrets()))
+}
+
+func (s *EnvironTestSuite) TestEnviron_Collisions() {
+	logs := &bytes.Buffer{}
+	src := &mockSource{conf: map[string]any{
+		"a":   map[string]any{"b_c": "nested"},
+		"a_b": map[string]any{"c": "underscore"},
+	}}
+	c, err := New(WithSource(src), WithLogger(slog.New(slog.NewTextHandler(logs, nil))))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	s.Equal([]string{"A_B_C=nested"}, c.Environ(""))
+	s.Contains(logs.String(), "key=a_b.c variable=A_B_C other=a.b_c")
+
+	env := c.Environ("ENVIRON_", EnvironSeparator("__"))
+	s.Equal([]string{"ENVIRON_A__B_C=nested", "ENVIRON_A_B__C=underscore"}, env)
+
+	for _, variable := range env {
+		name, value, _ := strings.Cut(variable, "=")
+		s.T().Setenv(name, value)
+	}
+	child, err := New(
+		WithOSEnvVarSource("ENVIRON_"),
+		WithEnvKeyReplacer(strings.NewReplacer("__", ".").Replace),
+	)
+	s.Require().NoError(err)
+	s.Require().NoError(child.Load(context.Background()))
+	s.Equal("nested", child.GetString("a.b_c"))
+	s.Equal("underscore", child.GetString("a_b.c"))
+}
+
+func (s *EnvironTestSuite) TestEnviron_Empty() {
+	c, err := New()
+	s.Require().NoError(err)
+	s.Empty(c.Environ("APP_"))
+
+	var nilConflex *Conflex
+	s.Empty(nilConflex.Environ("APP_"))
+}
@@ -14,7 +14,12 @@
 
 package conflex
 
-import "fmt"
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+)
 
 // ConfigError represents a configuration error with detailed context.
 // It provides information about where the error occurred (source, field),
@@ -63,3 +68,74 @@ func NewConfigFieldError(source, field, operation string, err error) *ConfigErro
 		Err:       err,
 	}
 }
+
+// OptionError describes a single option that failed while constructing a Conflex instance.
+type OptionError struct {
+	Index int    // Position of the option in the list passed to New
+	Name  string // Name of the function that built the option (e.g., "WithSource"), if known
+	Err   error  // The error returned by the option
+}
+
+// Error returns a formatted error message identifying the failed option.
+func (e *OptionError) Error() string {
+	if e.Name != "" {
+		return fmt.Sprintf("option[%d] (%s): %v", e.Index, e.Name, e.Err)
+	}
+	return fmt.Sprintf("option[%d]: %v", e.Index, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *OptionError) Unwrap() error {
+	return e.Err
+}
+
+// PartialInitError is returned by New when one or more options failed.
+// The returned instance is still usable, but is configured only with the options that succeeded.
+// Use NewStrict to refuse half-configured instances instead.
+type PartialInitError struct {
+	Options []*OptionError // The failed options, in the order they were applied
+}
+
+// Error returns a message listing every failed option.
+func (e *PartialInitError) Error() string {
+	msgs := make([]string, len(e.Options))
+	for i, opt := range e.Options {
+		msgs[i] = opt.Error()
+	}
+	return fmt.Sprintf("failed to apply %d option(s): %s", len(e.Options), strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the errors of the failed options, allowing errors.Is() and errors.As()
+// to match any of them.
+func (e *PartialInitError) Unwrap() []error {
+	errs := make([]error, len(e.Options))
+	for i, opt := range e.Options {
+		errs[i] = opt
+	}
+	return errs
+}
+
+// optionName returns the name of the function that built the option, e.g. "WithSource"
+// for the closure returned by WithSource. It returns an empty string if the name is unknown.
+func optionName(option Option) string {
+	fn := runtime.FuncForPC(reflect.ValueOf(option).Pointer())
+	if fn == nil {
+		return ""
+	}
+	name := fn.Name()
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	if i := strings.Index(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+	// Options are usually closures, named after their constructor with a ".funcN" suffix.
+	for {
+		i := strings.LastIndex(name, ".func")
+		if i < 0 {
+			break
+		}
+		name = name[:i]
+	}
+	return name
+}
@@ -109,3 +109,28 @@ func (s *ErrorTestSuite) TestConfigErrorChaining() {
 	s.Contains(secondErr.Error(), "second-source")
 	s.Contains(secondErr.Error(), "second-op")
 }
+
+func (s *ErrorTestSuite) TestPartialInitError() {
+	sourceErr := errors.New("source cannot be nil")
+	dumperErr := errors.New("dumper cannot be nil")
+	err := &PartialInitError{Options: []*OptionError{
+		{Index: 0, Name: "WithSource", Err: sourceErr},
+		{Index: 2, Err: dumperErr},
+	}}
+
+	s.Equal("failed to apply 2 option(s): option[0] (WithSource): source cannot be nil; option[2]: dumper cannot be nil", err.Error())
+	s.True(errors.Is(err, sourceErr))
+	s.True(errors.Is(err, dumperErr))
+
+	var optErr *OptionError
+	s.True(errors.As(err, &optErr))
+	s.Equal(0, optErr.Index)
+}
+
+func (s *ErrorTestSuite) TestOptionName() {
+	s.Equal("WithSource", optionName(WithSource(nil)))
+	s.Equal("WithPollInterval", optionName(WithPollInterval(0)))
+
+	custom := func(*Conflex) error { return nil }
+	s.NotEmpty(optionName(custom))
+}
@@ -0,0 +1,145 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"os"
+	"strings"
+)
+
+// WithEnvExpansion expands references to environment variables in string values after the sources are merged,
+// so configuration files can refer to the environment without a pre-processing step:
+//
+//	database:
+//	  url: postgres://${DB_USER}:${DB_PASSWORD}@${DB_HOST:-localhost}/app
+//
+// ${VAR} is replaced with the value of VAR, or with an empty string if it is not set. ${VAR:-default} is replaced
+// with default if VAR is unset or empty; the default may itself contain references. "$${" escapes a literal "${".
+// Placeholders that do not name a valid environment variable are left as they are.
+func WithEnvExpansion() Option {
+	return func(c *Conflex) error {
+		c.envExpansion = true
+		return nil
+	}
+}
+
+// expandEnv returns values with the environment variable references in its strings expanded.
+func expandEnv(values map[string]any) map[string]any {
+	var expand func(s string) string
+	expand = func(s string) string {
+		return expandPlaceholders(s, func(expr string) (string, bool) {
+			name, fallback, hasFallback := strings.Cut(expr, ":-")
+			if !isEnvName(name) {
+				return "", false
+			}
+			value := os.Getenv(name)
+			if value == "" && hasFallback {
+				value = expand(fallback)
+			}
+			return value, true
+		})
+	}
+	out, _ := mapStrings(values, expand).(map[string]any)
+	return out
+}
+
+// isEnvName reports whether name is a valid environment variable name.
+func isEnvName(name string) bool {
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		return false
+	}
+	for _, r := range name {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (r < '0' || r > '9') && r != '_' {
+			return false
+		}
+	}
+	return true
+}
+
+// expandPlaceholders replaces the ${...} placeholders in s with the result of resolve, which receives the text
+// between the braces and reports whether it handled the placeholder. Unhandled and unterminated placeholders are
+// kept as they are. Braces nest, so a placeholder may contain other placeholders. "$${" escapes a literal "${".
+func expandPlaceholders(s string, resolve func(expr string) (string, bool)) string {
+	if !strings.Contains(s, "${") {
+		return s
+	}
+
+	var sb strings.Builder
+	for {
+		i := strings.Index(s, "${")
+		if i < 0 {
+			sb.WriteString(s)
+			return sb.String()
+		}
+		if i > 0 && s[i-1] == '$' {
+			sb.WriteString(s[:i-1])
+			sb.WriteString("${")
+			s = s[i+2:]
+			continue
+		}
+		sb.WriteString(s[:i])
+
+		end := placeholderEnd(s[i:])
+		if end < 0 {
+			sb.WriteString(s[i:])
+			return sb.String()
+		}
+		placeholder := s[i : i+end+1]
+		if value, ok := resolve(placeholder[2 : len(placeholder)-1]); ok {
+			sb.WriteString(value)
+		} else {
+			sb.WriteString(placeholder)
+		}
+		s = s[i+end+1:]
+	}
+}
+
+// placeholderEnd returns the index of the brace closing the placeholder at the start of s, or -1.
+func placeholderEnd(s string) int {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// mapStrings returns a copy of v with fn applied to every string, descending into maps and slices.
+func mapStrings(v any, fn func(s string) string) any {
+	switch val := v.(type) {
+	case string:
+		return fn(val)
+	case map[string]any:
+		out := make(map[string]any, len(val))
+		for k, item := range val {
+			out[k] = mapStrings(item, fn)
+		}
+		return out
+	case []any:
+		out := make([]any, len(val))
+		for i, item := range val {
+			out[i] = mapStrings(item, fn)
+		}
+		return out
+	}
+	return v
+}
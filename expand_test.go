@@ -0,0 +1,104 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.companyinfo.dev/conflex/codec"
+)
+
+type ExpandTestSuite struct {
+	suite.Suite
+}
+
+func TestExpandTestSuite(t *testing.T) {
+	suite.Run(t, new(ExpandTestSuite))
+}
+
+func (s *ExpandTestSuite) TestEnvExpansion() {
+	s.T().Setenv("CONFLEX_TEST_USER", "app")
+	s.T().Setenv("CONFLEX_TEST_EMPTY", "")
+
+	yaml := `
+database:
+  url: postgres://${CONFLEX_TEST_USER}@${CONFLEX_TEST_HOST:-localhost}/app
+  user: ${CONFLEX_TEST_EMPTY:-${CONFLEX_TEST_USER}}
+  port: 5432
+hosts:
+  - ${CONFLEX_TEST_USER}.internal
+`
+	c, err := New(WithContentSource([]byte(yaml), codec.TypeYAML), WithEnvExpansion())
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	s.Equal("postgres://app@localhost/app", c.GetString("database.url"))
+	s.Equal("app", c.GetString("database.user"))
+	s.Equal(5432, c.GetInt("database.port"))
+	s.Equal([]string{"app.internal"}, c.GetStringSlice("hosts"))
+}
+
+func (s *ExpandTestSuite) TestDisabledByDefault() {
+	s.T().Setenv("CONFLEX_TEST_USER", "app")
+
+	c, err := New(WithSource(&mockSource{conf: map[string]any{"user": "${CONFLEX_TEST_USER}"}}))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+	s.Equal("${CONFLEX_TEST_USER}", c.GetString("user"))
+}
+
+func (s *ExpandTestSuite) TestDoesNotModifySources() {
+	s.T().Setenv("CONFLEX_TEST_USER", "app")
+
+	hosts := []any{"${CONFLEX_TEST_USER}"}
+	c, err := New(WithSource(&mockSource{conf: map[string]any{"hosts": hosts}}), WithEnvExpansion())
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+	s.Equal([]string{"app"}, c.GetStringSlice("hosts"))
+	s.Equal("${CONFLEX_TEST_USER}", hosts[0])
+}
+
+func (s *ExpandTestSuite) TestExpandPlaceholders() {
+	resolve := func(expr string) (string, bool) {
+		if expr == "known" {
+			return "value", true
+		}
+		return "", false
+	}
+	tests := []struct {
+		in, want string
+	}{
+		{"plain", "plain"},
+		{"${known}", "value"},
+		{"a-${known}-b-${known}", "a-value-b-value"},
+		{"${unknown}", "${unknown}"},
+		{"$${known}", "${known}"},
+		{"${known", "${known"},
+		{"$known", "$known"},
+	}
+	for _, tt := range tests {
+		s.Equal(tt.want, expandPlaceholders(tt.in, resolve), tt.in)
+	}
+}
+
+func (s *ExpandTestSuite) TestIsEnvName() {
+	s.True(isEnvName("HOME"))
+	s.True(isEnvName("_A1"))
+	s.False(isEnvName(""))
+	s.False(isEnvName("1A"))
+	s.False(isEnvName("ref:server.host"))
+}
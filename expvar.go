@@ -0,0 +1,114 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"errors"
+	"expvar"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// expvarMu serializes checking and publishing expvar names, which are global to the process.
+var expvarMu sync.Mutex
+
+// expvarRequest is a variable requested with WithExpvar, published once New has applied every option.
+type expvarRequest struct {
+	name   string
+	index  int    // Position of the option among the options passed to New
+	option string // Name of the option, as reported in OptionError
+}
+
+// WithExpvar publishes metadata about the configuration as an expvar variable with the given name, served as JSON
+// by the expvar handler at /debug/vars, for services that do not run Prometheus. The variable is an object with the
+// version of the configuration (see Snapshot.Version), its checksum with secrets redacted (see Checksum), and the
+// healthy, successes, failures, last_attempt, last_success and last_error fields of ReloadStatus:
+//
+//	"config": {"version": 3, "checksum": "9f86d0...", "healthy": true, "successes": 3, "failures": 0, ...}
+//
+// Values are computed whenever the variable is read. Expvar names are global to the process and cannot be
+// unpublished, so the variable is only published once New has applied every option successfully, and the option
+// fails if the name is already in use.
+func WithExpvar(name string) Option {
+	return func(c *Conflex) error {
+		if name == "" {
+			return errors.New("expvar name cannot be empty")
+		}
+		for _, request := range c.expvars {
+			if request.name == name {
+				return fmt.Errorf("expvar %q is already published", name)
+			}
+		}
+		c.expvars = append(c.expvars, expvarRequest{name: name})
+		return nil
+	}
+}
+
+// publishExpvars publishes the variables requested with WithExpvar. If any name is already in use, nothing is
+// published and an OptionError is returned for every such name.
+func (c *Conflex) publishExpvars() []*OptionError {
+	expvarMu.Lock()
+	defer expvarMu.Unlock()
+
+	var failed []*OptionError
+	for _, request := range c.expvars {
+		if expvar.Get(request.name) != nil {
+			failed = append(failed, &OptionError{
+				Index: request.index,
+				Name:  request.option,
+				Err:   fmt.Errorf("expvar %q is already published", request.name),
+			})
+		}
+	}
+	if len(failed) > 0 {
+		return failed
+	}
+	for _, request := range c.expvars {
+		expvar.Publish(request.name, expvar.Func(c.expvarValue))
+	}
+	return nil
+}
+
+// expvarValue returns the value of the variable published by WithExpvar.
+func (c *Conflex) expvarValue() any {
+	status := c.ReloadStatus()
+
+	c.mu.RLock()
+	version := c.version
+	c.mu.RUnlock()
+
+	value := map[string]any{
+		"version":   version,
+		"healthy":   status.Healthy(),
+		"successes": status.Successes,
+		"failures":  status.Failures,
+	}
+	if checksum, err := c.Checksum(); err == nil {
+		value["checksum"] = checksum
+	}
+	if !status.LastAttempt.IsZero() {
+		value["last_attempt"] = status.LastAttempt.Format(time.RFC3339)
+	}
+	if !status.LastSuccess.IsZero() {
+		value["last_success"] = status.LastSuccess.Format(time.RFC3339)
+	}
+	lastError := ""
+	if status.LastError != nil {
+		lastError = status.LastError.Error()
+	}
+	value["last_error"] = lastError
+	return value
+}
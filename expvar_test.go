@@ -0,0 +1,136 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"expvar"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ExpvarTestSuite struct {
+	suite.Suite
+}
+
+func TestExpvarTestSuite(t *testing.T) {
+	suite.Run(t, new(ExpvarTestSuite))
+}
+
+// uniqueName returns an expvar name that is not published yet, since variables outlive the tests.
+func uniqueName(prefix string) string {
+	return fmt.Sprintf("%s_%d", prefix, time.Now().UnixNano())
+}
+
+// read returns the decoded value of the expvar variable name.
+func (s *ExpvarTestSuite) read(name string) map[string]any {
+	v := expvar.Get(name)
+	s.Require().NotNil(v)
+
+	var value map[string]any
+	s.Require().NoError(json.Unmarshal([]byte(v.String()), &value))
+	return value
+}
+
+func (s *ExpvarTestSuite) TestWithExpvar() {
+	name := uniqueName("conflex_test_expvar")
+	src := &mockSyncSource{conf: map[string]any{"foo": "bar"}}
+	c, err := New(WithSource(src), WithExpvar(name))
+	s.Require().NoError(err)
+
+	value := s.read(name)
+	s.Equal(float64(0), value["version"])
+	s.Equal(false, value["healthy"])
+	s.NotContains(value, "last_attempt")
+	s.Equal("", value["last_error"])
+
+	s.Require().NoError(c.Load(context.Background()))
+	checksum, err := c.Checksum()
+	s.Require().NoError(err)
+
+	value = s.read(name)
+	s.Equal(float64(1), value["version"])
+	s.Equal(checksum, value["checksum"])
+	s.Equal(true, value["healthy"])
+	s.Equal(float64(1), value["successes"])
+	s.NotEmpty(value["last_success"])
+
+	src.set(nil, errors.New("connection refused"))
+	s.Require().Error(c.Load(context.Background()))
+	value = s.read(name)
+	s.Equal(false, value["healthy"])
+	s.Equal(float64(1), value["failures"])
+	s.Contains(value["last_error"], "connection refused")
+}
+
+func (s *ExpvarTestSuite) TestWithExpvar_Invalid() {
+	_, err := New(WithExpvar(""))
+	s.ErrorContains(err, "expvar name cannot be empty")
+
+	name := uniqueName("conflex_test_duplicate")
+	_, err = New(WithExpvar(name))
+	s.Require().NoError(err)
+	_, err = New(WithExpvar(name))
+	s.ErrorContains(err, fmt.Sprintf("expvar %q is already published", name))
+	var partial *PartialInitError
+	s.Require().ErrorAs(err, &partial)
+	s.Equal(0, partial.Options[0].Index)
+	s.Equal("WithExpvar", partial.Options[0].Name)
+
+	_, err = New(WithExpvar(name+"_twice"), WithExpvar(name+"_twice"))
+	s.ErrorContains(err, fmt.Sprintf("expvar %q is already published", name+"_twice"))
+}
+
+func (s *ExpvarTestSuite) TestWithExpvar_NotPublishedOnFailure() {
+	name := uniqueName("conflex_test_failed")
+	_, err := New(WithExpvar(name), WithSource(nil))
+	s.Require().Error(err)
+	s.Nil(expvar.Get(name))
+
+	// The name is still free for a construction that succeeds.
+	_, err = New(WithExpvar(name))
+	s.Require().NoError(err)
+	s.NotNil(expvar.Get(name))
+}
+
+func (s *ExpvarTestSuite) TestWithExpvar_Concurrent() {
+	name := uniqueName("conflex_test_concurrent")
+	errs := make(chan error, 10)
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := New(WithExpvar(name))
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	succeeded := 0
+	for err := range errs {
+		if err == nil {
+			succeeded++
+		}
+	}
+	s.Equal(1, succeeded)
+}
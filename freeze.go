@@ -0,0 +1,44 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import "errors"
+
+// ErrFrozen is returned by Load and Reload once the instance has been frozen with Freeze.
+var ErrFrozen = errors.New("configuration is frozen")
+
+// Freeze makes the configuration immutable, for services that deliberately do not support hot reload.
+// It stops all background activity like Close, and from then on Load and Reload fail with ErrFrozen without
+// touching the current values or the reload status. Values remain readable, and Validate can still be used
+// to check a configuration without applying it. Freezing an instance that is already frozen is a no-op.
+func (c *Conflex) Freeze() error {
+	c.mu.Lock()
+	c.frozen = true
+	c.mu.Unlock()
+
+	return c.Close()
+}
+
+// Frozen reports whether Freeze has been called.
+func (c *Conflex) Frozen() bool {
+	if c == nil {
+		return false
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.frozen
+}
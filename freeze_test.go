@@ -0,0 +1,83 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type FreezeTestSuite struct {
+	suite.Suite
+}
+
+func TestFreezeTestSuite(t *testing.T) {
+	suite.Run(t, new(FreezeTestSuite))
+}
+
+func (s *FreezeTestSuite) TestFreezeRejectsLoads() {
+	src := &mockSyncSource{conf: map[string]any{"foo": "bar"}}
+	c, err := New(WithSource(src))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+	s.False(c.Frozen())
+
+	s.Require().NoError(c.Freeze())
+	s.Require().NoError(c.Freeze())
+	s.True(c.Frozen())
+
+	src.set(map[string]any{"foo": "baz"}, nil)
+	s.ErrorIs(c.Load(context.Background()), ErrFrozen)
+	s.ErrorIs(c.Reload(context.Background(), "source[0]"), ErrFrozen)
+	s.Equal("bar", c.GetString("foo"))
+
+	// Rejected loads are not reload failures.
+	status := c.ReloadStatus()
+	s.True(status.Healthy())
+	s.Equal(uint64(1), status.Successes)
+
+	// Validate does not apply anything, so it still works.
+	s.NoError(c.Validate(context.Background()))
+}
+
+func (s *FreezeTestSuite) TestFreezeStopsAutoReload() {
+	src := &mockSyncSource{conf: map[string]any{"foo": "bar"}}
+	c, err := New(WithSource(src), WithAutoReload(10*time.Millisecond))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	s.Require().NoError(c.Freeze())
+	src.set(map[string]any{"foo": "baz"}, nil)
+	time.Sleep(50 * time.Millisecond)
+	s.Equal("bar", c.GetString("foo"))
+	s.Equal(uint64(1), c.ReloadStatus().Successes)
+}
+
+func (s *FreezeTestSuite) TestFreezeBeforeLoad() {
+	c, err := New(WithSource(&mockSyncSource{conf: map[string]any{"foo": "bar"}}))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Freeze())
+
+	s.ErrorIs(c.Load(context.Background()), ErrFrozen)
+	s.Nil(c.Get("foo"))
+}
+
+func (s *FreezeTestSuite) TestNilConflex() {
+	var c *Conflex
+	s.False(c.Frozen())
+}
@@ -0,0 +1,98 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"context"
+	"time"
+)
+
+// Hooks are functions called at well-defined points of Load and Reload, including background reloads such as
+// those of Watch and WithAutoReload and the refresh of leased secrets, to attach timing, logging or
+// circuit-breaking logic.
+// Any of them may be nil. Hooks are called synchronously from the goroutine performing the load.
+type Hooks struct {
+	// OnLoadStart is called before any source is loaded. If it returns an error, the load fails with that
+	// error without loading any source, which lets a circuit breaker stop hammering a failing backend.
+	OnLoadStart func(ctx context.Context) error
+
+	// OnSourceLoaded is called after each source is loaded, with the description of the source, the time it
+	// took and the error it returned, if any. Sources whose result is reused by Reload are not loaded and not
+	// reported. It is also called for the sources loaded by Validate.
+	OnSourceLoaded func(ctx context.Context, source SourceInfo, duration time.Duration, err error)
+
+	// OnLoadSuccess is called after a load has been applied, with the time the whole load took.
+	OnLoadSuccess func(ctx context.Context, duration time.Duration)
+
+	// OnLoadError is called after a load failed, with the time it took and its error. The previous
+	// configuration stays in effect.
+	OnLoadError func(ctx context.Context, duration time.Duration, err error)
+}
+
+// WithHooks registers lifecycle hooks called during Load and Reload. It may be used more than once; the
+// hooks of each call are called in registration order.
+func WithHooks(hooks Hooks) Option {
+	return func(c *Conflex) error {
+		c.hooks = append(c.hooks, hooks)
+		return nil
+	}
+}
+
+// runLoad loads the configuration like load, calling the lifecycle hooks and recording the outcome.
+func (c *Conflex) runLoad(ctx context.Context, cached func() []map[string]any) error {
+	start := time.Now()
+	report := &LoadReport{Start: start}
+	err := c.callLoadStartHooks(ctx)
+	if err == nil {
+		err = c.load(ctx, cached, report)
+	}
+	err = c.finishLoad(err)
+
+	duration := time.Since(start)
+	report.Duration = duration
+	report.Err = err
+	c.setLoadReport(report)
+	for _, hooks := range c.hooks {
+		if err == nil && hooks.OnLoadSuccess != nil {
+			hooks.OnLoadSuccess(ctx, duration)
+		}
+		if err != nil && hooks.OnLoadError != nil {
+			hooks.OnLoadError(ctx, duration, err)
+		}
+	}
+	return err
+}
+
+// callLoadStartHooks calls the OnLoadStart hooks and returns the first error, leaving the remaining hooks uncalled.
+func (c *Conflex) callLoadStartHooks(ctx context.Context) error {
+	for _, hooks := range c.hooks {
+		if hooks.OnLoadStart == nil {
+			continue
+		}
+		if err := hooks.OnLoadStart(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// callSourceLoadedHooks calls the OnSourceLoaded hooks for source i.
+func (c *Conflex) callSourceLoadedHooks(ctx context.Context, i int, duration time.Duration, err error) {
+	for _, hooks := range c.hooks {
+		if hooks.OnSourceLoaded != nil {
+			hooks.OnSourceLoaded(ctx, c.sourceInfo(i), duration, err)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type HooksTestSuite struct {
+	suite.Suite
+}
+
+func TestHooksTestSuite(t *testing.T) {
+	suite.Run(t, new(HooksTestSuite))
+}
+
+// recordingHooks returns hooks that append the events they observe to events.
+func recordingHooks(events *[]string) Hooks {
+	return Hooks{
+		OnLoadStart: func(context.Context) error {
+			*events = append(*events, "start")
+			return nil
+		},
+		OnSourceLoaded: func(_ context.Context, source SourceInfo, duration time.Duration, err error) {
+			event := "source " + source.Name
+			if err != nil {
+				event += ": " + err.Error()
+			}
+			*events = append(*events, event)
+		},
+		OnLoadSuccess: func(context.Context, time.Duration) {
+			*events = append(*events, "success")
+		},
+		OnLoadError: func(_ context.Context, _ time.Duration, err error) {
+			*events = append(*events, "error: "+err.Error())
+		},
+	}
+}
+
+func (s *HooksTestSuite) TestLoad() {
+	var events []string
+	c, err := New(
+		WithHooks(recordingHooks(&events)),
+		WithSource(&mockSource{conf: map[string]any{"foo": "bar"}}),
+		WithNamedSource("remote", &mockSource{conf: map[string]any{"baz": "qux"}}),
+	)
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+	s.Equal([]string{"start", "source source[0]", "source remote", "success"}, events)
+
+	events = nil
+	s.Require().NoError(c.Reload(context.Background(), "remote"))
+	s.Equal([]string{"start", "source remote", "success"}, events)
+}
+
+func (s *HooksTestSuite) TestLoad_Error() {
+	var events []string
+	var loadErr error
+	hooks := recordingHooks(&events)
+	hooks.OnLoadError = func(_ context.Context, _ time.Duration, err error) {
+		loadErr = err
+	}
+	src := &mockSyncSource{err: errors.New("connection refused")}
+	c, err := New(WithHooks(hooks), WithSource(src))
+	s.Require().NoError(err)
+
+	err = c.Load(context.Background())
+	s.Require().Error(err)
+	s.Equal(err, loadErr)
+	s.Equal([]string{"start", "source source[0]: connection refused"}, events)
+}
+
+func (s *HooksTestSuite) TestOnLoadStart_Abort() {
+	errOpen := errors.New("circuit open")
+	var called []string
+	src := &mockSyncSource{conf: map[string]any{"foo": "bar"}}
+	c, err := New(
+		WithSource(src),
+		WithHooks(Hooks{OnLoadStart: func(context.Context) error { return errOpen }}),
+		WithHooks(Hooks{
+			OnLoadStart: func(context.Context) error {
+				called = append(called, "start")
+				return nil
+			},
+			OnSourceLoaded: func(context.Context, SourceInfo, time.Duration, error) {
+				called = append(called, "source")
+			},
+			OnLoadError: func(_ context.Context, _ time.Duration, err error) {
+				called = append(called, "error")
+				s.ErrorIs(err, errOpen)
+			},
+		}),
+	)
+	s.Require().NoError(err)
+
+	s.ErrorIs(c.Load(context.Background()), errOpen)
+	s.Equal([]string{"error"}, called)
+	s.Nil(c.Get("foo"))
+	s.Equal(uint64(1), c.ReloadStatus().Failures)
+}
+
+func (s *HooksTestSuite) TestDurations() {
+	var sourceDuration, loadDuration time.Duration
+	c, err := New(
+		WithSource(&delayedSource{delay: 20 * time.Millisecond}),
+		WithHooks(Hooks{
+			OnSourceLoaded: func(_ context.Context, _ SourceInfo, duration time.Duration, _ error) {
+				sourceDuration = duration
+			},
+			OnLoadSuccess: func(_ context.Context, duration time.Duration) {
+				loadDuration = duration
+			},
+		}),
+	)
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	s.GreaterOrEqual(sourceDuration, 20*time.Millisecond)
+	s.GreaterOrEqual(loadDuration, sourceDuration)
+}
+
+// delayedSource is a source that takes delay to load.
+type delayedSource struct {
+	delay time.Duration
+}
+
+func (d *delayedSource) Load(context.Context) (map[string]any, error) {
+	time.Sleep(d.delay)
+	return map[string]any{}, nil
+}
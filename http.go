@@ -0,0 +1,187 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// HTTPServerConfig is the standard configuration subtree for an HTTP server, for example:
+//
+//	server:
+//	  addr: ":8080"
+//	  read_timeout: 5s
+//	  read_header_timeout: 2s
+//	  write_timeout: 10s
+//	  idle_timeout: 2m
+//	  max_header_bytes: 1048576
+//	  tls:
+//	    cert_file: /etc/tls/tls.crt
+//	    key_file: /etc/tls/tls.key
+//	    min_version: "1.2"
+type HTTPServerConfig struct {
+	Addr              string        `conflex:"addr"`
+	ReadTimeout       time.Duration `conflex:"read_timeout"`
+	ReadHeaderTimeout time.Duration `conflex:"read_header_timeout"`
+	WriteTimeout      time.Duration `conflex:"write_timeout"`
+	IdleTimeout       time.Duration `conflex:"idle_timeout"`
+	MaxHeaderBytes    int           `conflex:"max_header_bytes"`
+	TLS               HTTPTLSConfig `conflex:"tls"`
+}
+
+// HTTPTLSConfig configures TLS for an HTTP server. TLS is enabled when both CertFile and KeyFile are set.
+type HTTPTLSConfig struct {
+	CertFile   string `conflex:"cert_file"`
+	KeyFile    string `conflex:"key_file"`
+	MinVersion string `conflex:"min_version"` // "1.0", "1.1", "1.2" or "1.3"; defaults to "1.2"
+}
+
+// Enabled reports whether TLS is configured.
+func (t HTTPTLSConfig) Enabled() bool {
+	return t.CertFile != "" && t.KeyFile != ""
+}
+
+// tlsVersions maps configuration names to TLS versions.
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// HTTPServerConfig decodes the HTTP server configuration under key.
+func (c *Conflex) HTTPServerConfig(key string) (HTTPServerConfig, error) {
+	var cfg HTTPServerConfig
+	if c == nil {
+		return cfg, errors.New("conflex instance is nil")
+	}
+
+	value := c.Get(key)
+	if value == nil {
+		return cfg, NewConfigFieldError("http-server", key, "decode", errors.New("key not found"))
+	}
+	if err := c.decode(value, &cfg); err != nil {
+		return cfg, NewConfigFieldError("http-server", key, "decode", err)
+	}
+	return cfg, nil
+}
+
+// NewHTTPServer builds an *http.Server from the HTTP server configuration under key (see HTTPServerConfig).
+// If TLS is configured, the certificate is loaded into the server's TLSConfig, so the server is started with
+// ListenAndServeTLS("", "").
+//
+// The read and write timeouts follow configuration reloads: every request gets its deadlines from the
+// configuration in effect when it arrives, so new values apply to new requests without restarting the server.
+// The address, header timeout, idle timeout, header size and TLS settings only take effect on a new server.
+// The server stops following reloads once it is shut down with Shutdown; a server stopped with Close keeps its
+// change handler registered, so Shutdown should be used when servers are replaced during the lifetime of c.
+func (c *Conflex) NewHTTPServer(key string, handler http.Handler) (*http.Server, error) {
+	cfg, err := c.HTTPServerConfig(key)
+	if err != nil {
+		return nil, err
+	}
+	if handler == nil {
+		handler = http.DefaultServeMux
+	}
+
+	timeouts := &httpTimeouts{}
+	timeouts.store(cfg)
+
+	server := &http.Server{
+		Addr:              cfg.Addr,
+		Handler:           timeouts.wrap(handler),
+		ReadTimeout:       cfg.ReadTimeout,
+		ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+		WriteTimeout:      cfg.WriteTimeout,
+		IdleTimeout:       cfg.IdleTimeout,
+		MaxHeaderBytes:    cfg.MaxHeaderBytes,
+	}
+
+	if cfg.TLS.Enabled() {
+		tlsConfig, err := newTLSConfig(cfg.TLS)
+		if err != nil {
+			return nil, NewConfigFieldError("http-server", key+".tls", "load", err)
+		}
+		server.TLSConfig = tlsConfig
+	}
+
+	unregister := c.onChange(func(_, _ map[string]any) {
+		if cfg, err := c.HTTPServerConfig(key); err == nil {
+			timeouts.store(cfg)
+		}
+	})
+	server.RegisterOnShutdown(unregister)
+
+	return server, nil
+}
+
+// newTLSConfig loads the certificate and builds a TLS configuration.
+func newTLSConfig(cfg HTTPTLSConfig) (*tls.Config, error) {
+	minVersion := uint16(tls.VersionTLS12)
+	if cfg.MinVersion != "" {
+		version, ok := tlsVersions[cfg.MinVersion]
+		if !ok {
+			return nil, fmt.Errorf("unsupported TLS version %q", cfg.MinVersion)
+		}
+		minVersion = version
+	}
+
+	cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tls.Config{
+		MinVersion:   minVersion,
+		Certificates: []tls.Certificate{cert},
+	}, nil
+}
+
+// httpTimeouts holds the read and write timeouts currently in effect for a server built by NewHTTPServer.
+type httpTimeouts struct {
+	read  atomic.Int64
+	write atomic.Int64
+}
+
+func (t *httpTimeouts) store(cfg HTTPServerConfig) {
+	t.read.Store(int64(cfg.ReadTimeout))
+	t.write.Store(int64(cfg.WriteTimeout))
+}
+
+// wrap returns a handler that applies the current timeouts to each request before calling next.
+func (t *httpTimeouts) wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		now := time.Now()
+		rc := http.NewResponseController(w)
+		// Deadlines cannot be set on every connection type (e.g. HTTP/2 without support); the
+		// server-wide timeouts remain in effect in that case.
+		_ = rc.SetReadDeadline(deadline(now, time.Duration(t.read.Load())))
+		_ = rc.SetWriteDeadline(deadline(now, time.Duration(t.write.Load())))
+		next.ServeHTTP(w, r)
+	})
+}
+
+// deadline returns now+timeout, or the zero time (no deadline) if timeout is not positive.
+func deadline(now time.Time, timeout time.Duration) time.Time {
+	if timeout <= 0 {
+		return time.Time{}
+	}
+	return now.Add(timeout)
+}
@@ -0,0 +1,212 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type HTTPTestSuite struct {
+	suite.Suite
+}
+
+func TestHTTPTestSuite(t *testing.T) {
+	suite.Run(t, new(HTTPTestSuite))
+}
+
+func (s *HTTPTestSuite) TestHTTPServerConfig() {
+	c, err := New(WithSource(&mockSource{conf: map[string]any{
+		"server": map[string]any{
+			"addr":                ":8080",
+			"read_timeout":        "5s",
+			"read_header_timeout": "2s",
+			"write_timeout":       "10s",
+			"idle_timeout":        "2m",
+			"max_header_bytes":    4096,
+		},
+	}}))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	cfg, err := c.HTTPServerConfig("server")
+	s.Require().NoError(err)
+	s.Equal(HTTPServerConfig{
+		Addr:              ":8080",
+		ReadTimeout:       5 * time.Second,
+		ReadHeaderTimeout: 2 * time.Second,
+		WriteTimeout:      10 * time.Second,
+		IdleTimeout:       2 * time.Minute,
+		MaxHeaderBytes:    4096,
+	}, cfg)
+	s.False(cfg.TLS.Enabled())
+
+	server, err := c.NewHTTPServer("server", nil)
+	s.Require().NoError(err)
+	s.Equal(":8080", server.Addr)
+	s.Equal(5*time.Second, server.ReadTimeout)
+	s.Equal(2*time.Second, server.ReadHeaderTimeout)
+	s.Equal(10*time.Second, server.WriteTimeout)
+	s.Equal(2*time.Minute, server.IdleTimeout)
+	s.Equal(4096, server.MaxHeaderBytes)
+	s.Nil(server.TLSConfig)
+
+	_, err = c.HTTPServerConfig("missing")
+	s.ErrorContains(err, "key not found")
+}
+
+func (s *HTTPTestSuite) TestNewHTTPServer_TimeoutsFollowReload() {
+	src := &mockSyncSource{conf: map[string]any{
+		"server": map[string]any{"addr": "127.0.0.1:0", "write_timeout": "5s"},
+	}}
+	c, err := New(WithSource(src))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	server, err := c.NewHTTPServer("server", http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		_, _ = io.WriteString(w, "ok")
+	}))
+	s.Require().NoError(err)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	s.Require().NoError(err)
+	go func() { _ = server.Serve(ln) }()
+	defer func() { _ = server.Close() }()
+
+	url := "http://" + ln.Addr().String()
+	client := &http.Client{Transport: &http.Transport{DisableKeepAlives: true}}
+
+	resp, err := client.Get(url)
+	s.Require().NoError(err)
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	s.Equal("ok", string(body))
+
+	// After a reload with a shorter write timeout, slow responses are cut off.
+	src.set(map[string]any{
+		"server": map[string]any{"addr": "127.0.0.1:0", "write_timeout": "50ms"},
+	}, nil)
+	s.Require().NoError(c.Load(context.Background()))
+
+	resp, err = client.Get(url)
+	if err == nil {
+		body, _ = io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+	}
+	s.True(err != nil || string(body) != "ok", "expected the response to be cut off by the write timeout")
+}
+
+func (s *HTTPTestSuite) TestNewHTTPServer_ShutdownUnregisters() {
+	c, err := New(WithSource(&mockSource{conf: map[string]any{"server": map[string]any{"addr": "127.0.0.1:0"}}}))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	handlers := func() int {
+		c.mu.RLock()
+		defer c.mu.RUnlock()
+		return len(c.changeHandlers)
+	}
+
+	first, err := c.NewHTTPServer("server", nil)
+	s.Require().NoError(err)
+	second, err := c.NewHTTPServer("server", nil)
+	s.Require().NoError(err)
+	s.Equal(2, handlers())
+
+	// Shutdown runs the registered functions in their own goroutines.
+	s.Require().NoError(first.Shutdown(context.Background()))
+	s.Eventually(func() bool { return handlers() == 1 }, time.Second, 10*time.Millisecond)
+	s.Require().NoError(second.Shutdown(context.Background()))
+	s.Eventually(func() bool { return handlers() == 0 }, time.Second, 10*time.Millisecond)
+}
+
+func (s *HTTPTestSuite) TestNewHTTPServer_TLS() {
+	certFile, keyFile := s.writeCertificate()
+	c, err := New(WithSource(&mockSource{conf: map[string]any{
+		"server": map[string]any{
+			"addr": ":8443",
+			"tls":  map[string]any{"cert_file": certFile, "key_file": keyFile, "min_version": "1.3"},
+		},
+	}}))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	server, err := c.NewHTTPServer("server", nil)
+	s.Require().NoError(err)
+	s.Require().NotNil(server.TLSConfig)
+	s.Equal(uint16(tls.VersionTLS13), server.TLSConfig.MinVersion)
+	s.Len(server.TLSConfig.Certificates, 1)
+}
+
+func (s *HTTPTestSuite) TestNewHTTPServer_TLSErrors() {
+	certFile, keyFile := s.writeCertificate()
+	c, err := New(WithSource(&mockSource{conf: map[string]any{
+		"bad_version": map[string]any{
+			"tls": map[string]any{"cert_file": certFile, "key_file": keyFile, "min_version": "0.9"},
+		},
+		"missing_file": map[string]any{
+			"tls": map[string]any{"cert_file": "/nonexistent.crt", "key_file": "/nonexistent.key"},
+		},
+	}}))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	_, err = c.NewHTTPServer("bad_version", nil)
+	s.ErrorContains(err, `unsupported TLS version "0.9"`)
+
+	_, err = c.NewHTTPServer("missing_file", nil)
+	s.Error(err)
+}
+
+// writeCertificate writes a self-signed certificate and key and returns their paths.
+func (s *HTTPTestSuite) writeCertificate() (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.Require().NoError(err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	s.Require().NoError(err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	s.Require().NoError(err)
+
+	dir := s.T().TempDir()
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+	s.Require().NoError(os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	s.Require().NoError(os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+	return certFile, keyFile
+}
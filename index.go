@@ -0,0 +1,89 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/go-viper/mapstructure/v2"
+)
+
+// sliceIndex parses a path segment as an index into a list of length n. Only plain decimal numbers are indexes.
+func sliceIndex(segment string, n int) (int, bool) {
+	if segment == "" {
+		return 0, false
+	}
+	for _, r := range segment {
+		if r < '0' || r > '9' {
+			return 0, false
+		}
+	}
+	i, err := strconv.Atoi(segment)
+	if err != nil || i >= n {
+		return 0, false
+	}
+	return i, true
+}
+
+// indexedList converts a map whose keys are all list indexes, such as the map produced by the environment
+// variables SERVERS_0_HOST and SERVERS_1_HOST, into a list ordered by index. Missing indexes become nil.
+// It reports false if m is empty or has a key that is not an index.
+func indexedList(m map[string]any) ([]any, bool) {
+	if len(m) == 0 {
+		return nil, false
+	}
+
+	indexes := make([]int, 0, len(m))
+	byIndex := make(map[int]any, len(m))
+	for k, v := range m {
+		// Bound the length, so a key such as "1000000000" cannot allocate a huge list.
+		i, ok := sliceIndex(k, len(m)*maxIndexGap)
+		if !ok {
+			return nil, false
+		}
+		indexes = append(indexes, i)
+		byIndex[i] = v
+	}
+	sort.Ints(indexes)
+
+	list := make([]any, indexes[len(indexes)-1]+1)
+	for _, i := range indexes {
+		list[i] = byIndex[i]
+	}
+	return list, true
+}
+
+// maxIndexGap limits how sparse an index-keyed map may be for indexedList, relative to its size.
+const maxIndexGap = 64
+
+// indexedMapHookFunc returns a decode hook that decodes maps keyed by list indexes into slices and arrays,
+// so that elements of a []struct field can be set by index, for example from environment variables.
+func indexedMapHookFunc() mapstructure.DecodeHookFuncType {
+	return func(from reflect.Type, to reflect.Type, data any) (any, error) {
+		if from.Kind() != reflect.Map || (to.Kind() != reflect.Slice && to.Kind() != reflect.Array) {
+			return data, nil
+		}
+		m, ok := data.(map[string]any)
+		if !ok {
+			return data, nil
+		}
+		if list, ok := indexedList(m); ok {
+			return list, nil
+		}
+		return data, nil
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type IndexTestSuite struct {
+	suite.Suite
+}
+
+func TestIndexTestSuite(t *testing.T) {
+	suite.Run(t, new(IndexTestSuite))
+}
+
+func (s *IndexTestSuite) load(conf map[string]any, opts ...Option) *Conflex {
+	c, err := New(append([]Option{WithSource(&mockSource{conf: conf})}, opts...)...)
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+	return c
+}
+
+func (s *IndexTestSuite) TestGetByIndex() {
+	c := s.load(map[string]any{
+		"servers": []any{
+			map[string]any{"host": "a.internal", "ports": []any{80, 443}},
+			map[string]any{"host": "b.internal"},
+		},
+		"tags": []string{"x", "y"},
+	})
+
+	s.Equal("a.internal", c.GetString("servers.0.host"))
+	s.Equal("b.internal", c.GetString("Servers.1.Host"))
+	s.Equal(443, c.GetInt("servers.0.ports.1"))
+	s.Equal("y", c.GetString("tags.1"))
+	s.Equal(map[string]any{"host": "b.internal"}, c.Get("servers.1"))
+	s.Equal("b.internal", c.Sub("servers.1").GetString("host"))
+
+	s.Nil(c.Get("servers.2.host"))
+	s.Nil(c.Get("servers.-1.host"))
+	s.Nil(c.Get("servers.+1.host"))
+	s.Nil(c.Get("servers.first.host"))
+	s.Nil(c.Get("servers.0.host.extra"))
+}
+
+func (s *IndexTestSuite) TestBindElementsByIndex() {
+	type Server struct {
+		Host string `conflex:"host"`
+		Port int    `conflex:"port"`
+	}
+	type Config struct {
+		Servers []Server `conflex:"servers"`
+		Weights [2]int   `conflex:"weights"`
+		Labels  []string `conflex:"labels"`
+	}
+
+	// Environment variables such as APP_SERVERS_1_HOST produce maps keyed by index.
+	var cfg Config
+	s.load(map[string]any{
+		"servers": map[string]any{
+			"1": map[string]any{"host": "b.internal", "port": "8081"},
+			"0": map[string]any{"host": "a.internal", "port": "8080"},
+		},
+		"weights": map[string]any{"0": "3", "1": "1"},
+		"labels":  map[string]any{"0": "x", "2": "z"},
+	}, WithBinding(&cfg))
+
+	s.Equal([]Server{{Host: "a.internal", Port: 8080}, {Host: "b.internal", Port: 8081}}, cfg.Servers)
+	s.Equal([2]int{3, 1}, cfg.Weights)
+	s.Equal([]string{"x", "", "z"}, cfg.Labels)
+}
+
+func (s *IndexTestSuite) TestIndexedList() {
+	list, ok := indexedList(map[string]any{"1": "b", "0": "a"})
+	s.True(ok)
+	s.Equal([]any{"a", "b"}, list)
+
+	_, ok = indexedList(map[string]any{"0": "a", "name": "b"})
+	s.False(ok)
+	_, ok = indexedList(map[string]any{})
+	s.False(ok)
+	_, ok = indexedList(map[string]any{"1000000000": "a"})
+	s.False(ok)
+}
@@ -0,0 +1,99 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package keypath implements the dot-separated key paths shared by conflex and its dumpers.
+package keypath
+
+import "strings"
+
+// Split splits a dot-separated key into its segments. A dot inside a segment is escaped with a backslash, so
+// "labels.app\.kubernetes\.io/name" has the segments "labels" and "app.kubernetes.io/name". A literal backslash
+// before a dot or another backslash is written as "\\"; other backslashes are kept as they are.
+func Split(key string) []string {
+	if !strings.Contains(key, `\`) {
+		return strings.Split(key, ".")
+	}
+
+	var segments []string
+	var segment strings.Builder
+	for i := 0; i < len(key); i++ {
+		switch {
+		case key[i] == '\\' && i+1 < len(key) && (key[i+1] == '.' || key[i+1] == '\\'):
+			i++
+			segment.WriteByte(key[i])
+		case key[i] == '.':
+			segments = append(segments, segment.String())
+			segment.Reset()
+		default:
+			segment.WriteByte(key[i])
+		}
+	}
+	return append(segments, segment.String())
+}
+
+// Join joins key segments into a dot-separated key, escaping them so that Split returns the segments again.
+func Join(path []string) string {
+	escaped := make([]string, len(path))
+	for i, segment := range path {
+		escaped[i] = EscapeSegment(segment)
+	}
+	return strings.Join(escaped, ".")
+}
+
+// EscapeSegment escapes the dots and backslashes in a key segment, as described for Split.
+func EscapeSegment(segment string) string {
+	if !strings.ContainsAny(segment, `.\`) {
+		return segment
+	}
+	return strings.NewReplacer(`\`, `\\`, ".", `\.`).Replace(segment)
+}
+
+// Extract returns a map holding only the value at path in m, nested under the keys as they appear in m.
+// It returns an empty map if m has no value at path. If fold is set, a segment without an exact match matches
+// a key that equals it ignoring case. Values are shared, not copied.
+func Extract(m map[string]any, path []string, fold bool) map[string]any {
+	key, ok := findKey(m, path[0], fold)
+	if !ok {
+		return map[string]any{}
+	}
+	value := m[key]
+	if len(path) > 1 {
+		nested, ok := value.(map[string]any)
+		if !ok {
+			return map[string]any{}
+		}
+		inner := Extract(nested, path[1:], fold)
+		if len(inner) == 0 {
+			return inner
+		}
+		value = inner
+	}
+	return map[string]any{key: value}
+}
+
+// findKey returns the key of m that equals name, or failing that and if fold is set, equals it ignoring case.
+func findKey(m map[string]any, name string, fold bool) (string, bool) {
+	if _, ok := m[name]; ok {
+		return name, true
+	}
+	if !fold {
+		return "", false
+	}
+	for key := range m {
+		if strings.EqualFold(key, name) {
+			return key, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,45 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keypath
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type KeyPathTestSuite struct {
+	suite.Suite
+}
+
+func TestKeyPathTestSuite(t *testing.T) {
+	suite.Run(t, new(KeyPathTestSuite))
+}
+
+func (s *KeyPathTestSuite) TestSplitAndJoin() {
+	s.Equal([]string{"a", "b.c", "d"}, Split(`a.b\.c.d`))
+	for _, path := range [][]string{{"a"}, {"a.b", `c\`}, {`\.`, ""}} {
+		s.Equal(path, Split(Join(path)))
+	}
+}
+
+func (s *KeyPathTestSuite) TestExtract() {
+	m := map[string]any{"Server": map[string]any{"port": 80, "host": "a"}, "labels": map[string]any{"a.b": "c"}}
+
+	s.Equal(map[string]any{"Server": map[string]any{"port": 80}}, Extract(m, Split("server.port"), true))
+	s.Empty(Extract(m, Split("server.port"), false))
+	s.Equal(map[string]any{"labels": map[string]any{"a.b": "c"}}, Extract(m, Split(`labels.a\.b`), false))
+	s.Empty(Extract(m, Split("Server.port.value"), false))
+}
@@ -0,0 +1,172 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// lazyLoadTimeout bounds the first load of a lazy source, which runs inside a getter that has no context.
+const lazyLoadTimeout = 30 * time.Second
+
+// lazySource holds the state of a source added with WithLazySource.
+type lazySource struct {
+	prefix  string        // The key the data of the source is mounted under, as passed to WithLazySource
+	timeout time.Duration // Bound of the first load
+	mu      sync.Mutex    // Serializes the first load of the source
+	loaded  atomic.Bool   // Whether the source takes part in loads
+	frozen  bool          // Whether an access after Freeze has been logged; guarded by mu
+}
+
+// WithLazySource adds a source that is not loaded by Load, but on first access to a key under prefix, for
+// expensive sources that a program usually does not need, such as a remote backend that only a few commands of
+// a CLI read. The data of the source is mounted under prefix: a source returning {"port": 8080} mounted under
+// "plugins.search" provides "plugins.search.port".
+//
+// The first Get of a key under prefix, or of one of its parents, after the first successful Load loads the
+// source, with a timeout of 30 seconds, and merges its data with the results of the other sources from the
+// previous load, without loading those again; the typed getters, GetMany, View and Sub read through Get and
+// load it the same way. The merged configuration is validated and bound like a reload, and change and rebind
+// handlers see the keys of the source as added, but restart-required keys are not checked, since the data only
+// becomes visible. The lazy load waits for a load or reload in progress and then starts from its result. From then
+// on, the source is loaded like any other source by every Load and Reload. AllSettings, Dump, Bind and the
+// other whole-configuration reads do not load it. If the lazy load fails, the error is logged, the key reads
+// as unset and the next access tries again. A lazy source that has not been loaded when Freeze is called is
+// never loaded: its keys read as unset, and the first access logs a warning.
+//
+//	plugins, _ := source.NewConsul("app/plugins", &codec.JSONCodec{}, nil)
+//	conflex.WithLazySource("plugins", plugins)
+func WithLazySource(prefix string, src Source) Option {
+	return func(c *Conflex) error {
+		if src == nil {
+			return errors.New("source cannot be nil")
+		}
+		prefix = strings.TrimSpace(prefix)
+		if prefix == "" {
+			return errors.New("lazy source prefix cannot be empty")
+		}
+
+		if c.lazySources == nil {
+			c.lazySources = make(map[int]*lazySource)
+		}
+		c.lazySources[len(c.sources)] = &lazySource{prefix: prefix, timeout: lazyLoadTimeout}
+		info := describeCustomSource(src)
+		info.Options = map[string]string{"lazy": "true", "prefix": prefix}
+		c.addSource(src, info)
+		return nil
+	}
+}
+
+// skipLazySource reports whether source i is a lazy source that has not been accessed yet.
+func (c *Conflex) skipLazySource(i int) bool {
+	ls, ok := c.lazySources[i]
+	return ok && !ls.loaded.Load()
+}
+
+// mountLazySource returns conf nested under the prefix of source i, if it is a lazy source.
+func (c *Conflex) mountLazySource(i int, conf map[string]any) map[string]any {
+	ls, ok := c.lazySources[i]
+	if !ok {
+		return conf
+	}
+	mounted := make(map[string]any)
+	setValue(mounted, splitKey(ls.prefix), conf)
+	return mounted
+}
+
+// loadLazySources loads the lazy sources mounted under key, under one of its parents, or below it, that have
+// not been loaded yet. It does nothing before the first successful Load.
+func (c *Conflex) loadLazySources(key string) {
+	if len(c.lazySources) == 0 {
+		return
+	}
+	c.mu.RLock()
+	loaded := c.loaded
+	c.mu.RUnlock()
+	if !loaded {
+		return
+	}
+
+	key = c.normalizeKey(key)
+	for i, ls := range c.lazySources {
+		if ls.loaded.Load() {
+			continue
+		}
+		prefix := c.normalizeKey(ls.prefix)
+		if !keyHasPrefix(key, prefix) && !keyHasPrefix(prefix, key) {
+			continue
+		}
+		c.loadLazySource(i, ls)
+	}
+}
+
+// loadLazySource loads the lazy source i and merges its data with the cached results of the other sources.
+// The source is marked as loaded before it is loaded, so loadSources does not skip it.
+func (c *Conflex) loadLazySource(i int, ls *lazySource) {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	if ls.loaded.Load() {
+		return
+	}
+	if c.Frozen() {
+		if !ls.frozen {
+			ls.frozen = true
+			c.log().Warn("lazy configuration source not loaded: configuration is frozen", "source", c.sourceName(i))
+		}
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), ls.timeout)
+	defer cancel()
+
+	report := &LoadReport{Start: time.Now()}
+	res, oldValues, err := c.applyLazySource(ctx, ls, report)
+	if err != nil {
+		c.log().Warn("lazy configuration source failed to load", "source", c.sourceName(i), "error", err)
+		return
+	}
+	report.Duration = time.Since(report.Start)
+	c.setLoadReport(report)
+
+	c.notifyChange(oldValues, res.values)
+	c.notifyRebind(res.values)
+}
+
+// applyLazySource merges the data of the lazy source ls with the cached results of the other sources and applies
+// the result, returning the resolution and the previous values. Like loadAndApply, it holds loadMu, so it
+// neither reads results a running load is about to replace nor overwrites a newer result.
+func (c *Conflex) applyLazySource(ctx context.Context, ls *lazySource, report *LoadReport) (*resolution, map[string]any, error) {
+	c.loadMu.Lock()
+	defer c.loadMu.Unlock()
+
+	ls.loaded.Store(true)
+	res, err := c.resolve(ctx, c.cachedResults(), false, report)
+	if err != nil {
+		ls.loaded.Store(false)
+		return nil, nil, err
+	}
+	oldValues, err := c.apply(res)
+	if err != nil {
+		ls.loaded.Store(false)
+		return nil, nil, err
+	}
+	return res, oldValues, nil
+}
@@ -0,0 +1,234 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type LazySourceTestSuite struct {
+	suite.Suite
+}
+
+func TestLazySourceTestSuite(t *testing.T) {
+	suite.Run(t, new(LazySourceTestSuite))
+}
+
+// newLazy returns a loaded Conflex with an eager source and a lazy source mounted under "plugins.search".
+func (s *LazySourceTestSuite) newLazy(opts ...Option) (*Conflex, *countingSource) {
+	lazy := &countingSource{}
+	lazy.set(map[string]any{"port": 9200, "Index": "products"}, nil)
+	opts = append([]Option{
+		WithSource(&mockSource{conf: map[string]any{"server": map[string]any{"port": 8080}}}),
+		WithLazySource("plugins.search", lazy),
+	}, opts...)
+	c, err := New(opts...)
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+	return c, lazy
+}
+
+func (s *LazySourceTestSuite) TestLoadedOnFirstAccess() {
+	c, lazy := s.newLazy()
+	s.Equal(int32(0), lazy.loads.Load())
+	s.Nil(c.AllSettings()["plugins"])
+
+	s.Equal(8080, c.GetInt("server.port"))
+	s.Equal(int32(0), lazy.loads.Load(), "keys outside the prefix do not load the source")
+
+	s.Equal(9200, c.GetInt("plugins.search.port"))
+	s.Equal("products", c.GetString("plugins.search.index"))
+	s.Equal(int32(1), lazy.loads.Load())
+	s.Equal(8080, c.GetInt("server.port"))
+
+	// Once accessed, the source takes part in every load.
+	s.Require().NoError(c.Load(context.Background()))
+	s.Equal(int32(2), lazy.loads.Load())
+	s.Equal(9200, c.GetInt("plugins.search.port"))
+}
+
+func (s *LazySourceTestSuite) TestAccessToParent() {
+	c, lazy := s.newLazy()
+	s.Equal(map[string]any{"search": map[string]any{"port": 9200, "index": "products"}}, c.Get("plugins"))
+	s.Equal(int32(1), lazy.loads.Load())
+
+	c, lazy = s.newLazy()
+	s.Equal(9200, c.Sub("plugins").GetInt("search.port"))
+	s.Equal(int32(1), lazy.loads.Load())
+}
+
+func (s *LazySourceTestSuite) TestGetMany() {
+	c, lazy := s.newLazy()
+	values, err := c.GetManyE("server.port", "plugins.search.port")
+	s.Require().NoError(err)
+	s.Equal(map[string]any{"server.port": 8080, "plugins.search.port": 9200}, values)
+	s.Equal(int32(1), lazy.loads.Load())
+}
+
+func (s *LazySourceTestSuite) TestNotLoadedBeforeLoad() {
+	lazy := &countingSource{}
+	lazy.set(map[string]any{"port": 9200}, nil)
+	c, err := New(WithLazySource("plugins", lazy))
+	s.Require().NoError(err)
+
+	s.Nil(c.Get("plugins.port"))
+	s.Equal(int32(0), lazy.loads.Load())
+}
+
+func (s *LazySourceTestSuite) TestFailureIsRetried() {
+	c, lazy := s.newLazy()
+	lazy.set(nil, errors.New("connection refused"))
+
+	s.Nil(c.Get("plugins.search.port"))
+	s.Equal(int32(1), lazy.loads.Load())
+	s.Equal(8080, c.GetInt("server.port"))
+
+	// The failed lazy load does not make the source part of later loads.
+	s.Require().NoError(c.Load(context.Background()))
+	s.Equal(int32(1), lazy.loads.Load())
+
+	lazy.set(map[string]any{"port": 9200}, nil)
+	s.Equal(9200, c.GetInt("plugins.search.port"))
+	s.Equal(int32(2), lazy.loads.Load())
+}
+
+func (s *LazySourceTestSuite) TestConcurrentAccess() {
+	c, lazy := s.newLazy()
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.Get("plugins.search.port")
+		}()
+	}
+	wg.Wait()
+
+	s.Equal(int32(1), lazy.loads.Load())
+	s.Equal(9200, c.GetInt("plugins.search.port"))
+}
+
+func (s *LazySourceTestSuite) TestChangeHandlers() {
+	c, lazy := s.newLazy()
+	var changed []ChangeEvent
+	c.OnDiff(func(changes []ChangeEvent) { changed = append(changed, changes...) })
+	rebinds := 0
+	OnRebind(c, func(*map[string]any) { rebinds++ })
+	version := c.Snapshot().Version()
+
+	s.Equal(9200, c.GetInt("plugins.search.port"))
+	s.Equal(int32(1), lazy.loads.Load())
+	s.Equal(version+1, c.Snapshot().Version())
+	s.Equal([]ChangeEvent{
+		{Key: "plugins.search.index", Type: ChangeAdded, New: "products"},
+		{Key: "plugins.search.port", Type: ChangeAdded, New: 9200},
+	}, changed)
+	s.Equal(1, rebinds)
+
+	changed = nil
+	s.Require().NoError(c.Load(context.Background()))
+	s.Empty(changed)
+}
+
+func (s *LazySourceTestSuite) TestWaitsForLoads() {
+	base := &mockSyncSource{conf: map[string]any{"version": 1}}
+	lazy := &gatedSource{mockSyncSource: mockSyncSource{conf: map[string]any{"port": 9200}}}
+	c, err := New(WithSource(base), WithLazySource("plugins", lazy))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	lazy.mu.Lock()
+	lazy.started, lazy.release = make(chan struct{}), make(chan struct{})
+	lazy.mu.Unlock()
+	got := make(chan any, 1)
+	go func() { got <- c.Get("plugins.port") }()
+	<-lazy.started
+	lazy.mu.Lock()
+	lazy.started = nil
+	lazy.mu.Unlock()
+
+	base.set(map[string]any{"version": 2}, nil)
+	reloaded := make(chan error, 1)
+	go func() { reloaded <- c.Reload(context.Background(), "source[0]") }()
+
+	select {
+	case <-reloaded:
+		s.Require().Fail("a reload must wait for the lazy load in progress")
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(lazy.release)
+	s.Equal(9200, <-got)
+	s.Require().NoError(<-reloaded)
+
+	// The lazy load must not revert the reload with the stale cached result of the other source.
+	s.Equal(2, c.GetInt("version"))
+	s.Equal(9200, c.GetInt("plugins.port"))
+}
+
+func (s *LazySourceTestSuite) TestTimeout() {
+	c, err := New(WithLazySource("plugins", &mockContextAwareSource{conf: map[string]any{"port": 9200}}))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+	c.lazySources[0].timeout = 10 * time.Millisecond
+
+	s.Nil(c.Get("plugins.port"))
+
+	c.lazySources[0].timeout = time.Second
+	s.Equal(9200, c.Get("plugins.port"))
+}
+
+func (s *LazySourceTestSuite) TestFrozen() {
+	logs := &bytes.Buffer{}
+	c, lazy := s.newLazy(WithLogger(slog.New(slog.NewTextHandler(logs, nil))))
+	s.Require().NoError(c.Freeze())
+
+	s.Nil(c.Get("plugins.search.port"))
+	s.Nil(c.Get("plugins.search.index"))
+	s.Equal(int32(0), lazy.loads.Load())
+	s.Equal(1, strings.Count(logs.String(), "configuration is frozen"), "the access after Freeze is logged once")
+	s.Equal(8080, c.GetInt("server.port"))
+}
+
+func (s *LazySourceTestSuite) TestLoadReport() {
+	c, _ := s.newLazy()
+	report := c.LoadReport()
+	s.Require().Len(report.Sources, 1)
+	s.Equal("source[0]", report.Sources[0].Source.Name)
+
+	c.Get("plugins.search")
+	report = c.LoadReport()
+	s.Require().Len(report.Sources, 2)
+	s.Equal(map[string]string{"lazy": "true", "prefix": "plugins.search"}, report.Sources[1].Source.Options)
+	s.Equal(2, report.Sources[1].Keys)
+	s.True(report.Sources[0].Cached)
+}
+
+func (s *LazySourceTestSuite) TestInvalidOptions() {
+	_, err := New(WithLazySource("plugins", nil))
+	s.ErrorContains(err, "source cannot be nil")
+
+	_, err = New(WithLazySource(" ", &mockSource{}))
+	s.ErrorContains(err, "lazy source prefix cannot be empty")
+}
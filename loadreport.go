@@ -0,0 +1,80 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"time"
+)
+
+// LoadReport describes the last load, for diagnosing slow startups caused by remote backends.
+type LoadReport struct {
+	Start    time.Time     // When the load started
+	Duration time.Duration // Time the whole load took, including merging and validation
+	Sources  []SourceLoad  // The sources, in order, up to the one that failed the load, if any
+	Err      error         // The error of the load, or nil if it succeeded
+}
+
+// SourceLoad describes how a source was loaded during a load.
+type SourceLoad struct {
+	Source   SourceInfo    // The source
+	Duration time.Duration // Time the source took to load
+	Size     int           // Size in bytes of the data read, or -1 if the source does not report it
+	Keys     int           // Number of leaf values the source contributed
+	Cached   bool          // Whether the result of the previous load was reused instead of loading the source
+	Err      error         // The error of the source, or nil; failures of optional sources do not fail the load
+}
+
+// LoadReport returns the report of the last Load or Reload, successful or not, including background reloads.
+// It lists every source that was loaded, with the time it took, the size of the data it read, the number of
+// keys it contributed and its error, if any. Sources whose result was reused by Reload are marked as cached.
+// Before the first load, the report is empty.
+func (c *Conflex) LoadReport() LoadReport {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	report := c.loadReport
+	report.Sources = make([]SourceLoad, len(c.loadReport.Sources))
+	for i, load := range c.loadReport.Sources {
+		if load.Source.Options != nil {
+			options := make(map[string]string, len(load.Source.Options))
+			for k, v := range load.Source.Options {
+				options[k] = v
+			}
+			load.Source.Options = options
+		}
+		report.Sources[i] = load
+	}
+	return report
+}
+
+// setLoadReport records report as the report of the last load.
+func (c *Conflex) setLoadReport(report *LoadReport) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.loadReport = *report
+}
+
+// recordSourceLoad adds the outcome of loading source i, whose result is result, to report if it is not nil.
+func (c *Conflex) recordSourceLoad(report *LoadReport, i int, load SourceLoad, result map[string]any) {
+	if report == nil {
+		return
+	}
+	load.Source = c.sourceInfo(i)
+	walkLeaves(result, func([]string, any) {
+		load.Keys++
+	})
+	report.Sources = append(report.Sources, load)
+}
@@ -0,0 +1,116 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.companyinfo.dev/conflex/codec"
+)
+
+type LoadReportTestSuite struct {
+	suite.Suite
+}
+
+func TestLoadReportTestSuite(t *testing.T) {
+	suite.Run(t, new(LoadReportTestSuite))
+}
+
+func (s *LoadReportTestSuite) TestLoad() {
+	path := filepath.Join(s.T().TempDir(), "config.json")
+	content := `{"server": {"host": "localhost", "port": 8080}}`
+	s.Require().NoError(os.WriteFile(path, []byte(content), 0o600))
+
+	c, err := New(
+		WithFileSource(path, codec.TypeJSON),
+		WithNamedSource("remote", &delayedSource{delay: 20 * time.Millisecond}),
+		WithOptionalSource(&mockSource{err: errors.New("connection refused")}),
+	)
+	s.Require().NoError(err)
+	s.Empty(c.LoadReport().Sources)
+
+	s.Require().NoError(c.Load(context.Background()))
+	report := c.LoadReport()
+	s.NoError(report.Err)
+	s.False(report.Start.IsZero())
+	s.Require().Len(report.Sources, 3)
+
+	file := report.Sources[0]
+	s.Equal("source[0]", file.Source.Name)
+	s.Equal(len(content), file.Size)
+	s.Equal(2, file.Keys)
+	s.False(file.Cached)
+	s.NoError(file.Err)
+
+	remote := report.Sources[1]
+	s.Equal("remote", remote.Source.Name)
+	s.GreaterOrEqual(remote.Duration, 20*time.Millisecond)
+	s.GreaterOrEqual(report.Duration, remote.Duration)
+	s.Equal(-1, remote.Size)
+	s.Zero(remote.Keys)
+
+	optional := report.Sources[2]
+	s.True(optional.Source.Optional)
+	s.ErrorContains(optional.Err, "connection refused")
+
+	// The report is a copy.
+	report.Sources[0].Source.Options["codec"] = "yaml"
+	s.Equal("json", c.LoadReport().Sources[0].Source.Options["codec"])
+}
+
+func (s *LoadReportTestSuite) TestReload() {
+	c, err := New(
+		WithNamedSource("local", &mockSource{conf: map[string]any{"foo": "bar"}}),
+		WithNamedSource("remote", &mockSource{conf: map[string]any{"baz": "qux", "nested": map[string]any{"a": 1}}}),
+	)
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+	s.Require().NoError(c.Reload(context.Background(), "local"))
+
+	report := c.LoadReport()
+	s.Require().Len(report.Sources, 2)
+	s.False(report.Sources[0].Cached)
+	s.True(report.Sources[1].Cached)
+	s.Equal(2, report.Sources[1].Keys)
+}
+
+func (s *LoadReportTestSuite) TestFailure() {
+	c, err := New(
+		WithSource(&mockSource{conf: map[string]any{"foo": "bar"}}),
+		WithSource(&mockSource{err: errors.New("connection refused")}),
+		WithSource(&mockSource{conf: map[string]any{"baz": "qux"}}),
+	)
+	s.Require().NoError(err)
+	s.Require().Error(c.Load(context.Background()))
+
+	report := c.LoadReport()
+	s.ErrorContains(report.Err, "connection refused")
+	s.Require().Len(report.Sources, 2)
+	s.NoError(report.Sources[0].Err)
+	s.ErrorContains(report.Sources[1].Err, "connection refused")
+}
+
+func (s *LoadReportTestSuite) TestValidateDoesNotReport() {
+	c, err := New(WithSource(&mockSource{conf: map[string]any{"foo": "bar"}}))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Validate(context.Background()))
+	s.Empty(c.LoadReport().Sources)
+}
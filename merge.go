@@ -0,0 +1,166 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrMergeConflict is returned by Load when sources disagree on whether a key is a map,
+// and the merge conflict policy is MergeConflictError.
+var ErrMergeConflict = errors.New("conflicting value types across sources")
+
+// MergeConflictPolicy determines how Load resolves a key that one source defines as a map
+// and a later source defines as a scalar or list, or vice versa.
+type MergeConflictPolicy int
+
+const (
+	// MergeConflictOverride lets the later source replace the value entirely, like any other override.
+	// This is the default.
+	MergeConflictOverride MergeConflictPolicy = iota
+	// MergeConflictError makes Load fail with an error wrapping ErrMergeConflict that lists the conflicting keys.
+	MergeConflictError
+	// MergeConflictPreferMap keeps the map and ignores a scalar or list that would replace it.
+	// A map from a later source still replaces a scalar or list.
+	MergeConflictPreferMap
+)
+
+// String returns the name of the policy.
+func (p MergeConflictPolicy) String() string {
+	switch p {
+	case MergeConflictOverride:
+		return "override"
+	case MergeConflictError:
+		return "error"
+	case MergeConflictPreferMap:
+		return "prefer-map"
+	default:
+		return fmt.Sprintf("MergeConflictPolicy(%d)", int(p))
+	}
+}
+
+// WithMergeConflictPolicy sets how Load resolves keys that are a map in one source and a scalar or list in another.
+func WithMergeConflictPolicy(policy MergeConflictPolicy) Option {
+	return func(c *Conflex) error {
+		switch policy {
+		case MergeConflictOverride, MergeConflictError, MergeConflictPreferMap:
+			c.mergeConflictPolicy = policy
+			return nil
+		default:
+			return fmt.Errorf("unknown merge conflict policy: %s", policy)
+		}
+	}
+}
+
+// mergeConflict describes a key whose value is a map on one side of a merge and not on the other.
+type mergeConflict struct {
+	key      string
+	existing any
+	incoming any
+}
+
+func (m mergeConflict) String() string {
+	return fmt.Sprintf("%q is %s in earlier sources but %s in this source",
+		m.key, describeType(m.existing), describeType(m.incoming))
+}
+
+// resolveMergeConflicts applies the merge conflict policy to src before it is merged into dst.
+// With MergeConflictPreferMap, src is modified in place so that it no longer replaces maps in dst.
+func (c *Conflex) resolveMergeConflicts(dst, src map[string]any) error {
+	if c.mergeConflictPolicy == MergeConflictOverride {
+		return nil
+	}
+
+	conflicts := findMergeConflicts(dst, src, "")
+	if len(conflicts) == 0 {
+		return nil
+	}
+
+	if c.mergeConflictPolicy == MergeConflictError {
+		descriptions := make([]string, len(conflicts))
+		for i, conflict := range conflicts {
+			descriptions[i] = conflict.String()
+		}
+		return fmt.Errorf("%w: %s", ErrMergeConflict, strings.Join(descriptions, "; "))
+	}
+
+	for _, conflict := range conflicts {
+		if _, ok := conflict.existing.(map[string]any); ok {
+			deleteKey(src, splitKey(conflict.key))
+		}
+	}
+	return nil
+}
+
+// findMergeConflicts returns the keys, in sorted order, that are a map in exactly one of dst and src.
+// Nil values never conflict.
+func findMergeConflicts(dst, src map[string]any, prefix string) []mergeConflict {
+	keys := make([]string, 0, len(src))
+	for key := range src {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var conflicts []mergeConflict
+	for _, key := range keys {
+		existing, ok := dst[key]
+		incoming := src[key]
+		if !ok || existing == nil || incoming == nil {
+			continue
+		}
+
+		path := escapeKeySegment(key)
+		if prefix != "" {
+			path = prefix + "." + path
+		}
+
+		existingMap, existingIsMap := existing.(map[string]any)
+		incomingMap, incomingIsMap := incoming.(map[string]any)
+		switch {
+		case existingIsMap && incomingIsMap:
+			conflicts = append(conflicts, findMergeConflicts(existingMap, incomingMap, path)...)
+		case existingIsMap != incomingIsMap:
+			conflicts = append(conflicts, mergeConflict{key: path, existing: existing, incoming: incoming})
+		}
+	}
+	return conflicts
+}
+
+// deleteKey removes the value at path from m.
+func deleteKey(m map[string]any, path []string) {
+	for len(path) > 1 {
+		next, ok := m[path[0]].(map[string]any)
+		if !ok {
+			return
+		}
+		m, path = next, path[1:]
+	}
+	delete(m, path[0])
+}
+
+// describeType returns a short, human-readable description of a configuration value's type.
+func describeType(v any) string {
+	switch v.(type) {
+	case map[string]any:
+		return "a map"
+	case []any:
+		return "a list"
+	default:
+		return fmt.Sprintf("a scalar (%T)", v)
+	}
+}
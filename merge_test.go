@@ -0,0 +1,117 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type MergeTestSuite struct {
+	suite.Suite
+}
+
+func TestMergeTestSuite(t *testing.T) {
+	suite.Run(t, new(MergeTestSuite))
+}
+
+func (s *MergeTestSuite) sources() []Option {
+	return []Option{
+		WithSource(&mockSource{conf: map[string]any{
+			"server": map[string]any{"host": "localhost", "port": 8080},
+			"tags":   map[string]any{"env": "dev"},
+		}}),
+		WithSource(&mockSource{conf: map[string]any{
+			"server":   "localhost:9090",
+			"database": map[string]any{"tags": []any{"a"}},
+		}}),
+		WithSource(&mockSource{conf: map[string]any{
+			"database": map[string]any{"tags": map[string]any{"primary": true}},
+		}}),
+	}
+}
+
+func (s *MergeTestSuite) TestOverride_Default() {
+	c, err := New(s.sources()...)
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+	s.Equal("localhost:9090", c.Get("server"))
+	s.Equal(true, c.Get("database.tags.primary"))
+}
+
+func (s *MergeTestSuite) TestError() {
+	c, err := New(append(s.sources(), WithMergeConflictPolicy(MergeConflictError))...)
+	s.Require().NoError(err)
+
+	err = c.Load(context.Background())
+	s.Require().Error(err)
+	s.True(errors.Is(err, ErrMergeConflict))
+
+	var configErr *ConfigError
+	s.Require().ErrorAs(err, &configErr)
+	s.Equal("source[1]", configErr.Source)
+	s.Equal("merge", configErr.Operation)
+	s.Contains(err.Error(), `"server" is a map in earlier sources but a scalar (string) in this source`)
+}
+
+func (s *MergeTestSuite) TestError_NestedConflict() {
+	c, err := New(
+		WithSource(&mockSource{conf: map[string]any{"database": map[string]any{"tags": []any{"a"}}}}),
+		WithSource(&mockSource{conf: map[string]any{"database": map[string]any{"tags": map[string]any{"primary": true}}}}),
+		WithMergeConflictPolicy(MergeConflictError),
+	)
+	s.Require().NoError(err)
+
+	err = c.Load(context.Background())
+	s.Require().Error(err)
+	s.Contains(err.Error(), `"database.tags" is a list in earlier sources but a map in this source`)
+}
+
+func (s *MergeTestSuite) TestPreferMap() {
+	c, err := New(append(s.sources(), WithMergeConflictPolicy(MergeConflictPreferMap))...)
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	s.Equal("localhost", c.GetString("server.host"))
+	s.Equal(8080, c.GetInt("server.port"))
+	// A later map still replaces a list.
+	s.Equal(true, c.Get("database.tags.primary"))
+	s.Equal("dev", c.GetString("tags.env"))
+}
+
+func (s *MergeTestSuite) TestNilValuesDoNotConflict() {
+	c, err := New(
+		WithSource(&mockSource{conf: map[string]any{"server": map[string]any{"port": 8080}}}),
+		WithSource(&mockSource{conf: map[string]any{"server": nil}}),
+		WithMergeConflictPolicy(MergeConflictError),
+	)
+	s.Require().NoError(err)
+	s.NoError(c.Load(context.Background()))
+}
+
+func (s *MergeTestSuite) TestWithMergeConflictPolicy_Unknown() {
+	_, err := New(WithMergeConflictPolicy(MergeConflictPolicy(42)))
+	s.Error(err)
+	s.Contains(err.Error(), "unknown merge conflict policy: MergeConflictPolicy(42)")
+}
+
+func (s *MergeTestSuite) TestMergeConflictPolicyString() {
+	s.Equal("override", MergeConflictOverride.String())
+	s.Equal("error", MergeConflictError.String())
+	s.Equal("prefer-map", MergeConflictPreferMap.String())
+}
@@ -0,0 +1,125 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"reflect"
+)
+
+// OriginDefault is the origin of values that come from a default in a struct tag.
+const OriginDefault = "default"
+
+// OriginEnvPrefix prefixes the origin of values set with BindEnv, followed by the name of the variable,
+// as in "env:DB_HOST".
+const OriginEnvPrefix = "env:"
+
+// Origin returns the name of the source that supplied the effective value of key, such as "source[1]" or a
+// name given with WithNamedSource. Values set with BindEnv have the origin "env:" followed by the variable
+// name, and values taken from a struct tag default have the origin OriginDefault. Values copied from a
+// deprecated key have the origin of that key.
+//
+// Origins are tracked for leaf values, so ok is false for keys holding a map, as well as for unknown keys.
+// The elements of a list share the origin of the list.
+func (c *Conflex) Origin(key string) (sourceName string, ok bool) {
+	if c == nil {
+		return "", false
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	normalized := c.normalizeKey(key)
+	if origin, ok := c.keyOrigins[normalized]; ok {
+		return origin, true
+	}
+
+	// Lookups only descend below a leaf into lists, so an existing key below a leaf is a list element.
+	if c.values == nil || c.lookup(*c.values, normalized) == nil {
+		return "", false
+	}
+	path := splitKey(normalized)
+	for len(path) > 1 {
+		path = path[:len(path)-1]
+		if origin, ok := c.keyOrigins[joinKey(path)]; ok {
+			return origin, true
+		}
+	}
+	return "", false
+}
+
+// valueOrigins returns the origin of every leaf value in values, the merged values before expansion,
+// references and secrets are resolved. envKeys maps the keys set by BindEnv to their variables.
+func (c *Conflex) valueOrigins(results []map[string]any, values map[string]any, envKeys map[string]string) map[string]string {
+	origins := make(map[string]string)
+	walkLeaves(values, func(path []string, value any) {
+		key := joinKey(path)
+		if origin, ok := c.valueOrigin(results, path, value, envKeys); ok {
+			origins[key] = origin
+		}
+	})
+	return origins
+}
+
+// valueOrigin returns the origin of the value at path. The last source holding an equal value at the same
+// path is taken to have supplied it: later sources that set the key to something else did not take effect,
+// for example because the merge conflict policy kept a map instead.
+func (c *Conflex) valueOrigin(results []map[string]any, path []string, value any, envKeys map[string]string) (string, bool) {
+	key := joinKey(path)
+	if name, ok := envKeys[key]; ok {
+		return OriginEnvPrefix + name, true
+	}
+	if origin, ok := c.sourceOrigin(results, path, value); ok {
+		return origin, true
+	}
+	for _, d := range c.deprecatedKeys {
+		if d.replacement == "" || c.normalizeKey(d.replacement) != key {
+			continue
+		}
+		if origin, ok := c.sourceOrigin(results, splitKey(c.normalizeKey(d.key)), value); ok {
+			return origin, true
+		}
+	}
+	for _, d := range c.defaults {
+		if c.normalizeKey(d.key) == key {
+			return OriginDefault, true
+		}
+	}
+	return "", false
+}
+
+// sourceOrigin returns the name of the last source among results that holds value at path.
+func (c *Conflex) sourceOrigin(results []map[string]any, path []string, value any) (string, bool) {
+	for i := len(results) - 1; i >= 0; i-- {
+		if v, ok := lookupPath(results[i], path); ok && reflect.DeepEqual(v, value) {
+			return c.sourceName(i), true
+		}
+	}
+	return "", false
+}
+
+// lookupPath returns the value at path in m, and whether m holds a value there, even a nil one.
+func lookupPath(m map[string]any, path []string) (any, bool) {
+	var current any = m
+	for _, segment := range path {
+		node, ok := current.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if current, ok = node[segment]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
@@ -0,0 +1,97 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type OriginTestSuite struct {
+	suite.Suite
+}
+
+func TestOriginTestSuite(t *testing.T) {
+	suite.Run(t, new(OriginTestSuite))
+}
+
+func (s *OriginTestSuite) TestOrigin() {
+	type Config struct {
+		Timeout string `conflex:"timeout,default=30s"`
+	}
+
+	s.T().Setenv("DB_HOST", "db.internal")
+	c, err := New(
+		WithSource(&mockSource{conf: map[string]any{
+			"server":  map[string]any{"host": "localhost", "port": 8080},
+			"servers": []any{map[string]any{"host": "a"}},
+			"db":      map[string]any{"host": "localhost"},
+		}}),
+		WithNamedSource("overrides", &mockSource{conf: map[string]any{
+			"server": map[string]any{"port": 9090},
+		}}),
+		WithBinding(&Config{}),
+	)
+	s.Require().NoError(err)
+	s.Require().NoError(c.BindEnv("db.host", "DB_HOST"))
+
+	_, ok := c.Origin("server.port")
+	s.False(ok)
+
+	s.Require().NoError(c.Load(context.Background()))
+
+	origin, ok := c.Origin("Server.Port")
+	s.True(ok)
+	s.Equal("overrides", origin)
+
+	origin, _ = c.Origin("server.host")
+	s.Equal("source[0]", origin)
+
+	origin, _ = c.Origin("servers.0.host")
+	s.Equal("source[0]", origin)
+
+	origin, _ = c.Origin("db.host")
+	s.Equal("env:DB_HOST", origin)
+
+	origin, _ = c.Origin("timeout")
+	s.Equal(OriginDefault, origin)
+
+	_, ok = c.Origin("server")
+	s.False(ok)
+	_, ok = c.Origin("missing")
+	s.False(ok)
+	_, ok = c.Origin("server.port.extra")
+	s.False(ok)
+
+	var nilConflex *Conflex
+	_, ok = nilConflex.Origin("server.port")
+	s.False(ok)
+}
+
+func (s *OriginTestSuite) TestOriginOfDeprecatedKey() {
+	c, err := New(
+		WithSource(&mockSource{conf: map[string]any{"server": map[string]any{"port": 8080}}}),
+		WithNamedSource("legacy", &mockSource{conf: map[string]any{"server_host": "legacy.internal"}}),
+		WithDeprecatedKey("server_host", "server.host", ""),
+	)
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	origin, ok := c.Origin("server.host")
+	s.True(ok)
+	s.Equal("legacy", origin)
+}
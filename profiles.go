@@ -0,0 +1,94 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.companyinfo.dev/conflex/codec"
+	"go.companyinfo.dev/conflex/source"
+)
+
+// ProfilesEnvVar is the environment variable that selects the profiles of WithProfiles, as a comma-separated
+// list such as "production" or "production,eu". When it is set, it replaces the profiles passed in code.
+const ProfilesEnvVar = "CONFLEX_PROFILES"
+
+// WithProfiles adds the file at path followed by one overlay file per profile, for the usual split between
+// shared settings and per-environment ones. Overlays are named after the file with the profile inserted before
+// the extension, so with path "config.yaml" the profile "production" reads "config.production.yaml".
+// Later profiles take precedence over earlier ones, and all of them over the base file.
+//
+// The profiles passed in code are defaults: if ProfilesEnvVar is set, the profiles are read from it instead,
+// so the same binary selects its environment at deploy time. Every file is required, so a misspelled profile
+// fails the load rather than being ignored. Profiles returns the selected profiles.
+func WithProfiles(path string, codecType codec.Type, profiles ...string) Option {
+	return func(c *Conflex) error {
+		if path == "" {
+			return errors.New("path cannot be empty")
+		}
+		decoder, err := codec.GetDecoder(codecType)
+		if err != nil {
+			return NewConfigError("profiles", "get-decoder", err)
+		}
+
+		if env := os.Getenv(ProfilesEnvVar); env != "" {
+			profiles = nil
+			for _, profile := range strings.Split(env, ",") {
+				if profile = strings.TrimSpace(profile); profile != "" {
+					profiles = append(profiles, profile)
+				}
+			}
+		}
+		for _, profile := range profiles {
+			if profile == "" || strings.ContainsAny(profile, `/\`) {
+				return fmt.Errorf("invalid profile name %q", profile)
+			}
+		}
+
+		c.addSource(source.NewFile(path, decoder), SourceInfo{
+			Type:    "file",
+			Target:  path,
+			Options: map[string]string{"codec": string(codecType)},
+		})
+		for _, profile := range profiles {
+			overlay := profilePath(path, profile)
+			c.addSource(source.NewFile(overlay, decoder), SourceInfo{
+				Type:    "file",
+				Target:  overlay,
+				Options: map[string]string{"codec": string(codecType), "profile": profile},
+			})
+		}
+		c.profiles = append(c.profiles, profiles...)
+		return nil
+	}
+}
+
+// profilePath returns the path of the overlay for profile, such as "config.production.yaml" for "config.yaml".
+func profilePath(path, profile string) string {
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "." + profile + ext
+}
+
+// Profiles returns the profiles selected by WithProfiles, in the order their overlays are merged.
+func (c *Conflex) Profiles() []string {
+	if c == nil {
+		return nil
+	}
+	return append([]string(nil), c.profiles...)
+}
@@ -0,0 +1,107 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.companyinfo.dev/conflex/codec"
+)
+
+type ProfilesTestSuite struct {
+	suite.Suite
+	dir string
+}
+
+func TestProfilesTestSuite(t *testing.T) {
+	suite.Run(t, new(ProfilesTestSuite))
+}
+
+func (s *ProfilesTestSuite) SetupTest() {
+	s.dir = s.T().TempDir()
+	s.write("config.yaml", "host: localhost\nport: 8080\nlog:\n  level: debug\n")
+	s.write("config.production.yaml", "host: prod.internal\nlog:\n  level: info\n")
+	s.write("config.eu.yaml", "region: eu-west-1\nlog:\n  level: warn\n")
+}
+
+func (s *ProfilesTestSuite) write(name, content string) {
+	s.Require().NoError(os.WriteFile(filepath.Join(s.dir, name), []byte(content), 0o600))
+}
+
+func (s *ProfilesTestSuite) TestOverlays() {
+	path := filepath.Join(s.dir, "config.yaml")
+	c, err := NewStrict(WithProfiles(path, codec.TypeYAML, "production", "eu"))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	s.Equal("prod.internal", c.GetString("host"))
+	s.Equal(8080, c.GetInt("port"))
+	s.Equal("eu-west-1", c.GetString("region"))
+	s.Equal("warn", c.GetString("log.level"))
+	s.Equal([]string{"production", "eu"}, c.Profiles())
+
+	sources := c.Sources()
+	s.Require().Len(sources, 3)
+	s.Equal(path, sources[0].Target)
+	s.Equal(filepath.Join(s.dir, "config.production.yaml"), sources[1].Target)
+	s.Equal("production", sources[1].Options["profile"])
+}
+
+func (s *ProfilesTestSuite) TestBaseOnly() {
+	c, err := NewStrict(WithProfiles(filepath.Join(s.dir, "config.yaml"), codec.TypeYAML))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	s.Equal("localhost", c.GetString("host"))
+	s.Empty(c.Profiles())
+}
+
+func (s *ProfilesTestSuite) TestEnvVarSelectsProfiles() {
+	s.T().Setenv(ProfilesEnvVar, " eu , ")
+	c, err := NewStrict(WithProfiles(filepath.Join(s.dir, "config.yaml"), codec.TypeYAML, "production"))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	s.Equal([]string{"eu"}, c.Profiles())
+	s.Equal("localhost", c.GetString("host"))
+	s.Equal("eu-west-1", c.GetString("region"))
+}
+
+func (s *ProfilesTestSuite) TestMissingOverlayFailsLoad() {
+	c, err := NewStrict(WithProfiles(filepath.Join(s.dir, "config.yaml"), codec.TypeYAML, "staging"))
+	s.Require().NoError(err)
+	s.ErrorContains(c.Load(context.Background()), "source[1]")
+}
+
+func (s *ProfilesTestSuite) TestErrors() {
+	_, err := NewStrict(WithProfiles("", codec.TypeYAML))
+	s.ErrorContains(err, "path cannot be empty")
+
+	_, err = NewStrict(WithProfiles("config.yaml", "ini"))
+	s.ErrorContains(err, "get-decoder")
+
+	_, err = NewStrict(WithProfiles("config.yaml", codec.TypeYAML, "../prod"))
+	s.ErrorContains(err, `invalid profile name "../prod"`)
+}
+
+func (s *ProfilesTestSuite) TestProfilePath() {
+	s.Equal("config.production.yaml", profilePath("config.yaml", "production"))
+	s.Equal("/etc/app/config.dev", profilePath("/etc/app/config", "dev"))
+	s.Equal("conf.d/app.eu.json", profilePath("conf.d/app.json", "eu"))
+}
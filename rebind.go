@@ -0,0 +1,59 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+// OnRebind registers fn to be called after each successful Load or Reload with the configuration
+// decoded into a fresh *T. Every call gets its own value, so consumers never observe a struct that is
+// being updated: they can swap the pointer into place (for example with atomic.Pointer) and keep using
+// the previous one until then.
+//
+// T is decoded with the same settings as WithBinding and does not have to be the binding type.
+// If T implements Validator, the decoded value is validated first. When decoding or validation fails,
+// the error is logged and fn is not called; the loaded configuration stays in effect.
+func OnRebind[T any](c *Conflex, fn func(cfg *T)) {
+	if c == nil || fn == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.rebindHandlers = append(c.rebindHandlers, func(values map[string]any) error {
+		cfg := new(T)
+		if err := c.decode(values, cfg); err != nil {
+			return err
+		}
+		if v, ok := any(cfg).(Validator); ok {
+			if err := v.Validate(); err != nil {
+				return err
+			}
+		}
+		fn(cfg)
+		return nil
+	})
+}
+
+// notifyRebind calls the registered rebind handlers with the newly loaded values.
+func (c *Conflex) notifyRebind(values map[string]any) {
+	c.mu.RLock()
+	handlers := c.rebindHandlers
+	c.mu.RUnlock()
+
+	for i, fn := range handlers {
+		if err := fn(values); err != nil {
+			c.log().Error("configuration rebind failed", "handler", i, "error", err)
+		}
+	}
+}
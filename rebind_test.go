@@ -0,0 +1,114 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type rebindConfig struct {
+	Name string `conflex:"name"`
+	Port int    `conflex:"port"`
+}
+
+type validatedRebindConfig struct {
+	Port int `conflex:"port"`
+}
+
+func (c *validatedRebindConfig) Validate() error {
+	if c.Port <= 0 {
+		return errors.New("port must be positive")
+	}
+	return nil
+}
+
+type RebindTestSuite struct {
+	suite.Suite
+}
+
+func TestRebindTestSuite(t *testing.T) {
+	suite.Run(t, new(RebindTestSuite))
+}
+
+func (s *RebindTestSuite) TestOnRebind() {
+	src := &mockSyncSource{conf: map[string]any{"name": "app", "port": 8080}}
+	c, err := New(WithSource(src))
+	s.Require().NoError(err)
+
+	var got []*rebindConfig
+	OnRebind(c, func(cfg *rebindConfig) {
+		got = append(got, cfg)
+	})
+
+	s.Require().NoError(c.Load(context.Background()))
+	src.set(map[string]any{"name": "app", "port": 9090}, nil)
+	s.Require().NoError(c.Load(context.Background()))
+
+	s.Require().Len(got, 2)
+	s.Equal(&rebindConfig{Name: "app", Port: 8080}, got[0])
+	s.Equal(&rebindConfig{Name: "app", Port: 9090}, got[1])
+	s.NotSame(got[0], got[1])
+}
+
+func (s *RebindTestSuite) TestOnRebindNotCalledOnFailedLoad() {
+	src := &mockSyncSource{conf: map[string]any{"port": 8080}}
+	c, err := New(WithSource(src))
+	s.Require().NoError(err)
+
+	calls := 0
+	OnRebind(c, func(*rebindConfig) { calls++ })
+
+	s.Require().NoError(c.Load(context.Background()))
+	src.set(nil, errors.New("unavailable"))
+	s.Require().Error(c.Load(context.Background()))
+
+	s.Equal(1, calls)
+}
+
+func (s *RebindTestSuite) TestOnRebindValidation() {
+	src := &mockSyncSource{conf: map[string]any{"port": 0}}
+	logs := &bytes.Buffer{}
+	c, err := New(WithSource(src), WithLogger(slog.New(slog.NewTextHandler(logs, nil))))
+	s.Require().NoError(err)
+
+	var got *validatedRebindConfig
+	OnRebind(c, func(cfg *validatedRebindConfig) { got = cfg })
+
+	s.Require().NoError(c.Load(context.Background()))
+	s.Nil(got)
+	s.Contains(logs.String(), "configuration rebind failed")
+	s.Contains(logs.String(), "port must be positive")
+
+	src.set(map[string]any{"port": 8080}, nil)
+	s.Require().NoError(c.Load(context.Background()))
+	s.Equal(&validatedRebindConfig{Port: 8080}, got)
+}
+
+func (s *RebindTestSuite) TestOnRebindNil() {
+	s.NotPanics(func() {
+		OnRebind[rebindConfig](nil, func(*rebindConfig) {})
+	})
+
+	c, err := New()
+	s.Require().NoError(err)
+	OnRebind[rebindConfig](c, nil)
+	s.Empty(c.rebindHandlers)
+}
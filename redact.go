@@ -0,0 +1,137 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"reflect"
+	"strconv"
+)
+
+// WithRedactedKeys replaces the values of the keys matching the given patterns with RedactedValue when the
+// configuration is dumped, so secrets are never written to disk in plaintext. Keys classified as SensitivitySecret
+// by other means, such as WithSensitivity or the "sensitivity=secret" tag option, are redacted in dumps as well:
+//
+//	conflex.WithRedactedKeys("auth.jwt.secret", "database.*.password")
+//
+// Patterns use the syntax of WithSensitivity: a "*" segment matches any single key segment, and a pattern also covers
+// everything nested below the keys it matches. The keys are classified as SensitivitySecret as well, so their values
+// are redacted in diffs and in the configuration explorer too.
+// Fields of the bound struct marked with the "secret" tag option, e.g. `conflex:"password,secret"`, are redacted
+// without being listed here.
+func WithRedactedKeys(patterns ...string) Option {
+	return func(c *Conflex) error {
+		for _, pattern := range patterns {
+			if pattern == "" {
+				return errors.New("redacted key pattern cannot be empty")
+			}
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("invalid redacted key pattern %q: %w", pattern, err)
+			}
+			c.redactedKeys = append(c.redactedKeys, pattern)
+			c.sensitivityRules = append(c.sensitivityRules, sensitivityRule{pattern: pattern, level: SensitivitySecret})
+		}
+		return nil
+	}
+}
+
+// secretKeysFromTags returns the key paths of the fields of the struct type t marked with the "secret" tag option.
+func secretKeysFromTags(t reflect.Type, prefix string) []string {
+	var keys []string
+	walkFields(t, prefix, func(path string, _ reflect.StructField, tag fieldTag) {
+		if tag.has("secret") {
+			keys = append(keys, path)
+		}
+	})
+	return keys
+}
+
+// isRedacted reports whether the value of key must be redacted when the configuration is dumped: it matches one of
+// the patterns of WithRedactedKeys or is classified as SensitivitySecret.
+func (c *Conflex) isRedacted(key string) bool {
+	for _, pattern := range c.redactedKeys {
+		if matchKeyPattern(c.normalizeKey(pattern), key) {
+			return true
+		}
+	}
+	return c.Sensitivity(key) == SensitivitySecret
+}
+
+// hasSecrets reports whether any key may be redacted, so dumps can skip the redaction walk otherwise.
+func (c *Conflex) hasSecrets() bool {
+	if len(c.redactedKeys) > 0 {
+		return true
+	}
+	for _, rule := range c.sensitivityRules {
+		if rule.level == SensitivitySecret {
+			return true
+		}
+	}
+	return false
+}
+
+// SafeValues returns a deep copy of the current configuration in which the values of secret keys, those classified
+// as SensitivitySecret by WithSensitivity, WithRedactedKeys or the "secret" and "sensitivity" tag options, are
+// replaced with RedactedValue. The result is safe to include in support bundles, crash reports and logs.
+// It returns an empty map before the configuration is loaded.
+func (c *Conflex) SafeValues() map[string]any {
+	if c == nil {
+		return map[string]any{}
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.values == nil {
+		return map[string]any{}
+	}
+	return redactValues(*c.values, func(key string) bool {
+		return c.Sensitivity(key) == SensitivitySecret
+	})
+}
+
+// redactValues returns a deep copy of values in which the values of the keys for which redacted reports true are
+// replaced with RedactedValue. Keys of list elements are suffixed with their index, e.g. "servers.0.password".
+func redactValues(values map[string]any, redacted func(key string) bool) map[string]any {
+	var redact func(key string, v any) any
+	redact = func(key string, v any) any {
+		if key != "" && redacted(key) {
+			return RedactedValue
+		}
+		switch val := v.(type) {
+		case map[string]any:
+			out := make(map[string]any, len(val))
+			for k, item := range val {
+				itemKey := escapeKeySegment(k)
+				if key != "" {
+					itemKey = key + "." + itemKey
+				}
+				out[k] = redact(itemKey, item)
+			}
+			return out
+		case []any:
+			out := make([]any, len(val))
+			for i, item := range val {
+				out[i] = redact(key+"."+strconv.Itoa(i), item)
+			}
+			return out
+		}
+		return v
+	}
+	out, _ := redact("", values).(map[string]any)
+	return out
+}
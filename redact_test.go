@@ -0,0 +1,161 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type RedactTestSuite struct {
+	suite.Suite
+}
+
+func TestRedactTestSuite(t *testing.T) {
+	suite.Run(t, new(RedactTestSuite))
+}
+
+func (s *RedactTestSuite) TestDump() {
+	dumper := &mockDumper{}
+	c, err := New(
+		WithSource(&mockSource{conf: map[string]any{
+			"auth": map[string]any{"jwt": map[string]any{"secret": "s3cret", "ttl": "1h"}},
+			"database": map[string]any{
+				"primary": map[string]any{"host": "db1", "password": "p1"},
+				"replica": map[string]any{"host": "db2", "password": "p2"},
+			},
+			"servers": []any{map[string]any{"password": "p3"}},
+		}}),
+		WithDumper(dumper),
+		WithRedactedKeys("Auth.JWT.Secret", "database.*.password", "servers.*.password"),
+	)
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+	s.Require().NoError(c.Dump(context.Background()))
+
+	dumped := *dumper.values
+	s.Equal(map[string]any{"secret": RedactedValue, "ttl": "1h"}, dumped["auth"].(map[string]any)["jwt"])
+	s.Equal(map[string]any{
+		"primary": map[string]any{"host": "db1", "password": RedactedValue},
+		"replica": map[string]any{"host": "db2", "password": RedactedValue},
+	}, dumped["database"])
+	s.Equal([]any{map[string]any{"password": RedactedValue}}, dumped["servers"])
+
+	s.Equal("s3cret", c.GetString("auth.jwt.secret"), "the values are not modified")
+	s.Equal(SensitivitySecret, c.Sensitivity("database.replica.password"))
+}
+
+func (s *RedactTestSuite) TestRedactsSections() {
+	dumper := &mockDumper{}
+	c, err := New(
+		WithSource(&mockSource{conf: map[string]any{"credentials": map[string]any{"user": "u", "password": "p"}}}),
+		WithDumper(dumper),
+		WithRedactedKeys("credentials"),
+	)
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+	s.Require().NoError(c.Dump(context.Background()))
+
+	s.Equal(map[string]any{"credentials": RedactedValue}, *dumper.values)
+}
+
+func (s *RedactTestSuite) TestSecretTag() {
+	var cfg struct {
+		Database struct {
+			User     string `conflex:"user"`
+			Password string `conflex:"password,secret"`
+		} `conflex:"database"`
+	}
+	dumper := &mockDumper{}
+	c, err := New(
+		WithSource(&mockSource{conf: map[string]any{"database": map[string]any{"user": "app", "password": "p"}}}),
+		WithDumper(dumper),
+		WithBinding(&cfg),
+	)
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+	s.Require().NoError(c.Dump(context.Background()))
+
+	s.Equal(map[string]any{"database": map[string]any{"user": "app", "password": RedactedValue}}, *dumper.values)
+	s.Equal("p", cfg.Database.Password)
+}
+
+func (s *RedactTestSuite) TestDumpSecretSensitivity() {
+	var cfg struct {
+		APIKey string `conflex:"api_key,sensitivity=secret"`
+	}
+	dumper := &mockDumper{}
+	c, err := New(
+		WithSource(&mockSource{conf: map[string]any{
+			"api_key": "k",
+			"oauth":   map[string]any{"client_id": "id", "client_secret": "cs"},
+			"region":  "eu",
+		}}),
+		WithDumper(dumper),
+		WithBinding(&cfg),
+		WithSensitivity(SensitivitySecret, "oauth.client_secret"),
+		WithSensitivity(SensitivityInternal, "region"),
+	)
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+	s.Require().NoError(c.Dump(context.Background()))
+
+	s.Equal(map[string]any{
+		"api_key": RedactedValue,
+		"oauth":   map[string]any{"client_id": "id", "client_secret": RedactedValue},
+		"region":  "eu",
+	}, *dumper.values)
+}
+
+func (s *RedactTestSuite) TestSafeValues() {
+	var cfg struct {
+		Token string `conflex:"token,secret"`
+	}
+	c, err := New(
+		WithSource(&mockSource{conf: map[string]any{
+			"token":    "t0ken",
+			"database": map[string]any{"host": "db", "password": "p"},
+			"servers":  []any{map[string]any{"name": "a", "key": "k"}},
+		}}),
+		WithBinding(&cfg),
+		WithSensitivity(SensitivitySecret, "database.password", "servers.*.key"),
+		WithSensitivity(SensitivityInternal, "database.host"),
+	)
+	s.Require().NoError(err)
+	s.Equal(map[string]any{}, c.SafeValues())
+	s.Require().NoError(c.Load(context.Background()))
+
+	safe := c.SafeValues()
+	s.Equal(map[string]any{
+		"token":    RedactedValue,
+		"database": map[string]any{"host": "db", "password": RedactedValue},
+		"servers":  []any{map[string]any{"name": "a", "key": RedactedValue}},
+	}, safe)
+
+	safe["database"].(map[string]any)["host"] = "changed"
+	s.Equal("db", c.GetString("database.host"), "the result is a deep copy")
+
+	var nilConflex *Conflex
+	s.Equal(map[string]any{}, nilConflex.SafeValues())
+}
+
+func (s *RedactTestSuite) TestInvalidPatterns() {
+	_, err := NewStrict(WithRedactedKeys(""))
+	s.Error(err)
+	_, err = NewStrict(WithRedactedKeys("database.[.password"))
+	s.Error(err)
+}
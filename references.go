@@ -0,0 +1,169 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cast"
+)
+
+// refPrefix starts a reference to another key, as in ${ref:server.host}.
+const refPrefix = "ref:"
+
+// ErrReferenceCycle is returned by Load when keys reference each other in a cycle.
+var ErrReferenceCycle = errors.New("reference cycle")
+
+// WithKeyReferences resolves references to other keys of the merged configuration in string values,
+// so a value can be defined once and reused:
+//
+//	server:
+//	  host: api.internal
+//	client:
+//	  url: https://${ref:server.host}:8443
+//
+// A string that consists of a single reference is replaced with the referenced value, keeping its type,
+// so ${ref:server.port} yields a number and ${ref:server} a copy of the whole section. References embedded
+// in a longer string are replaced with the referenced value formatted as a string. References are resolved
+// recursively after environment variable expansion (see WithEnvExpansion) and before validation.
+// Load fails with an error if a referenced key does not exist, or wrapping ErrReferenceCycle if keys
+// reference each other in a cycle.
+func WithKeyReferences() Option {
+	return func(c *Conflex) error {
+		c.keyReferences = true
+		return nil
+	}
+}
+
+// refResolver resolves the key references of a configuration.
+type refResolver struct {
+	c        *Conflex
+	values   map[string]any
+	resolved map[string]any // Resolved values by key
+	stack    []string       // Keys being resolved, to detect cycles
+}
+
+// resolveReferences returns values with all key references resolved.
+func (c *Conflex) resolveReferences(values map[string]any) (map[string]any, error) {
+	r := &refResolver{c: c, values: values, resolved: make(map[string]any)}
+	out := make(map[string]any, len(values))
+	for key := range values {
+		v, err := r.resolveKey(escapeKeySegment(key))
+		if err != nil {
+			return nil, err
+		}
+		out[key] = v
+	}
+	return out, nil
+}
+
+// resolveKey returns the value of key with its references resolved.
+func (r *refResolver) resolveKey(key string) (any, error) {
+	if v, ok := r.resolved[key]; ok {
+		return v, nil
+	}
+	for i, k := range r.stack {
+		if k == key {
+			cycle := append(append([]string(nil), r.stack[i:]...), key)
+			return nil, fmt.Errorf("%w: %s", ErrReferenceCycle, strings.Join(cycle, " -> "))
+		}
+	}
+
+	r.stack = append(r.stack, key)
+	v, err := r.resolveValue(key, r.c.lookup(r.values, key))
+	r.stack = r.stack[:len(r.stack)-1]
+	if err != nil {
+		return nil, err
+	}
+	r.resolved[key] = v
+	return v, nil
+}
+
+// resolveValue returns a copy of v, the value of key, with its references resolved.
+func (r *refResolver) resolveValue(key string, v any) (any, error) {
+	switch val := v.(type) {
+	case string:
+		return r.resolveString(key, val)
+	case map[string]any:
+		out := make(map[string]any, len(val))
+		for k := range val {
+			item, err := r.resolveKey(key + "." + escapeKeySegment(k))
+			if err != nil {
+				return nil, err
+			}
+			out[k] = item
+		}
+		return out, nil
+	case []any:
+		out := make([]any, len(val))
+		for i, item := range val {
+			resolved, err := r.resolveValue(key, item)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = resolved
+		}
+		return out, nil
+	}
+	return v, nil
+}
+
+// resolveString resolves the references in s, a string in the value of key.
+func (r *refResolver) resolveString(key, s string) (any, error) {
+	if strings.HasPrefix(s, "${"+refPrefix) && placeholderEnd(s) == len(s)-1 {
+		return r.resolveRef(key, s[len("${"+refPrefix):len(s)-1])
+	}
+
+	var err error
+	out := expandPlaceholders(s, func(expr string) (string, bool) {
+		ref, ok := strings.CutPrefix(expr, refPrefix)
+		if !ok || err != nil {
+			return "", false
+		}
+		var v any
+		if v, err = r.resolveRef(key, ref); err != nil {
+			return "", false
+		}
+		var str string
+		if str, err = cast.ToStringE(v); err != nil {
+			err = NewConfigFieldError("references", key, "resolve", fmt.Errorf("cannot interpolate %q: %w", ref, err))
+		}
+		return str, true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// resolveRef returns the resolved value of the key referenced from the value of key.
+func (r *refResolver) resolveRef(key, ref string) (any, error) {
+	ref = r.c.normalizeKey(strings.TrimSpace(ref))
+	if r.c.lookup(r.values, ref) == nil {
+		return nil, NewConfigFieldError("references", key, "resolve", fmt.Errorf("reference to unknown key %q", ref))
+	}
+	v, err := r.resolveKey(ref)
+	if err != nil {
+		var cfgErr *ConfigError
+		if !errors.As(err, &cfgErr) {
+			err = NewConfigFieldError("references", key, "resolve", err)
+		}
+		return nil, err
+	}
+	// Copy the value, so keys referencing the same section do not share it.
+	return copyValue(v), nil
+}
@@ -0,0 +1,140 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.companyinfo.dev/conflex/codec"
+)
+
+type ReferencesTestSuite struct {
+	suite.Suite
+}
+
+func TestReferencesTestSuite(t *testing.T) {
+	suite.Run(t, new(ReferencesTestSuite))
+}
+
+func (s *ReferencesTestSuite) load(yaml string, options ...Option) (*Conflex, error) {
+	options = append([]Option{WithContentSource([]byte(yaml), codec.TypeYAML), WithKeyReferences()}, options...)
+	c, err := New(options...)
+	s.Require().NoError(err)
+	return c, c.Load(context.Background())
+}
+
+func (s *ReferencesTestSuite) TestResolves() {
+	c, err := s.load(`
+server:
+  host: api.internal
+  port: 8443
+client:
+  url: https://${ref:server.host}:${ref:server.port}/v1
+  port: ${ref:server.port}
+  server: ${ref:server}
+  hosts:
+    - ${ref:client.fallback}
+  fallback: ${ref:server.host}
+`)
+	s.Require().NoError(err)
+
+	s.Equal("https://api.internal:8443/v1", c.GetString("client.url"))
+	s.Equal(c.Get("server.port"), c.Get("client.port"), "a single reference keeps the type of the value")
+	s.Equal("api.internal", c.GetString("client.server.host"))
+	s.Equal([]string{"api.internal"}, c.GetStringSlice("client.hosts"))
+	s.Equal("api.internal", c.GetString("client.fallback"))
+}
+
+func (s *ReferencesTestSuite) TestDottedKeys() {
+	c, err := s.load(`
+labels:
+  app.kubernetes.io/name: web
+  app.kubernetes.io/instance: ${ref:labels.app\.kubernetes\.io/name}-1
+top.level: ${ref:labels}
+`)
+	s.Require().NoError(err)
+
+	labels := c.GetStringMap("labels")
+	s.Equal("web", labels["app.kubernetes.io/name"])
+	s.Equal("web-1", labels["app.kubernetes.io/instance"])
+	s.Equal("web", c.GetString(`top\.level.app\.kubernetes\.io/name`))
+}
+
+func (s *ReferencesTestSuite) TestDoesNotShareSections() {
+	c, err := s.load(`
+defaults:
+  timeout: 5s
+a: ${ref:defaults}
+b: ${ref:defaults}
+`)
+	s.Require().NoError(err)
+
+	a := c.GetStringMap("a")
+	a["timeout"] = "1s"
+	s.Equal("5s", c.GetString("b.timeout"))
+}
+
+func (s *ReferencesTestSuite) TestUnknownKey() {
+	_, err := s.load(`url: https://${ref:server.host}`)
+	s.Require().Error(err)
+	s.Contains(err.Error(), `config error in references.url during resolve: reference to unknown key "server.host"`)
+}
+
+func (s *ReferencesTestSuite) TestCycle() {
+	_, err := s.load(`
+a: ${ref:b}
+b: x-${ref:c}
+c: ${ref:a}
+`)
+	s.Require().ErrorIs(err, ErrReferenceCycle)
+
+	_, err = s.load(`
+server:
+  url: ${ref:server}
+`)
+	s.Require().ErrorIs(err, ErrReferenceCycle)
+	s.Contains(err.Error(), "server -> server.url -> server")
+}
+
+func (s *ReferencesTestSuite) TestCannotInterpolateSection() {
+	_, err := s.load(`
+server:
+  host: api.internal
+url: https://${ref:server}
+`)
+	s.Require().Error(err)
+	s.Contains(err.Error(), `cannot interpolate "server"`)
+}
+
+func (s *ReferencesTestSuite) TestAfterEnvExpansion() {
+	s.T().Setenv("CONFLEX_TEST_HOST", "db.internal")
+
+	c, err := s.load(`
+database:
+  host: ${CONFLEX_TEST_HOST}
+replica: ${ref:database.host}
+`, WithEnvExpansion())
+	s.Require().NoError(err)
+	s.Equal("db.internal", c.GetString("replica"))
+}
+
+func (s *ReferencesTestSuite) TestDisabledByDefault() {
+	c, err := New(WithSource(&mockSource{conf: map[string]any{"host": "a", "url": "${ref:host}"}}))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+	s.Equal("${ref:host}", c.GetString("url"))
+}
@@ -0,0 +1,96 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"context"
+	"time"
+)
+
+// ReloadStatus reports the outcome of the configuration loads performed so far.
+// It is intended for health checks and metrics.
+type ReloadStatus struct {
+	LastAttempt time.Time // When Load was last called
+	LastSuccess time.Time // When Load last succeeded
+	LastError   error     // The error of the last Load, or nil if it succeeded
+	Successes   uint64    // Number of successful loads
+	Failures    uint64    // Number of failed loads
+}
+
+// Healthy reports whether the last load succeeded.
+// An instance that was never loaded is not healthy.
+func (s ReloadStatus) Healthy() bool {
+	return !s.LastSuccess.IsZero() && s.LastError == nil
+}
+
+// OnReloadError registers a handler that is called when a background reload fails.
+// Background reloads are those triggered by Watch, StartWatch, ReloadOnSignal, WithAutoReload and the refresh of
+// leased secrets (see LeasedSecretProvider); a failed reload never replaces the current configuration, so the
+// previous configuration keeps being served.
+// Handlers are called synchronously from the goroutine performing the reload.
+func (c *Conflex) OnReloadError(fn func(err error)) {
+	if fn == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.reloadErrorHandlers = append(c.reloadErrorHandlers, fn)
+}
+
+// ReloadStatus returns the outcome of the configuration loads performed so far.
+func (c *Conflex) ReloadStatus() ReloadStatus {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.reloadStatus
+}
+
+// recordLoad updates the reload status with the outcome of a load.
+func (c *Conflex) recordLoad(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	c.reloadStatus.LastAttempt = now
+	c.reloadStatus.LastError = err
+	if err != nil {
+		c.reloadStatus.Failures++
+		return
+	}
+	c.reloadStatus.LastSuccess = now
+	c.reloadStatus.Successes++
+}
+
+// reload runs Load on behalf of a background reloader and reports failures to the reload error handlers.
+func (c *Conflex) reload(ctx context.Context) error {
+	err := c.Load(ctx)
+	if err != nil {
+		c.reportReloadError(err)
+	}
+	return err
+}
+
+// reportReloadError calls the reload error handlers with the error of a failed background reload.
+func (c *Conflex) reportReloadError(err error) {
+	c.mu.RLock()
+	handlers := c.reloadErrorHandlers
+	c.mu.RUnlock()
+
+	for _, fn := range handlers {
+		fn(err)
+	}
+}
@@ -0,0 +1,218 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// countingSource is a source that counts how often it is loaded.
+type countingSource struct {
+	mockSyncSource
+	loads atomic.Int32
+}
+
+func (m *countingSource) Load(ctx context.Context) (map[string]any, error) {
+	m.loads.Add(1)
+	return m.mockSyncSource.Load(ctx)
+}
+
+type ReloadTestSuite struct {
+	suite.Suite
+}
+
+func TestReloadTestSuite(t *testing.T) {
+	suite.Run(t, new(ReloadTestSuite))
+}
+
+func (s *ReloadTestSuite) TestReloadStatus() {
+	src := &mockSyncSource{conf: map[string]any{"foo": "bar"}}
+	c, err := New(WithSource(src))
+	s.Require().NoError(err)
+	s.False(c.ReloadStatus().Healthy())
+
+	s.Require().NoError(c.Load(context.Background()))
+	status := c.ReloadStatus()
+	s.True(status.Healthy())
+	s.Equal(uint64(1), status.Successes)
+	s.Zero(status.Failures)
+	s.Equal(status.LastAttempt, status.LastSuccess)
+
+	src.set(nil, errors.New("source unavailable"))
+	s.Error(c.Load(context.Background()))
+	status = c.ReloadStatus()
+	s.False(status.Healthy())
+	s.Equal(uint64(1), status.Failures)
+	s.ErrorContains(status.LastError, "source unavailable")
+	s.True(status.LastAttempt.After(status.LastSuccess))
+	s.Equal("bar", c.GetString("foo"))
+}
+
+func (s *ReloadTestSuite) TestFailedReloadKeepsBinding() {
+	src := &mockSyncSource{conf: map[string]any{"foo": "bar", "bar": 1}}
+	var bind validatingBindStruct
+	c, err := New(WithSource(src), WithBinding(&bind))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	// The binding rejects the new configuration, so neither the values nor the struct change.
+	src.set(map[string]any{"foo": "", "bar": 2}, nil)
+	s.Error(c.Load(context.Background()))
+	s.Equal("bar", bind.Foo)
+	s.Equal(1, bind.Bar)
+	s.Equal(1, c.GetInt("bar"))
+}
+
+func (s *ReloadTestSuite) TestOnReloadError() {
+	src := &mockSyncSource{conf: map[string]any{"foo": "bar"}}
+	c, err := New(WithSource(src), WithPollInterval(20*time.Millisecond))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	reloadErrs := make(chan error, 10)
+	c.OnReloadError(func(err error) {
+		select {
+		case reloadErrs <- err:
+		default:
+		}
+	})
+	c.OnReloadError(nil)
+
+	src.set(nil, errors.New("source unavailable"))
+	_, err = c.StartWatch(context.Background())
+	s.Require().NoError(err)
+	defer func() { s.NoError(c.StopWatch(context.Background())) }()
+
+	select {
+	case err := <-reloadErrs:
+		s.ErrorContains(err, "source unavailable")
+	case <-time.After(2 * time.Second):
+		s.Fail("expected reload error")
+	}
+	s.Equal("bar", c.GetString("foo"))
+}
+
+func (s *ReloadTestSuite) TestOnReloadError_NotCalledForDirectLoad() {
+	c, err := New(WithSource(&mockSource{err: errors.New("fail")}))
+	s.Require().NoError(err)
+
+	called := false
+	c.OnReloadError(func(error) { called = true })
+	s.Error(c.Load(context.Background()))
+	s.False(called)
+}
+
+func (s *ReloadTestSuite) TestReload_SingleSource() {
+	remote := &countingSource{}
+	remote.set(map[string]any{"server": map[string]any{"host": "remote", "port": 80}}, nil)
+	local := &countingSource{}
+	local.set(map[string]any{"server": map[string]any{"port": 8080}}, nil)
+
+	c, err := New(WithSource(remote), WithSource(local))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+	s.Equal(int32(1), remote.loads.Load())
+
+	remote.set(map[string]any{"server": map[string]any{"host": "changed"}}, nil)
+	local.set(map[string]any{"server": map[string]any{"port": 9090}}, nil)
+	s.Require().NoError(c.Reload(context.Background(), "source[1]"))
+
+	s.Equal(int32(1), remote.loads.Load())
+	s.Equal(int32(2), local.loads.Load())
+	s.Equal("remote", c.GetString("server.host"))
+	s.Equal(9090, c.GetInt("server.port"))
+
+	// Reloading again does not see the effect of earlier merges on the cached results.
+	local.set(map[string]any{}, nil)
+	s.Require().NoError(c.Reload(context.Background(), "source[1]"))
+	s.Equal(80, c.GetInt("server.port"))
+
+	s.Require().NoError(c.Reload(context.Background(), "source[0]"))
+	s.Equal("changed", c.GetString("server.host"))
+}
+
+// gatedSource is a source whose loads wait until release is closed, once started is set.
+type gatedSource struct {
+	mockSyncSource
+	started chan struct{}
+	release chan struct{}
+}
+
+func (g *gatedSource) Load(ctx context.Context) (map[string]any, error) {
+	g.mu.Lock()
+	started, release := g.started, g.release
+	g.mu.Unlock()
+	if started != nil {
+		close(started)
+		<-release
+	}
+	return g.mockSyncSource.Load(ctx)
+}
+
+func (s *ReloadTestSuite) TestReload_Serialized() {
+	slow := &gatedSource{mockSyncSource: mockSyncSource{conf: map[string]any{"slow": 1}}}
+	fast := &mockSyncSource{conf: map[string]any{"fast": 1}}
+	c, err := New(WithSource(slow), WithSource(fast))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	slow.mu.Lock()
+	slow.conf = map[string]any{"slow": 2}
+	slow.started, slow.release = make(chan struct{}), make(chan struct{})
+	slow.mu.Unlock()
+	slowDone := make(chan error, 1)
+	go func() { slowDone <- c.Reload(context.Background(), "source[0]") }()
+	<-slow.started
+
+	fast.set(map[string]any{"fast": 2}, nil)
+	fastDone := make(chan error, 1)
+	go func() { fastDone <- c.Reload(context.Background(), "source[1]") }()
+
+	select {
+	case <-fastDone:
+		s.Require().Fail("a reload must wait for the reload already in progress")
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(slow.release)
+	s.Require().NoError(<-slowDone)
+	s.Require().NoError(<-fastDone)
+
+	// The slow reload must not overwrite the newer result of the fast source with its stale cached one.
+	s.Equal(2, c.GetInt("slow"))
+	s.Equal(2, c.GetInt("fast"))
+}
+
+func (s *ReloadTestSuite) TestReload_Errors() {
+	src := &mockSyncSource{conf: map[string]any{"foo": "bar"}}
+	c, err := New(WithSource(src))
+	s.Require().NoError(err)
+
+	s.ErrorContains(c.Reload(context.Background(), "source[5]"), `unknown source "source[5]"`)
+
+	// Reloading before the first Load loads every source.
+	s.Require().NoError(c.Reload(context.Background(), "source[0]"))
+	s.Equal("bar", c.GetString("foo"))
+
+	// A failed reload keeps the previous configuration and cached results.
+	src.set(nil, errors.New("source unavailable"))
+	s.ErrorContains(c.Reload(context.Background(), "source[0]"), "source unavailable")
+	s.Equal("bar", c.GetString("foo"))
+}
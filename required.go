@@ -0,0 +1,55 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ErrRequiredKeyMissing is returned by Load when keys of fields marked with the "required" tag option are missing.
+var ErrRequiredKeyMissing = errors.New("required configuration keys are missing")
+
+// requiredKeysFromTags returns the key paths of the fields of the struct type t marked with the
+// "required" tag option, e.g. `conflex:"port,required"`.
+func requiredKeysFromTags(t reflect.Type, prefix string) []string {
+	var keys []string
+	walkFields(t, prefix, func(path string, _ reflect.StructField, tag fieldTag) {
+		if tag.has("required") {
+			keys = append(keys, path)
+		}
+	})
+	return keys
+}
+
+// checkRequiredKeys returns an error wrapping ErrRequiredKeyMissing that lists every required key
+// without a value in values. A key set to null counts as missing.
+func (c *Conflex) checkRequiredKeys(values map[string]any) error {
+	var missing []string
+	var details []*ValidationError
+	for _, key := range c.requiredKeys {
+		key = c.normalizeKey(key)
+		if c.lookup(values, key) == nil {
+			missing = append(missing, key)
+			details = append(details, missingKeyError(splitKey(key)))
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return withDetails(fmt.Errorf("%w: %s", ErrRequiredKeyMissing, strings.Join(missing, ", ")), details)
+}
@@ -0,0 +1,75 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type requiredConfig struct {
+	Name   string `conflex:"name,required"`
+	Port   int    `conflex:"port,required"`
+	Debug  bool   `conflex:"debug"`
+	Server struct {
+		Host string `conflex:"host,required"`
+	} `conflex:"server"`
+}
+
+type RequiredTestSuite struct {
+	suite.Suite
+}
+
+func TestRequiredTestSuite(t *testing.T) {
+	suite.Run(t, new(RequiredTestSuite))
+}
+
+func (s *RequiredTestSuite) TestAllPresent() {
+	var cfg requiredConfig
+	c, err := New(
+		WithSource(&mockSource{conf: map[string]any{"name": "app", "port": 0, "server": map[string]any{"host": "localhost"}}}),
+		WithBinding(&cfg),
+	)
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+	s.Equal("localhost", cfg.Server.Host)
+}
+
+func (s *RequiredTestSuite) TestMissing() {
+	cfg := requiredConfig{Name: "previous"}
+	c, err := New(
+		WithSource(&mockSource{conf: map[string]any{"NAME": "app", "port": nil, "debug": true}}),
+		WithBinding(&cfg),
+	)
+	s.Require().NoError(err)
+
+	err = c.Load(context.Background())
+	s.Require().Error(err)
+	s.ErrorIs(err, ErrRequiredKeyMissing)
+	s.Equal("config error in binding during validate: required configuration keys are missing: port, server.host", err.Error())
+
+	var configErr *ConfigError
+	s.Require().ErrorAs(err, &configErr)
+	s.Equal("binding", configErr.Source)
+	s.Equal("previous", cfg.Name, "the binding must not be modified")
+}
+
+func (s *RequiredTestSuite) TestRequiredKeysFromTags() {
+	s.Equal([]string{"name", "port", "server.host"}, requiredKeysFromTags(reflect.TypeOf(&requiredConfig{}), ""))
+	s.Equal([]string{"app.name", "app.port", "app.server.host"}, requiredKeysFromTags(reflect.TypeOf(&requiredConfig{}), "app"))
+}
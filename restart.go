@@ -0,0 +1,188 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrRestartRequired is returned by Load when a reload changes keys marked as restart-required in a way that cannot
+// be deferred, such as replacing a parent of such a key with a value that is not a map.
+var ErrRestartRequired = errors.New("configuration change requires a restart")
+
+// WithRestartRequiredKeys marks keys whose changes cannot be applied by a hot reload.
+// A key also covers everything nested below it, so "server" marks every key under "server.".
+// Once the configuration has been loaded, a reload that changes any of these keys applies every other change,
+// while the marked keys keep their current values until the process restarts. The handlers registered with
+// OnRestartRequired are called with the changed keys, once for every distinct set of pending values.
+func WithRestartRequiredKeys(keys ...string) Option {
+	return func(c *Conflex) error {
+		for _, key := range keys {
+			if key == "" {
+				return errors.New("restart-required key cannot be empty")
+			}
+			c.restartKeys = append(c.restartKeys, key)
+		}
+		return nil
+	}
+}
+
+// OnRestartRequired registers a handler that is called when a reload changes restart-required keys.
+// The handler receives the changed keys and is typically used to trigger a graceful shutdown or restart,
+// after which the new configuration is picked up by the next process. Reloads that find the same pending
+// values again, such as periodic polls of an unchanged source, do not call it again.
+func (c *Conflex) OnRestartRequired(fn func(keys []string)) {
+	if fn == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.restartHandlers = append(c.restartHandlers, fn)
+}
+
+// deferRestartRequired keeps the current values of the restart-required keys that res changes, so only the
+// other changes are applied, and notifies the restart handlers of the pending changes. The merged values are
+// validated again, since they combine the current and the new configuration. It returns an error wrapping
+// ErrRestartRequired if the current values cannot be kept, because the new configuration replaced one of
+// their parents with a value that is not a map.
+func (c *Conflex) deferRestartRequired(res *resolution) error {
+	if len(c.restartKeys) == 0 {
+		return nil
+	}
+
+	c.mu.RLock()
+	loaded := c.loaded && c.values != nil
+	var oldValues map[string]any
+	oldOrigins := make(map[string]string, len(c.keyOrigins))
+	if loaded {
+		oldValues = *c.values
+		for key, origin := range c.keyOrigins {
+			oldOrigins[key] = origin
+		}
+	}
+	c.mu.RUnlock()
+
+	// Nothing requires a restart before the configuration has been loaded for the first time.
+	if !loaded {
+		return nil
+	}
+
+	newLeaves := leafValues(res.values)
+	pending := make(map[string]any)
+	restored := make(map[string]bool)
+	for _, key := range changedLeafKeys(leafValues(oldValues), newLeaves) {
+		for _, restartKey := range c.restartKeys {
+			restartKey = c.normalizeKey(restartKey)
+			if keyHasPrefix(key, restartKey) {
+				pending[key] = newLeaves[key]
+				restored[restartKey] = true
+				break
+			}
+		}
+	}
+	if len(pending) == 0 {
+		c.setRestartPending("")
+		return nil
+	}
+
+	keys := make([]string, 0, len(pending))
+	for key := range pending {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	values := copyMap(res.values)
+	for restartKey := range restored {
+		if !keepValue(values, oldValues, splitKey(restartKey)) {
+			c.notifyRestartRequired(keys, pending)
+			return NewConfigError("restart-policy", "reload",
+				fmt.Errorf("%w: %s", ErrRestartRequired, strings.Join(keys, ", ")))
+		}
+		for key := range res.origins {
+			if keyHasPrefix(key, restartKey) {
+				delete(res.origins, key)
+			}
+		}
+		for key, origin := range oldOrigins {
+			if keyHasPrefix(key, restartKey) {
+				res.origins[key] = origin
+			}
+		}
+	}
+	if err := c.validate(values, res.origins); err != nil {
+		return err
+	}
+	res.values = values
+
+	c.notifyRestartRequired(keys, pending)
+	return nil
+}
+
+// keepValue makes the value at path in values the one in oldValues, removing it if oldValues has none.
+// It reports false if a parent of path in values is not a map.
+func keepValue(values, oldValues map[string]any, path []string) bool {
+	if len(extractPath(oldValues, path)) > 0 {
+		return setValue(values, path, copyValue(valueAt(oldValues, path)))
+	}
+
+	current := values
+	for _, segment := range path[:len(path)-1] {
+		nested, ok := current[segment].(map[string]any)
+		if !ok {
+			return current[segment] == nil
+		}
+		current = nested
+	}
+	delete(current, path[len(path)-1])
+	return true
+}
+
+// setRestartPending records the fingerprint of the pending restart-required values and reports whether it changed.
+func (c *Conflex) setRestartPending(fingerprint string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.restartPending == fingerprint {
+		return false
+	}
+	c.restartPending = fingerprint
+	return true
+}
+
+// notifyRestartRequired calls the registered restart handlers with the changed keys, unless they were already
+// called for the same pending values.
+func (c *Conflex) notifyRestartRequired(keys []string, pending map[string]any) {
+	fingerprint, err := json.Marshal(pending)
+	if err != nil {
+		fingerprint = []byte(fmt.Sprint(pending))
+	}
+	if !c.setRestartPending(string(fingerprint)) {
+		return
+	}
+
+	c.mu.RLock()
+	handlers := c.restartHandlers
+	c.mu.RUnlock()
+
+	for _, fn := range handlers {
+		fn(append([]string(nil), keys...))
+	}
+}
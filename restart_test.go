@@ -0,0 +1,178 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type RestartTestSuite struct {
+	suite.Suite
+}
+
+func TestRestartTestSuite(t *testing.T) {
+	suite.Run(t, new(RestartTestSuite))
+}
+
+func (s *RestartTestSuite) TestRestartRequired_DefersKeys() {
+	src := &mockSource{conf: map[string]any{
+		"server":  map[string]any{"port": 8080, "timeout": "5s"},
+		"logging": map[string]any{"level": "info"},
+	}}
+	c, err := New(WithSource(src), WithRestartRequiredKeys("Server.Port"))
+	s.Require().NoError(err)
+
+	var restartKeys []string
+	c.OnRestartRequired(func(keys []string) {
+		restartKeys = keys
+	})
+
+	// The initial load never requires a restart.
+	s.Require().NoError(c.Load(context.Background()))
+	s.Nil(restartKeys)
+
+	// Changing a hot-reloadable key is applied.
+	src.conf = map[string]any{
+		"server":  map[string]any{"port": 8080, "timeout": "5s"},
+		"logging": map[string]any{"level": "debug"},
+	}
+	s.Require().NoError(c.Load(context.Background()))
+	s.Equal("debug", c.GetString("logging.level"))
+	s.Nil(restartKeys)
+
+	// A changed restart-required key keeps its value, and the other changes are applied.
+	src.conf = map[string]any{
+		"server":  map[string]any{"port": 9090, "timeout": "10s"},
+		"logging": map[string]any{"level": "warn"},
+	}
+	s.Require().NoError(c.Load(context.Background()))
+	s.Equal([]string{"server.port"}, restartKeys)
+	s.Equal(8080, c.GetInt("server.port"))
+	s.Equal("10s", c.GetString("server.timeout"))
+	s.Equal("warn", c.GetString("logging.level"))
+	origin, _ := c.Origin("server.port")
+	s.Equal("source[0]", origin)
+}
+
+func (s *RestartTestSuite) TestRestartRequired_NotifiesOncePerValue() {
+	src := &mockSyncSource{conf: map[string]any{"server": map[string]any{"port": 8080}, "level": "info"}}
+	c, err := New(WithSource(src), WithRestartRequiredKeys("server.port"))
+	s.Require().NoError(err)
+
+	var calls [][]string
+	c.OnRestartRequired(func(keys []string) {
+		calls = append(calls, keys)
+	})
+	s.Require().NoError(c.Load(context.Background()))
+
+	src.set(map[string]any{"server": map[string]any{"port": 9090}, "level": "info"}, nil)
+	s.Require().NoError(c.Load(context.Background()))
+	s.Require().NoError(c.Load(context.Background()))
+	src.set(map[string]any{"server": map[string]any{"port": 9090}, "level": "debug"}, nil)
+	s.Require().NoError(c.Load(context.Background()))
+	s.Equal([][]string{{"server.port"}}, calls, "polls finding the same pending value do not notify again")
+
+	src.set(map[string]any{"server": map[string]any{"port": 9191}, "level": "debug"}, nil)
+	s.Require().NoError(c.Load(context.Background()))
+	s.Len(calls, 2)
+
+	// Once the pending change is reverted, changing the key again notifies again.
+	src.set(map[string]any{"server": map[string]any{"port": 8080}, "level": "debug"}, nil)
+	s.Require().NoError(c.Load(context.Background()))
+	src.set(map[string]any{"server": map[string]any{"port": 9191}, "level": "debug"}, nil)
+	s.Require().NoError(c.Load(context.Background()))
+	s.Len(calls, 3)
+	s.Equal(8080, c.GetInt("server.port"))
+	s.Equal("debug", c.GetString("level"))
+}
+
+func (s *RestartTestSuite) TestRestartRequired_AddedAndRemovedKeys() {
+	src := &mockSyncSource{conf: map[string]any{"server": map[string]any{"port": 8080}}}
+	c, err := New(WithSource(src), WithRestartRequiredKeys("server.port", "database"))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	src.set(map[string]any{"server": map[string]any{"host": "0.0.0.0"}, "database": map[string]any{"host": "db"}}, nil)
+	s.Require().NoError(c.Load(context.Background()))
+	s.Equal(map[string]any{"server": map[string]any{"port": 8080, "host": "0.0.0.0"}}, c.AllSettings())
+}
+
+func (s *RestartTestSuite) TestRestartRequired_CannotDefer() {
+	src := &mockSyncSource{conf: map[string]any{"server": map[string]any{"port": 8080}}}
+	c, err := New(WithSource(src), WithRestartRequiredKeys("server.port"))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	src.set(map[string]any{"server": "localhost:9090"}, nil)
+	err = c.Load(context.Background())
+	s.True(errors.Is(err, ErrRestartRequired))
+	s.Equal(8080, c.GetInt("server.port"))
+}
+
+func (s *RestartTestSuite) TestRestartRequired_ValidatesMergedValues() {
+	src := &mockSyncSource{conf: map[string]any{"min": 1, "max": 10}}
+	c, err := New(
+		WithSource(src),
+		WithRestartRequiredKeys("max"),
+		WithValidator(func(values map[string]any) error {
+			if values["min"].(int) > values["max"].(int) {
+				return errors.New("min exceeds max")
+			}
+			return nil
+		}),
+	)
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	// The new configuration is valid, but not combined with the current max.
+	src.set(map[string]any{"min": 15, "max": 20}, nil)
+	s.ErrorContains(c.Load(context.Background()), "min exceeds max")
+	s.Equal(1, c.GetInt("min"))
+}
+
+func (s *RestartTestSuite) TestRestartRequired_CoversNestedKeys() {
+	src := &mockSource{conf: map[string]any{"database": map[string]any{"host": "a", "pool": map[string]any{"size": 1}}}}
+	c, err := New(WithSource(src), WithRestartRequiredKeys("database"))
+	s.Require().NoError(err)
+
+	var restartKeys []string
+	c.OnRestartRequired(func(keys []string) {
+		restartKeys = keys
+	})
+	c.OnRestartRequired(nil)
+
+	s.Require().NoError(c.Load(context.Background()))
+
+	src.conf = map[string]any{"database": map[string]any{"host": "b", "pool": map[string]any{"size": 2}}}
+	s.Require().NoError(c.Load(context.Background()))
+	s.Equal([]string{"database.host", "database.pool.size"}, restartKeys)
+	s.Equal("a", c.GetString("database.host"))
+}
+
+func (s *RestartTestSuite) TestWithRestartRequiredKeys_Empty() {
+	_, err := New(WithRestartRequiredKeys(""))
+	s.Error(err)
+}
+
+func (s *RestartTestSuite) TestChangedKeys() {
+	oldValues := map[string]any{"a": 1, "b": map[string]any{"c": 2, "d": 3}}
+	newValues := map[string]any{"a": 1, "b": map[string]any{"c": 4}, "e": 5}
+	s.Equal([]string{"b.c", "b.d", "e"}, changedKeys(oldValues, newValues))
+	s.Empty(changedKeys(oldValues, oldValues))
+}
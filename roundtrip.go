@@ -0,0 +1,117 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+
+	"go.companyinfo.dev/conflex/dumper"
+)
+
+// WithRoundTrip enables round-trip mode, in which loading a dump of the configuration yields exactly the same
+// configuration: Load(Dump(x)) == x for the JSON, YAML and TOML codecs. This is the foundation for write-back
+// and GitOps workflows built on dumpers.
+//
+// In round-trip mode, loaded values are converted to canonical types that every codec decodes identically:
+//   - integers become int64, and floats without a fractional part that fit in an int64 become int64 as well;
+//   - other floats become float64;
+//   - maps become map[string]any and lists become []any;
+//   - time.Duration, time.Time and []byte values become strings, as written by dumper.Normalize.
+//
+// TOML cannot represent null values, so configurations containing them only round-trip through JSON and YAML.
+func WithRoundTrip() Option {
+	return func(c *Conflex) error {
+		c.roundTrip = true
+		return nil
+	}
+}
+
+// canonicalValues returns values converted to the canonical types used in round-trip mode.
+func canonicalValues(values map[string]any) map[string]any {
+	out, _ := canonicalValue(dumper.Normalize(values)).(map[string]any)
+	return out
+}
+
+func canonicalValue(v any) any {
+	switch val := v.(type) {
+	case nil, bool, string:
+		return v
+	case map[string]any:
+		out := make(map[string]any, len(val))
+		for k, item := range val {
+			out[k] = canonicalValue(item)
+		}
+		return out
+	case []any:
+		out := make([]any, len(val))
+		for i, item := range val {
+			out[i] = canonicalValue(item)
+		}
+		return out
+	case json.Number:
+		if i, err := val.Int64(); err == nil {
+			return i
+		}
+		if f, err := val.Float64(); err == nil {
+			return canonicalFloat(f)
+		}
+		return val.String()
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if rv.Uint() > math.MaxInt64 {
+			return rv.Uint()
+		}
+		return int64(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		return canonicalFloat(rv.Float())
+	case reflect.String:
+		return rv.String()
+	case reflect.Bool:
+		return rv.Bool()
+	case reflect.Slice, reflect.Array:
+		out := make([]any, rv.Len())
+		for i := range out {
+			out[i] = canonicalValue(rv.Index(i).Interface())
+		}
+		return out
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return v
+		}
+		out := make(map[string]any, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			out[iter.Key().String()] = canonicalValue(iter.Value().Interface())
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// canonicalFloat returns f as an int64 if it has no fractional part and fits in an int64, and as a float64 otherwise.
+func canonicalFloat(f float64) any {
+	if f == math.Trunc(f) && f >= math.MinInt64 && f < math.MaxInt64 {
+		return int64(f)
+	}
+	return f
+}
@@ -0,0 +1,123 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.companyinfo.dev/conflex/codec"
+)
+
+const roundTripYAML = `
+server:
+  host: localhost
+  port: 8080
+  timeout: 1.5
+  ratio: 2.0
+  enabled: true
+  started: !!timestamp 2024-01-02T03:04:05Z
+  key: !!binary aGVsbG8=
+tags: [a, b, c]
+limits:
+  - name: read
+    max: 100
+  - name: write
+    max: 10
+empty: {}
+`
+
+type RoundTripTestSuite struct {
+	suite.Suite
+}
+
+func TestRoundTripTestSuite(t *testing.T) {
+	suite.Run(t, new(RoundTripTestSuite))
+}
+
+func (s *RoundTripTestSuite) TestLoadDumpLoad() {
+	for _, codecType := range []codec.Type{codec.TypeJSON, codec.TypeYAML, codec.TypeTOML} {
+		s.Run(string(codecType), func() {
+			dir := s.T().TempDir()
+			first := filepath.Join(dir, "first."+string(codecType))
+			second := filepath.Join(dir, "second."+string(codecType))
+
+			original, err := New(
+				WithContentSource([]byte(roundTripYAML), codec.TypeYAML),
+				WithRoundTrip(),
+				WithFileDumper(first, codecType),
+			)
+			s.Require().NoError(err)
+			s.Require().NoError(original.Load(context.Background()))
+			s.Require().NoError(original.Dump(context.Background()))
+
+			reloaded, err := New(
+				WithFileSource(first, codecType),
+				WithRoundTrip(),
+				WithFileDumper(second, codecType),
+			)
+			s.Require().NoError(err)
+			s.Require().NoError(reloaded.Load(context.Background()))
+			s.Equal(original.AllSettings(), reloaded.AllSettings())
+
+			// Dumping the reloaded configuration produces the same output.
+			s.Require().NoError(reloaded.Dump(context.Background()))
+			firstData, err := os.ReadFile(first)
+			s.Require().NoError(err)
+			secondData, err := os.ReadFile(second)
+			s.Require().NoError(err)
+			s.Equal(string(firstData), string(secondData))
+		})
+	}
+}
+
+func (s *RoundTripTestSuite) TestCanonicalTypes() {
+	c, err := New(
+		WithContentSource([]byte(roundTripYAML), codec.TypeYAML),
+		WithRoundTrip(),
+	)
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	s.Equal(int64(8080), c.Get("server.port"))
+	s.Equal(1.5, c.Get("server.timeout"))
+	s.Equal(int64(2), c.Get("server.ratio"))
+	s.Equal("2024-01-02T03:04:05Z", c.Get("server.started"))
+	s.Equal("aGVsbG8=", c.Get("server.key"))
+	s.Equal([]any{"a", "b", "c"}, c.Get("tags"))
+}
+
+func (s *RoundTripTestSuite) TestCanonicalValue() {
+	s.Equal(map[string]any{
+		"int":    int64(1),
+		"uint":   int64(2),
+		"float":  int64(3),
+		"frac":   3.25,
+		"list":   []any{int64(1), int64(2)},
+		"nested": map[string]any{"a": int64(1)},
+		"null":   nil,
+	}, canonicalValues(map[string]any{
+		"int":    1,
+		"uint":   uint16(2),
+		"float":  float32(3),
+		"frac":   3.25,
+		"list":   []int{1, 2},
+		"nested": map[string]int{"a": 1},
+		"null":   nil,
+	}))
+}
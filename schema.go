@@ -0,0 +1,151 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// schemaCache holds compiled schemas keyed by their resource name, so instances configured with
+// the same schema bytes share a single compiled schema instead of compiling it again.
+var schemaCache sync.Map
+
+// schemaResourceName returns a deterministic resource name for the given schema bytes.
+// The name is derived from the SHA-256 hash of the content, so registering the same
+// schema twice always yields the same name and different schemas never collide.
+func schemaResourceName(schema []byte) string {
+	sum := sha256.Sum256(schema)
+	return "inline_" + hex.EncodeToString(sum[:]) + ".json"
+}
+
+// SchemaOption configures how a JSON Schema is compiled by CompileJSONSchema and WithJSONSchema.
+type SchemaOption func(o *schemaOptions) error
+
+// schemaOptions holds the settings applied by SchemaOption values.
+type schemaOptions struct {
+	assertFormats bool
+	formats       []*jsonschema.Format
+}
+
+// SchemaAssertFormats makes the "format" keyword an assertion instead of an annotation, so values that do
+// not match their format, such as "uri", "hostname" or "email", fail validation. Without it, drafts 2019-09
+// and 2020-12 only report formats as annotations.
+// The "go-duration" format accepts Go duration strings such as "1m30s", the syntax used to bind time.Duration
+// fields, while the standard "duration" format keeps its ISO 8601 meaning, as in "PT1M30S".
+func SchemaAssertFormats() SchemaOption {
+	return func(o *schemaOptions) error {
+		o.assertFormats = true
+		return nil
+	}
+}
+
+// SchemaFormat registers a custom format that validate checks values against, replacing any built-in format
+// of the same name. validate is called for values of every type and should return nil for types the format does
+// not apply to. Registering a format also enables format assertions (see SchemaAssertFormats).
+// Schemas compiled with custom formats are not cached.
+func SchemaFormat(name string, validate func(v any) error) SchemaOption {
+	return func(o *schemaOptions) error {
+		if name == "" {
+			return errors.New("format name cannot be empty")
+		}
+		if validate == nil {
+			return errors.New("format validator cannot be nil")
+		}
+		o.assertFormats = true
+		o.formats = append(o.formats, &jsonschema.Format{Name: name, Validate: validate})
+		return nil
+	}
+}
+
+// DurationFormat is the name of the format of Go duration strings, which GenerateJSONSchema uses for
+// time.Duration fields. The standard "duration" format means an ISO 8601 duration in draft 2020-12.
+const DurationFormat = "go-duration"
+
+// durationFormat validates that strings are Go durations, as accepted by time.ParseDuration.
+var durationFormat = &jsonschema.Format{
+	Name: DurationFormat,
+	Validate: func(v any) error {
+		s, ok := v.(string)
+		if !ok {
+			return nil
+		}
+		_, err := time.ParseDuration(s)
+		return err
+	},
+}
+
+// CompileJSONSchema compiles the given JSON Schema document.
+// Documents without a "$schema" keyword are compiled as draft 2020-12; earlier drafts declared with "$schema"
+// are supported as well.
+// Compiled schemas are cached by content hash and options, so compiling the same bytes again returns the
+// previously compiled schema. The returned schema can be shared across Conflex instances using
+// WithCompiledJSONSchema.
+func CompileJSONSchema(schema []byte, options ...SchemaOption) (*jsonschema.Schema, error) {
+	var opts schemaOptions
+	for _, option := range options {
+		if err := option(&opts); err != nil {
+			return nil, err
+		}
+	}
+
+	name := schemaResourceName(schema)
+	cacheKey := name
+	if opts.assertFormats {
+		cacheKey += "#assert-formats"
+	}
+	cacheable := len(opts.formats) == 0
+	if cacheable {
+		if cached, ok := schemaCache.Load(cacheKey); ok {
+			return cached.(*jsonschema.Schema), nil
+		}
+	}
+
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(schema))
+	if err != nil {
+		return nil, err
+	}
+
+	compiler := jsonschema.NewCompiler()
+	compiler.DefaultDraft(jsonschema.Draft2020)
+	if opts.assertFormats {
+		compiler.AssertFormat()
+		compiler.RegisterFormat(durationFormat)
+	}
+	for _, format := range opts.formats {
+		compiler.RegisterFormat(format)
+	}
+	if err := compiler.AddResource(name, doc); err != nil {
+		return nil, err
+	}
+
+	compiled, err := compiler.Compile(name)
+	if err != nil {
+		return nil, err
+	}
+	if !cacheable {
+		return compiled, nil
+	}
+
+	// Another goroutine may have compiled the same schema concurrently; keep the first one stored.
+	actual, _ := schemaCache.LoadOrStore(cacheKey, compiled)
+	return actual.(*jsonschema.Schema), nil
+}
@@ -0,0 +1,175 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+const testSchema = `{"$schema":"http://json-schema.org/draft-07/schema#","type":"object","properties":{"foo":{"type":"string"},"bar":{"type":"integer"}},"required":["foo","bar"]}`
+
+type SchemaTestSuite struct {
+	suite.Suite
+}
+
+func TestSchemaTestSuite(t *testing.T) {
+	suite.Run(t, new(SchemaTestSuite))
+}
+
+func (s *SchemaTestSuite) TestSchemaResourceName_Deterministic() {
+	s.Equal(schemaResourceName([]byte(testSchema)), schemaResourceName([]byte(testSchema)))
+	s.NotEqual(schemaResourceName([]byte(testSchema)), schemaResourceName([]byte(`{}`)))
+}
+
+func (s *SchemaTestSuite) TestCompileJSONSchema_Invalid() {
+	_, err := CompileJSONSchema([]byte(`{"type":`))
+	s.Error(err)
+}
+
+func (s *SchemaTestSuite) TestCompileJSONSchema_Cached() {
+	first, err := CompileJSONSchema([]byte(testSchema))
+	s.Require().NoError(err)
+	second, err := CompileJSONSchema([]byte(testSchema))
+	s.Require().NoError(err)
+	s.Same(first, second)
+
+	a, err := New(WithJSONSchema([]byte(testSchema)))
+	s.Require().NoError(err)
+	b, err := New(WithJSONSchema([]byte(testSchema)))
+	s.Require().NoError(err)
+	s.Same(a.JSONSchema(), b.JSONSchema())
+}
+
+func (s *SchemaTestSuite) TestCompileJSONSchema_InvalidNotCached() {
+	bad := []byte(`{"type":"notatype"}`)
+	_, err := CompileJSONSchema(bad)
+	s.Error(err)
+	_, ok := schemaCache.Load(schemaResourceName(bad))
+	s.False(ok)
+}
+
+func (s *SchemaTestSuite) TestWithCompiledJSONSchema_SharedAcrossInstances() {
+	compiled, err := CompileJSONSchema([]byte(testSchema))
+	s.Require().NoError(err)
+
+	valid, err := New(WithSource(&mockSource{conf: map[string]any{"foo": "x", "bar": 1}}), WithCompiledJSONSchema(compiled))
+	s.Require().NoError(err)
+	s.Same(compiled, valid.JSONSchema())
+	s.NoError(valid.Load(context.Background()))
+
+	invalid, err := New(WithSource(&mockSource{conf: map[string]any{"foo": "x"}}), WithCompiledJSONSchema(compiled))
+	s.Require().NoError(err)
+	s.Error(invalid.Load(context.Background()))
+}
+
+func (s *SchemaTestSuite) TestWithCompiledJSONSchema_Nil() {
+	_, err := New(WithCompiledJSONSchema(nil))
+	s.Error(err)
+	s.Contains(err.Error(), "schema cannot be nil")
+}
+
+func (s *SchemaTestSuite) TestWithJSONSchema_Parallel() {
+	var wg sync.WaitGroup
+	errs := make(chan error, 50)
+	for range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := New(WithJSONSchema([]byte(testSchema)))
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		s.NoError(err)
+	}
+}
+
+func (s *SchemaTestSuite) TestCompileJSONSchema_Draft2020() {
+	// prefixItems and dependentRequired only exist since draft 2019-09/2020-12.
+	schema, err := CompileJSONSchema([]byte(`{
+		"type": "object",
+		"properties": {"pair": {"type": "array", "prefixItems": [{"type": "string"}, {"type": "integer"}]}},
+		"dependentRequired": {"tls_cert": ["tls_key"]}
+	}`))
+	s.Require().NoError(err)
+
+	s.NoError(schema.Validate(map[string]any{"pair": []any{"a", 1}}))
+	s.Error(schema.Validate(map[string]any{"pair": []any{1, "a"}}))
+	s.Error(schema.Validate(map[string]any{"tls_cert": "cert.pem"}))
+}
+
+const formatSchema = `{
+	"type": "object",
+	"properties": {
+		"endpoint": {"type": "string", "format": "uri"},
+		"host": {"type": "string", "format": "hostname"},
+		"timeout": {"type": "string", "format": "go-duration"},
+		"retention": {"type": "string", "format": "duration"}
+	}
+}`
+
+func (s *SchemaTestSuite) TestCompileJSONSchema_Formats() {
+	invalid := map[string]any{"endpoint": "not a uri", "host": "-bad-", "timeout": "soon", "retention": "1m30s"}
+
+	annotated, err := CompileJSONSchema([]byte(formatSchema))
+	s.Require().NoError(err)
+	s.NoError(annotated.Validate(invalid), "formats are annotations by default")
+
+	asserted, err := CompileJSONSchema([]byte(formatSchema), SchemaAssertFormats())
+	s.Require().NoError(err)
+	s.NotSame(annotated, asserted)
+	s.NoError(asserted.Validate(map[string]any{
+		"endpoint": "https://example.com", "host": "example.com", "timeout": "1m30s", "retention": "P30D",
+	}))
+	for key, value := range invalid {
+		s.Error(asserted.Validate(map[string]any{key: value}), key)
+	}
+
+	cached, err := CompileJSONSchema([]byte(formatSchema), SchemaAssertFormats())
+	s.Require().NoError(err)
+	s.Same(asserted, cached)
+}
+
+func (s *SchemaTestSuite) TestCompileJSONSchema_CustomFormat() {
+	schema := []byte(`{"type": "object", "properties": {"region": {"type": "string", "format": "region"}}}`)
+	region := SchemaFormat("region", func(v any) error {
+		if str, ok := v.(string); ok && !strings.HasPrefix(str, "eu-") {
+			return errors.New("unknown region")
+		}
+		return nil
+	})
+
+	c, err := New(
+		WithSource(&mockSource{conf: map[string]any{"region": "us-east-1"}}),
+		WithJSONSchema(schema, region),
+	)
+	s.Require().NoError(err)
+	err = c.Load(context.Background())
+	s.Require().Error(err)
+	s.Contains(err.Error(), "unknown region")
+
+	_, err = CompileJSONSchema(schema, SchemaFormat("", func(any) error { return nil }))
+	s.Error(err)
+	_, err = CompileJSONSchema(schema, SchemaFormat("region", nil))
+	s.Error(err)
+}
@@ -0,0 +1,205 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// SchemaFor generates a JSON Schema (draft 2020-12) document describing the configuration keys bound to the
+// struct v, which must be a struct or a pointer to one. Property names follow the conflex tags, fields marked
+// "required" are listed as required, and "default" options become schema defaults.
+// The document can be passed to WithJSONSchema to validate the merged configuration, or published for editor
+// autocompletion:
+//
+//	schema, err := conflex.SchemaFor(&AppConfig{})
+func SchemaFor(v any) ([]byte, error) {
+	if v == nil {
+		return nil, errors.New("schema target cannot be nil")
+	}
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, errors.New("schema target must be a struct or a pointer to a struct")
+	}
+
+	schema, err := structSchema(t, map[reflect.Type]bool{})
+	if err != nil {
+		return nil, err
+	}
+	schema["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	if t.Name() != "" {
+		schema["title"] = t.Name()
+	}
+
+	return json.MarshalIndent(schema, "", "  ")
+}
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	timeType     = reflect.TypeOf(time.Time{})
+	urlType      = reflect.TypeOf(url.URL{})
+)
+
+// scalarStructTypes are the struct types that are decoded from a single value rather than from an object.
+var scalarStructTypes = map[reflect.Type]bool{
+	timeType:     true,
+	urlType:      true,
+	locationType: true,
+	regexpType:   true,
+	bigIntType:   true,
+	bigFloatType: true,
+}
+
+// structSchema returns the object schema of the struct type t. Nested structs become nested objects,
+// while embedded and squashed structs contribute their properties to the enclosing object.
+func structSchema(t reflect.Type, visiting map[reflect.Type]bool) (map[string]any, error) {
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	root := objectSchema()
+	objects := map[string]map[string]any{"": root}
+	var errs error
+	walkFields(t, "", func(path string, field reflect.StructField, tag fieldTag) {
+		// Loaded keys are lowercase by default, so the schema uses lowercase property names.
+		path = strings.ToLower(path)
+		parentPath, name := "", path
+		if i := strings.LastIndex(path, "."); i >= 0 {
+			parentPath, name = path[:i], path[i+1:]
+		}
+		parent := objects[parentPath]
+		if parent == nil {
+			return
+		}
+
+		schema, err := typeSchema(field.Type, visiting)
+		if err != nil {
+			errs = errors.Join(errs, err)
+			return
+		}
+		if schema["type"] == "object" && schema["properties"] != nil {
+			objects[path] = schema
+		}
+
+		if raw, ok := tag.options["default"]; ok {
+			value, err := parseDefault(raw, field.Type)
+			if err != nil {
+				errs = errors.Join(errs, fmt.Errorf("field %s: invalid default %q: %w", path, raw, err))
+			} else {
+				schema["default"] = value
+			}
+		}
+		if tag.has("required") {
+			parent["required"] = append(parent["required"].([]string), name)
+		}
+		parent["properties"].(map[string]any)[name] = schema
+	})
+	if errs != nil {
+		return nil, errs
+	}
+
+	// Drop empty lists, so the document only contains the keywords that apply.
+	for _, object := range objects {
+		if len(object["required"].([]string)) == 0 {
+			delete(object, "required")
+		}
+	}
+
+	return root, nil
+}
+
+// objectSchema returns an empty object schema ready to be filled by structSchema.
+func objectSchema() map[string]any {
+	return map[string]any{"type": "object", "properties": map[string]any{}, "required": []string{}}
+}
+
+// typeSchema returns the schema of values of type t. Nested struct types return an empty object schema,
+// which structSchema fills with the properties of the struct; struct elements of slices and maps are
+// described completely. Recursive types are described as unconstrained objects once they repeat.
+func typeSchema(t reflect.Type, visiting map[reflect.Type]bool) (map[string]any, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t {
+	case durationType:
+		return map[string]any{"type": "string", "format": DurationFormat}, nil
+	case timeType:
+		return map[string]any{"type": "string", "format": "date-time"}, nil
+	case urlType:
+		return map[string]any{"type": "string", "format": "uri"}, nil
+	case byteSizeType:
+		return map[string]any{"type": []string{"integer", "string"}}, nil
+	case locationType:
+		return map[string]any{"type": "string"}, nil
+	case regexpType:
+		return map[string]any{"type": "string", "format": "regex"}, nil
+	case bigIntType:
+		return map[string]any{"type": []string{"integer", "string"}}, nil
+	case bigFloatType:
+		return map[string]any{"type": []string{"number", "string"}}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]any{"type": "integer"}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer", "minimum": 0}, nil
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}, nil
+	case reflect.String:
+		return map[string]any{"type": "string"}, nil
+	case reflect.Slice, reflect.Array:
+		items, err := elemSchema(t.Elem(), visiting)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"type": "array", "items": items}, nil
+	case reflect.Map:
+		values, err := elemSchema(t.Elem(), visiting)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"type": "object", "additionalProperties": values}, nil
+	case reflect.Struct:
+		return objectSchema(), nil
+	default:
+		return map[string]any{}, nil
+	}
+}
+
+// elemSchema returns the complete schema of the elements of a slice or map.
+func elemSchema(t reflect.Type, visiting map[reflect.Type]bool) (map[string]any, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || scalarStructTypes[t] {
+		return typeSchema(t, visiting)
+	}
+	if visiting[t] {
+		return map[string]any{"type": "object"}, nil
+	}
+	return structSchema(t, visiting)
+}
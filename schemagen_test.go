@@ -0,0 +1,164 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"context"
+	"math/big"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type schemaEndpoint struct {
+	URL     url.URL `conflex:"url,required"`
+	Weight  uint    `conflex:"weight,default=1"`
+	Private string  `conflex:"-"`
+}
+
+type schemaBase struct {
+	Env string `conflex:"env,default=dev"`
+}
+
+type schemaNode struct {
+	Name     string       `conflex:"name"`
+	Children []schemaNode `conflex:"children"`
+}
+
+type schemaConfig struct {
+	schemaBase
+	Name    string         `conflex:"name,required"`
+	Port    int            `conflex:"port,default=8080"`
+	Ratio   float64        `conflex:"ratio"`
+	Debug   bool           `conflex:"debug"`
+	Timeout time.Duration  `conflex:"timeout,default=30s"`
+	Started time.Time      `conflex:"started"`
+	MaxBody ByteSize       `conflex:"max_body,default=1MiB"`
+	Zone    *time.Location `conflex:"zone,default=UTC"`
+	Match   *regexp.Regexp `conflex:"match"`
+	Supply  *big.Int       `conflex:"supply"`
+	Server  struct {
+		Host string `conflex:"host,required"`
+	} `conflex:"server"`
+	Endpoints []schemaEndpoint  `conflex:"endpoints"`
+	Labels    map[string]string `conflex:"labels"`
+	Extra     any               `conflex:"extra"`
+	Tree      schemaNode        `conflex:"tree"`
+}
+
+type SchemaForTestSuite struct {
+	suite.Suite
+}
+
+func TestSchemaForTestSuite(t *testing.T) {
+	suite.Run(t, new(SchemaForTestSuite))
+}
+
+func (s *SchemaForTestSuite) TestSchemaFor() {
+	schema, err := SchemaFor(&schemaConfig{})
+	s.Require().NoError(err)
+
+	s.JSONEq(`{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"title": "schemaConfig",
+		"type": "object",
+		"required": ["name"],
+		"properties": {
+			"env": {"type": "string", "default": "dev"},
+			"name": {"type": "string"},
+			"port": {"type": "integer", "default": 8080},
+			"ratio": {"type": "number"},
+			"debug": {"type": "boolean"},
+			"timeout": {"type": "string", "format": "go-duration", "default": "30s"},
+			"started": {"type": "string", "format": "date-time"},
+			"max_body": {"type": ["integer", "string"], "default": 1048576},
+			"zone": {"type": "string", "default": "UTC"},
+			"match": {"type": "string", "format": "regex"},
+			"supply": {"type": ["integer", "string"]},
+			"server": {
+				"type": "object",
+				"required": ["host"],
+				"properties": {"host": {"type": "string"}}
+			},
+			"endpoints": {
+				"type": "array",
+				"items": {
+					"type": "object",
+					"required": ["url"],
+					"properties": {
+						"url": {"type": "string", "format": "uri"},
+						"weight": {"type": "integer", "minimum": 0, "default": 1}
+					}
+				}
+			},
+			"labels": {"type": "object", "additionalProperties": {"type": "string"}},
+			"extra": {},
+			"tree": {
+				"type": "object",
+				"properties": {
+					"name": {"type": "string"},
+					"children": {
+						"type": "array",
+						"items": {
+							"type": "object",
+							"properties": {
+								"name": {"type": "string"},
+								"children": {"type": "array", "items": {"type": "object"}}
+							}
+						}
+					}
+				}
+			}
+		}
+	}`, string(schema))
+}
+
+func (s *SchemaForTestSuite) TestSchemaValidatesConfiguration() {
+	schema, err := SchemaFor(schemaConfig{})
+	s.Require().NoError(err)
+
+	c, err := New(
+		WithSource(&mockSource{conf: map[string]any{"name": "app", "port": "not a number", "server": map[string]any{"host": "localhost"}}}),
+		WithJSONSchema(schema),
+	)
+	s.Require().NoError(err)
+	s.Error(c.Load(context.Background()))
+
+	c, err = New(
+		WithSource(&mockSource{conf: map[string]any{"name": "app", "port": 9090, "server": map[string]any{"host": "localhost"}}}),
+		WithJSONSchema(schema),
+	)
+	s.Require().NoError(err)
+	s.NoError(c.Load(context.Background()))
+}
+
+func (s *SchemaForTestSuite) TestInvalid() {
+	_, err := SchemaFor(nil)
+	s.Error(err)
+
+	_, err = SchemaFor(42)
+	s.Error(err)
+
+	type badDefault struct {
+		Items []struct {
+			Port int `conflex:"port,default=http"`
+		} `conflex:"items"`
+	}
+	_, err = SchemaFor(&badDefault{})
+	s.ErrorContains(err, `field port: invalid default "http"`)
+}
@@ -0,0 +1,121 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"context"
+	"time"
+)
+
+// minSecretRefreshRetry is the shortest delay before retrying a failed refresh of leased secrets.
+const minSecretRefreshRetry = time.Second
+
+// LeasedSecretProvider is implemented by secret providers whose secrets expire, such as Vault dynamic secrets.
+// Conflex keeps track of the leases and re-resolves the secrets in the background once two thirds of the shortest
+// lease have elapsed, well before it expires. The refreshed configuration is validated and applied like a reload,
+// so OnChange handlers, typed rebind handlers and the binding see the new secrets.
+type LeasedSecretProvider interface {
+	SecretProvider
+	// ResolveLease resolves ref like Resolve, and returns how long the secret stays valid.
+	// A TTL of zero or less means the secret does not expire.
+	ResolveLease(ctx context.Context, ref string) (secret string, ttl time.Duration, err error)
+}
+
+// resolveSecret resolves ref with provider, returning the TTL of the secret if the provider leases it.
+func resolveSecret(ctx context.Context, provider SecretProvider, ref string) (string, time.Duration, error) {
+	if leased, ok := provider.(LeasedSecretProvider); ok {
+		return leased.ResolveLease(ctx, ref)
+	}
+	secret, err := provider.Resolve(ctx, ref)
+	return secret, 0, err
+}
+
+// scheduleSecretRefresh schedules the refresh of the secrets of the configuration just applied, which expire
+// after ttl, starting the refresh loop if needed. A ttl of zero cancels any scheduled refresh.
+func (c *Conflex) scheduleSecretRefresh(ttl time.Duration) {
+	c.secretLeaseMu.Lock()
+	if ttl > 0 {
+		now := time.Now()
+		c.secretExpiry = now.Add(ttl)
+		c.secretRefreshAt = now.Add(ttl * 2 / 3)
+	} else {
+		c.secretExpiry, c.secretRefreshAt = time.Time{}, time.Time{}
+	}
+	if c.secretLeaseChanged == nil {
+		c.secretLeaseChanged = make(chan struct{}, 1)
+	}
+	changed := c.secretLeaseChanged
+	c.secretLeaseMu.Unlock()
+
+	select {
+	case changed <- struct{}{}:
+	default:
+	}
+
+	if ttl > 0 {
+		c.secretRefreshOnce.Do(func() {
+			c.startBackground(c.refreshSecretLeases)
+		})
+	}
+}
+
+// refreshSecretLeases re-resolves leased secrets when they are due, until ctx is cancelled.
+// A failed refresh is reported to the reload error handlers and retried halfway to the expiry of the lease.
+func (c *Conflex) refreshSecretLeases(ctx context.Context) {
+	for {
+		c.secretLeaseMu.Lock()
+		refreshAt := c.secretRefreshAt
+		changed := c.secretLeaseChanged
+		c.secretLeaseMu.Unlock()
+
+		var timer *time.Timer
+		var due <-chan time.Time
+		if !refreshAt.IsZero() {
+			timer = time.NewTimer(time.Until(refreshAt))
+			due = timer.C
+		}
+		stop := func() {
+			if timer != nil {
+				timer.Stop()
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			stop()
+			return
+		case <-changed:
+			stop()
+			continue
+		case <-due:
+		}
+
+		if err := c.refreshSecrets(ctx); err != nil {
+			c.secretLeaseMu.Lock()
+			retry := max(time.Until(c.secretExpiry)/2, minSecretRefreshRetry)
+			c.secretRefreshAt = time.Now().Add(retry)
+			c.secretLeaseMu.Unlock()
+		}
+	}
+}
+
+// refreshSecrets resolves the secrets again and applies the result, reusing the results of the sources.
+func (c *Conflex) refreshSecrets(ctx context.Context) error {
+	err := c.runLoad(ctx, c.cachedResults)
+	if err != nil {
+		c.reportReloadError(err)
+	}
+	return err
+}
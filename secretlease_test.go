@@ -0,0 +1,127 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// leasingProvider issues a new credential with the given TTL for every resolution.
+type leasingProvider struct {
+	mu     sync.Mutex
+	ttl    time.Duration
+	issued int
+	err    error
+}
+
+func (p *leasingProvider) Resolve(ctx context.Context, ref string) (string, error) {
+	secret, _, err := p.ResolveLease(ctx, ref)
+	return secret, err
+}
+
+func (p *leasingProvider) ResolveLease(context.Context, string) (string, time.Duration, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.err != nil {
+		return "", 0, p.err
+	}
+	p.issued++
+	return fmt.Sprintf("credential-%d", p.issued), p.ttl, nil
+}
+
+func (p *leasingProvider) fail(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.err = err
+}
+
+type SecretLeaseTestSuite struct {
+	suite.Suite
+}
+
+func TestSecretLeaseTestSuite(t *testing.T) {
+	suite.Run(t, new(SecretLeaseTestSuite))
+}
+
+func (s *SecretLeaseTestSuite) TestRefreshesBeforeExpiry() {
+	provider := &leasingProvider{ttl: 150 * time.Millisecond}
+	src := &countingSource{mockSyncSource: mockSyncSource{conf: map[string]any{"password": "vault://database/creds/app"}}}
+	c, err := New(WithSource(src), WithSecretProvider("vault", provider))
+	s.Require().NoError(err)
+	defer c.Close()
+
+	changes := make(chan string, 10)
+	c.OnChange(func(_, newValues map[string]any) {
+		changes <- newValues["password"].(string)
+	})
+
+	s.Require().NoError(c.Load(context.Background()))
+	s.Equal("credential-1", c.GetString("password"))
+	s.Equal("credential-1", <-changes)
+
+	select {
+	case password := <-changes:
+		s.Equal("credential-2", password)
+	case <-time.After(2 * time.Second):
+		s.Fail("the leased secret was not refreshed")
+	}
+	s.Equal(int32(1), src.loads.Load(), "the sources are not loaded again")
+}
+
+func (s *SecretLeaseTestSuite) TestReportsRefreshFailures() {
+	provider := &leasingProvider{ttl: 90 * time.Millisecond}
+	c, err := New(
+		WithSource(&mockSource{conf: map[string]any{"password": "vault://database/creds/app"}}),
+		WithSecretProvider("vault", provider),
+	)
+	s.Require().NoError(err)
+	defer c.Close()
+
+	refreshErr := errors.New("vault sealed")
+	failures := make(chan error, 10)
+	c.OnReloadError(func(err error) { failures <- err })
+
+	s.Require().NoError(c.Load(context.Background()))
+	provider.fail(refreshErr)
+
+	select {
+	case err := <-failures:
+		s.ErrorIs(err, refreshErr)
+	case <-time.After(2 * time.Second):
+		s.Fail("the failed refresh was not reported")
+	}
+	s.Equal("credential-1", c.GetString("password"), "the previous configuration is kept")
+}
+
+func (s *SecretLeaseTestSuite) TestNoRefreshWithoutLeases() {
+	c, err := New(
+		WithSource(&mockSource{conf: map[string]any{"password": "vault://secret"}}),
+		WithSecretProvider("vault", staticProvider("static")),
+	)
+	s.Require().NoError(err)
+	defer c.Close()
+
+	s.Require().NoError(c.Load(context.Background()))
+	c.secretLeaseMu.Lock()
+	defer c.secretLeaseMu.Unlock()
+	s.True(c.secretRefreshAt.IsZero())
+}
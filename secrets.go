@@ -0,0 +1,191 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SecretProvider fetches secrets from a secret store, such as Vault, AWS Secrets Manager or a proprietary store.
+// Resolve receives the whole reference, including its scheme, e.g. "vault://secret/data/db#password".
+type SecretProvider interface {
+	Resolve(ctx context.Context, ref string) (string, error)
+}
+
+// SecretProviderFunc adapts a function to the SecretProvider interface.
+type SecretProviderFunc func(ctx context.Context, ref string) (string, error)
+
+// Resolve calls f(ctx, ref).
+func (f SecretProviderFunc) Resolve(ctx context.Context, ref string) (string, error) {
+	return f(ctx, ref)
+}
+
+var (
+	secretProvidersMu sync.RWMutex
+	secretProviders   = make(map[string]SecretProvider)
+)
+
+// RegisterSecretProvider registers a provider for the given URL scheme for all Conflex instances, so a package can
+// plug a secret store in from its init function, the way database/sql drivers register themselves. A provider
+// registered on an instance with WithSecretProvider takes precedence. Registering a scheme again replaces its
+// provider; nil providers are ignored.
+func RegisterSecretProvider(scheme string, provider SecretProvider) {
+	if provider == nil {
+		return
+	}
+
+	secretProvidersMu.Lock()
+	defer secretProvidersMu.Unlock()
+
+	secretProviders[strings.ToLower(scheme)] = provider
+}
+
+// WithSecretProvider registers provider to resolve secret references with the given URL scheme, so secrets stay out
+// of configuration files entirely. When the configuration is loaded, every string value of the form "scheme://..."
+// with a registered scheme is replaced with the secret returned by the provider:
+//
+//	cfg, err := conflex.New(
+//		conflex.WithFileSource("config.yaml", codec.TypeYAML), // password: vault://secret/data/db#password
+//		conflex.WithSecretProvider("vault", vaultProvider),
+//	)
+//
+// Schemes are case-insensitive, and values with other schemes, such as https://, are left as they are. Each reference
+// is resolved once per load, after environment variables and key references are expanded and before validation.
+// Load fails if a secret cannot be resolved. Keys holding secrets should be classified with WithSensitivity.
+func WithSecretProvider(scheme string, provider SecretProvider) Option {
+	return func(c *Conflex) error {
+		scheme = strings.ToLower(scheme)
+		if scheme == "" || strings.ContainsAny(scheme, ":/") {
+			return fmt.Errorf("invalid secret scheme %q", scheme)
+		}
+		if provider == nil {
+			return errors.New("secret provider cannot be nil")
+		}
+		if _, ok := c.secretProviders[scheme]; ok {
+			return fmt.Errorf("secret provider for scheme %q already registered", scheme)
+		}
+		if c.secretProviders == nil {
+			c.secretProviders = make(map[string]SecretProvider)
+		}
+		c.secretProviders[scheme] = provider
+		return nil
+	}
+}
+
+// WithSecretResolver registers fn to resolve secret references with the given URL scheme.
+// It is a shorthand for WithSecretProvider(scheme, SecretProviderFunc(fn)).
+func WithSecretResolver(scheme string, fn func(ctx context.Context, ref string) (string, error)) Option {
+	if fn == nil {
+		return WithSecretProvider(scheme, nil)
+	}
+	return WithSecretProvider(scheme, SecretProviderFunc(fn))
+}
+
+// hasSecretProviders reports whether any secret provider applies to the instance.
+func (c *Conflex) hasSecretProviders() bool {
+	if len(c.secretProviders) > 0 {
+		return true
+	}
+
+	secretProvidersMu.RLock()
+	defer secretProvidersMu.RUnlock()
+
+	return len(secretProviders) > 0
+}
+
+// secretProvider returns the provider for the scheme of the reference s, if any.
+func (c *Conflex) secretProvider(s string) (SecretProvider, bool) {
+	scheme, _, ok := strings.Cut(s, "://")
+	if !ok {
+		return nil, false
+	}
+	scheme = strings.ToLower(scheme)
+	if provider, ok := c.secretProviders[scheme]; ok {
+		return provider, true
+	}
+
+	secretProvidersMu.RLock()
+	defer secretProvidersMu.RUnlock()
+
+	provider, ok := secretProviders[scheme]
+	return provider, ok
+}
+
+// resolveSecrets returns a copy of values with the secret references replaced with the secrets they refer to,
+// and the time until the first leased secret expires, or 0 if none does.
+func (c *Conflex) resolveSecrets(ctx context.Context, values map[string]any) (map[string]any, time.Duration, error) {
+	resolved := make(map[string]string)
+	var minTTL time.Duration
+
+	var walk func(key string, v any) (any, error)
+	walk = func(key string, v any) (any, error) {
+		switch val := v.(type) {
+		case string:
+			provider, ok := c.secretProvider(val)
+			if !ok {
+				return val, nil
+			}
+			if secret, ok := resolved[val]; ok {
+				return secret, nil
+			}
+			secret, ttl, err := resolveSecret(ctx, provider, val)
+			if err != nil {
+				return nil, NewConfigFieldError("secrets", key, "resolve", err)
+			}
+			if ttl > 0 && (minTTL == 0 || ttl < minTTL) {
+				minTTL = ttl
+			}
+			resolved[val] = secret
+			return secret, nil
+		case map[string]any:
+			out := make(map[string]any, len(val))
+			for k, item := range val {
+				itemKey := k
+				if key != "" {
+					itemKey = key + "." + k
+				}
+				resolvedItem, err := walk(itemKey, item)
+				if err != nil {
+					return nil, err
+				}
+				out[k] = resolvedItem
+			}
+			return out, nil
+		case []any:
+			out := make([]any, len(val))
+			for i, item := range val {
+				resolvedItem, err := walk(key+"."+strconv.Itoa(i), item)
+				if err != nil {
+					return nil, err
+				}
+				out[i] = resolvedItem
+			}
+			return out, nil
+		}
+		return v, nil
+	}
+
+	out, err := walk("", values)
+	if err != nil {
+		return nil, 0, err
+	}
+	return out.(map[string]any), minTTL, nil
+}
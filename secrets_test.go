@@ -0,0 +1,142 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type SecretsTestSuite struct {
+	suite.Suite
+}
+
+func TestSecretsTestSuite(t *testing.T) {
+	suite.Run(t, new(SecretsTestSuite))
+}
+
+// vaultStub resolves vault:// references from a map, counting the lookups.
+type vaultStub struct {
+	secrets map[string]string
+	calls   int
+}
+
+func (v *vaultStub) resolve(_ context.Context, ref string) (string, error) {
+	v.calls++
+	secret, ok := v.secrets[ref]
+	if !ok {
+		return "", errors.New("secret not found")
+	}
+	return secret, nil
+}
+
+func (s *SecretsTestSuite) TestResolves() {
+	vault := &vaultStub{secrets: map[string]string{
+		"vault://secret/data/db#password": "s3cret",
+		"VAULT://secret/data/api#token":   "t0ken",
+	}}
+	c, err := New(
+		WithSource(&mockSource{conf: map[string]any{
+			"database": map[string]any{
+				"password": "vault://secret/data/db#password",
+				"url":      "https://db.internal",
+			},
+			"replicas": []any{map[string]any{"password": "vault://secret/data/db#password"}},
+			"token":    "VAULT://secret/data/api#token",
+		}}),
+		WithSecretResolver("vault", vault.resolve),
+	)
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	s.Equal("s3cret", c.GetString("database.password"))
+	s.Equal("https://db.internal", c.GetString("database.url"))
+	s.Equal("s3cret", c.Get("replicas").([]any)[0].(map[string]any)["password"])
+	s.Equal("t0ken", c.GetString("token"))
+	s.Equal(2, vault.calls, "each reference is resolved once per load")
+}
+
+func (s *SecretsTestSuite) TestErrors() {
+	vault := &vaultStub{}
+	c, err := New(
+		WithSource(&mockSource{conf: map[string]any{"database": map[string]any{"password": "vault://missing"}}}),
+		WithSecretResolver("vault", vault.resolve),
+	)
+	s.Require().NoError(err)
+
+	err = c.Load(context.Background())
+	s.Require().Error(err)
+	s.Equal("config error in secrets.database.password during resolve: secret not found", err.Error())
+	s.Nil(c.Get("database"), "nothing is applied")
+}
+
+func (s *SecretsTestSuite) TestInvalidResolvers() {
+	resolve := func(context.Context, string) (string, error) { return "", nil }
+
+	_, err := NewStrict(WithSecretResolver("", resolve))
+	s.Error(err)
+	_, err = NewStrict(WithSecretResolver("vault://", resolve))
+	s.Error(err)
+	_, err = NewStrict(WithSecretResolver("vault", nil))
+	s.Error(err)
+	_, err = NewStrict(WithSecretResolver("vault", resolve), WithSecretResolver("Vault", resolve))
+	s.Error(err)
+}
+
+// staticProvider resolves every reference to the same secret.
+type staticProvider string
+
+func (p staticProvider) Resolve(context.Context, string) (string, error) {
+	return string(p), nil
+}
+
+func (s *SecretsTestSuite) TestWithSecretProvider() {
+	c, err := New(
+		WithSource(&mockSource{conf: map[string]any{"token": "corp://tokens/api"}}),
+		WithSecretProvider("corp", staticProvider("t0ken")),
+	)
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+	s.Equal("t0ken", c.GetString("token"))
+
+	_, err = NewStrict(WithSecretProvider("corp", nil))
+	s.Error(err)
+}
+
+func (s *SecretsTestSuite) TestRegisterSecretProvider() {
+	RegisterSecretProvider("Global", staticProvider("global"))
+	defer func() {
+		secretProvidersMu.Lock()
+		delete(secretProviders, "global")
+		secretProvidersMu.Unlock()
+	}()
+	RegisterSecretProvider("ignored", nil)
+
+	src := &mockSource{conf: map[string]any{"a": "global://a", "b": "ignored://b"}}
+	c, err := New(WithSource(src))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+	s.Equal("global", c.GetString("a"))
+	s.Equal("ignored://b", c.GetString("b"))
+
+	// A provider registered on the instance takes precedence.
+	c, err = New(WithSource(src), WithSecretProvider("global", staticProvider("local")))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+	s.Equal("local", c.GetString("a"))
+}
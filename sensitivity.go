@@ -0,0 +1,149 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"reflect"
+	"strings"
+)
+
+// RedactedValue replaces the values of secret keys wherever they are shown, such as in the changes returned by Diff.
+const RedactedValue = "***"
+
+// Sensitivity classifies how sensitive a configuration key is, and therefore where its value may be shown.
+type Sensitivity int
+
+const (
+	// SensitivityPublic marks values that may be shown anywhere. It is the default for unclassified keys.
+	SensitivityPublic Sensitivity = iota
+	// SensitivityInternal marks values that may be shown to operators but must not leave the organization.
+	SensitivityInternal
+	// SensitivitySecret marks values that must never be shown, such as passwords and tokens.
+	SensitivitySecret
+)
+
+// String returns the name of the sensitivity level.
+func (s Sensitivity) String() string {
+	switch s {
+	case SensitivityPublic:
+		return "public"
+	case SensitivityInternal:
+		return "internal"
+	case SensitivitySecret:
+		return "secret"
+	default:
+		return fmt.Sprintf("sensitivity(%d)", int(s))
+	}
+}
+
+// ParseSensitivity parses a sensitivity level name ("public", "internal" or "secret").
+func ParseSensitivity(name string) (Sensitivity, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "public":
+		return SensitivityPublic, nil
+	case "internal":
+		return SensitivityInternal, nil
+	case "secret":
+		return SensitivitySecret, nil
+	default:
+		return SensitivityPublic, fmt.Errorf("unknown sensitivity %q", name)
+	}
+}
+
+// sensitivityRule assigns a sensitivity level to all keys matching a pattern.
+type sensitivityRule struct {
+	pattern string
+	level   Sensitivity
+}
+
+// WithSensitivity classifies the keys matching the given patterns with the given sensitivity level.
+// Patterns are dot-separated key paths in which a "*" segment matches any single key segment,
+// e.g. "database.*.password". A pattern also covers everything nested below the keys it matches.
+// Fields of the bound struct can be classified with the "sensitivity" tag option instead,
+// e.g. `conflex:"password,sensitivity=secret"`, or marked as secret with `conflex:"password,secret"`.
+func WithSensitivity(level Sensitivity, patterns ...string) Option {
+	return func(c *Conflex) error {
+		for _, pattern := range patterns {
+			if pattern == "" {
+				return errors.New("sensitivity pattern cannot be empty")
+			}
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("invalid sensitivity pattern %q: %w", pattern, err)
+			}
+			c.sensitivityRules = append(c.sensitivityRules, sensitivityRule{pattern: pattern, level: level})
+		}
+		return nil
+	}
+}
+
+// Sensitivity returns the sensitivity level of the given key.
+// If several rules match the key, the most restrictive level wins. Unclassified keys are public.
+func (c *Conflex) Sensitivity(key string) Sensitivity {
+	if c == nil {
+		return SensitivityPublic
+	}
+
+	level := SensitivityPublic
+	normalized := c.normalizeKey(key)
+	for _, rule := range c.sensitivityRules {
+		if rule.level > level && matchKeyPattern(c.normalizeKey(rule.pattern), normalized) {
+			level = rule.level
+		}
+	}
+	return level
+}
+
+// sensitivityRulesFromTags returns the sensitivity rules declared with the "sensitivity" and "secret" tag options
+// on the fields of the struct type t, with key paths relative to prefix.
+func sensitivityRulesFromTags(t reflect.Type, prefix string) ([]sensitivityRule, error) {
+	var rules []sensitivityRule
+	var errs error
+	walkFields(t, prefix, func(path string, _ reflect.StructField, tag fieldTag) {
+		if tag.has("secret") {
+			rules = append(rules, sensitivityRule{pattern: path, level: SensitivitySecret})
+		}
+		name, ok := tag.options["sensitivity"]
+		if !ok {
+			return
+		}
+		level, err := ParseSensitivity(name)
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("field %s: %w", path, err))
+			return
+		}
+		rules = append(rules, sensitivityRule{pattern: path, level: level})
+	})
+	return rules, errs
+}
+
+// matchKeyPattern reports whether key matches pattern or is nested below a key matching it.
+// Both are dot-separated paths, with dots inside segments escaped as described for splitKey. Each pattern segment
+// is matched against the corresponding key segment using path.Match, so "*" matches any single segment.
+func matchKeyPattern(pattern, key string) bool {
+	patternSegments := splitKey(pattern)
+	keySegments := splitKey(key)
+	if len(keySegments) < len(patternSegments) {
+		return false
+	}
+	for i, segment := range patternSegments {
+		if ok, err := path.Match(segment, keySegments[i]); err != nil || !ok {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,128 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type SensitivityTestSuite struct {
+	suite.Suite
+}
+
+func TestSensitivityTestSuite(t *testing.T) {
+	suite.Run(t, new(SensitivityTestSuite))
+}
+
+func (s *SensitivityTestSuite) TestWithSensitivity() {
+	c, err := New(
+		WithSensitivity(SensitivityInternal, "database"),
+		WithSensitivity(SensitivitySecret, "database.*.password", "Auth.JWT.Secret"),
+	)
+	s.Require().NoError(err)
+
+	s.Equal(SensitivityPublic, c.Sensitivity("server.port"))
+	s.Equal(SensitivityInternal, c.Sensitivity("database.primary.host"))
+	s.Equal(SensitivitySecret, c.Sensitivity("database.primary.password"))
+	s.Equal(SensitivitySecret, c.Sensitivity("DATABASE.Replica.Password"))
+	s.Equal(SensitivitySecret, c.Sensitivity("auth.jwt.secret"))
+	s.Equal(SensitivitySecret, c.Sensitivity("auth.jwt.secret.current"))
+	s.Equal(SensitivityPublic, c.Sensitivity("auth.jwt"))
+}
+
+func (s *SensitivityTestSuite) TestSensitivity_EscapedDots() {
+	c, err := New(
+		WithSource(&mockSource{conf: map[string]any{"labels": map[string]any{"app.kubernetes.io/token": "abc", "app": map[string]any{"kubernetes": "x"}}}}),
+		WithSensitivity(SensitivitySecret, `labels.app\.kubernetes\.io/token`),
+	)
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	s.Equal(SensitivitySecret, c.Sensitivity(`labels.app\.kubernetes\.io/token`))
+	s.Equal(SensitivityPublic, c.Sensitivity("labels.app.kubernetes"))
+	s.Equal(map[string]any{"labels": map[string]any{"app.kubernetes.io/token": RedactedValue, "app": map[string]any{"kubernetes": "x"}}},
+		c.SafeValues())
+}
+
+func (s *SensitivityTestSuite) TestWithSensitivity_InvalidPattern() {
+	_, err := New(WithSensitivity(SensitivitySecret, ""))
+	s.Error(err)
+
+	_, err = New(WithSensitivity(SensitivitySecret, "database.[.password"))
+	s.Error(err)
+}
+
+func (s *SensitivityTestSuite) TestSensitivity_FromTags() {
+	type credentials struct {
+		User     string `conflex:"user"`
+		Password string `conflex:"password,sensitivity=secret"`
+	}
+	type config struct {
+		Host     string      `conflex:"host,sensitivity=internal"`
+		Database credentials `conflex:"database"`
+	}
+
+	var cfg config
+	c, err := New(WithBinding(&cfg))
+	s.Require().NoError(err)
+
+	s.Equal(SensitivityInternal, c.Sensitivity("host"))
+	s.Equal(SensitivityPublic, c.Sensitivity("database.user"))
+	s.Equal(SensitivitySecret, c.Sensitivity("database.password"))
+}
+
+func (s *SensitivityTestSuite) TestSensitivity_SecretTag() {
+	type config struct {
+		Token  string `conflex:"token,secret"`
+		APIKey string `conflex:"api_key,secret,sensitivity=internal"`
+	}
+
+	var cfg config
+	c, err := New(WithBinding(&cfg))
+	s.Require().NoError(err)
+
+	s.Equal(SensitivitySecret, c.Sensitivity("token"))
+	s.Equal(SensitivitySecret, c.Sensitivity("api_key"), "the most restrictive level wins")
+}
+
+func (s *SensitivityTestSuite) TestSensitivity_InvalidTag() {
+	type config struct {
+		Host string `conflex:"host,sensitivity=top-secret"`
+	}
+
+	var cfg config
+	_, err := New(WithBinding(&cfg))
+	s.Error(err)
+	s.Contains(err.Error(), "unknown sensitivity")
+}
+
+func (s *SensitivityTestSuite) TestParseSensitivity() {
+	for _, level := range []Sensitivity{SensitivityPublic, SensitivityInternal, SensitivitySecret} {
+		parsed, err := ParseSensitivity(level.String())
+		s.NoError(err)
+		s.Equal(level, parsed)
+	}
+	_, err := ParseSensitivity("unknown")
+	s.Error(err)
+	s.Equal("sensitivity(7)", Sensitivity(7).String())
+}
+
+func (s *SensitivityTestSuite) TestSensitivity_NilInstance() {
+	var c *Conflex
+	s.Equal(SensitivityPublic, c.Sensitivity("any"))
+}
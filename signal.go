@@ -0,0 +1,58 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// ReloadOnSignal reloads the configuration of c whenever the process receives one of the given signals.
+// If no signals are given, it listens for SIGHUP, the conventional signal for asking a daemon to reload its configuration.
+// The outcome of every reload is logged with the logger configured by WithLogger.
+// If a reload fails, the previous configuration stays in effect and the failure is reported to the handlers
+// registered with OnReloadError.
+// ReloadOnSignal blocks until ctx is cancelled, in which case it returns nil.
+func ReloadOnSignal(ctx context.Context, c *Conflex, sigs ...os.Signal) error {
+	if ctx == nil {
+		return errors.New("context cannot be nil")
+	}
+	if c == nil {
+		return errors.New("conflex instance is nil")
+	}
+	if len(sigs) == 0 {
+		sigs = []os.Signal{syscall.SIGHUP}
+	}
+
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, sigs...)
+	defer signal.Stop(ch)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case sig := <-ch:
+			if err := c.reload(ctx); err != nil {
+				c.log().Error("configuration reload failed", "signal", sig.String(), "error", err)
+				continue
+			}
+			c.log().Info("configuration reloaded", "signal", sig.String())
+		}
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// syncBuffer is a bytes.Buffer that can be written and read concurrently.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+type SignalTestSuite struct {
+	suite.Suite
+	guard chan os.Signal
+}
+
+func TestSignalTestSuite(t *testing.T) {
+	suite.Run(t, new(SignalTestSuite))
+}
+
+func (s *SignalTestSuite) SetupTest() {
+	// Keep SIGHUP from terminating the test process before ReloadOnSignal has subscribed to it.
+	s.guard = make(chan os.Signal, 1)
+	signal.Notify(s.guard, syscall.SIGHUP)
+}
+
+func (s *SignalTestSuite) TearDownTest() {
+	signal.Stop(s.guard)
+}
+
+func (s *SignalTestSuite) sendSignal() {
+	p, err := os.FindProcess(os.Getpid())
+	s.Require().NoError(err)
+	s.Require().NoError(p.Signal(syscall.SIGHUP))
+}
+
+func (s *SignalTestSuite) TestReloadOnSignal() {
+	src := &mockSyncSource{conf: map[string]any{"foo": "bar"}}
+	logs := &syncBuffer{}
+	c, err := New(WithSource(src), WithLogger(slog.New(slog.NewTextHandler(logs, nil))))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- ReloadOnSignal(ctx, c)
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	src.set(map[string]any{"foo": "baz"}, nil)
+	s.sendSignal()
+	s.Eventually(func() bool { return c.GetString("foo") == "baz" }, 2*time.Second, 10*time.Millisecond)
+	s.Eventually(func() bool { return strings.Contains(logs.String(), "configuration reloaded") }, 2*time.Second, 10*time.Millisecond)
+
+	src.set(nil, errors.New("source unavailable"))
+	s.sendSignal()
+	s.Eventually(func() bool { return strings.Contains(logs.String(), "configuration reload failed") }, 2*time.Second, 10*time.Millisecond)
+	s.Equal("baz", c.GetString("foo"))
+
+	cancel()
+	s.NoError(<-done)
+}
+
+func (s *SignalTestSuite) TestReloadOnSignal_InvalidArguments() {
+	c, err := New()
+	s.Require().NoError(err)
+
+	s.Error(ReloadOnSignal(nil, c))
+	s.Error(ReloadOnSignal(context.Background(), nil))
+}
+
+func (s *SignalTestSuite) TestWithLogger_Nil() {
+	_, err := New(WithLogger(nil))
+	s.Error(err)
+	s.Contains(err.Error(), "logger cannot be nil")
+}
@@ -0,0 +1,326 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import "time"
+
+// Snapshot is an immutable copy of the configuration at one point in time, created with Conflex.Snapshot.
+// Later loads and reloads do not affect it, so a request handler can capture a snapshot when the request
+// starts and read consistent values for the lifetime of the request, even if the configuration changes.
+//
+// Snapshot is a small value type that is safe to copy and to use from multiple goroutines.
+// The zero Snapshot is empty and has version 0.
+type Snapshot struct {
+	c       *Conflex
+	version uint64
+}
+
+// Snapshot returns a snapshot of the current configuration. Taking a snapshot does not copy the values:
+// loads replace the values of the instance rather than modifying them, so the snapshot keeps seeing the
+// values that were current when it was taken. Maps and lists read from the snapshot are deep copies, so
+// neither the snapshot nor the instance can be modified through them.
+func (c *Conflex) Snapshot() Snapshot {
+	if c == nil {
+		return Snapshot{}
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return Snapshot{
+		c: &Conflex{
+			values:            c.values,
+			keyOrigins:        c.keyOrigins,
+			caseSensitiveKeys: c.caseSensitiveKeys,
+			sliceSeparator:    c.sliceSeparator,
+			loaded:            c.loaded,
+			frozen:            true,
+		},
+		version: c.version,
+	}
+}
+
+// Version returns the version of the configuration the snapshot was taken from. The version starts at 0
+// before the first Load and is incremented every time a load or reload applies new values, so two
+// snapshots with the same version hold the same values.
+func (s Snapshot) Version() uint64 {
+	return s.version
+}
+
+// AllKeys returns the dot-separated path of every leaf value in the snapshot, in sorted order.
+// See Conflex.AllKeys.
+func (s Snapshot) AllKeys() []string {
+	return s.c.AllKeys()
+}
+
+// AllSettings returns a deep copy of the values in the snapshot. See Conflex.AllSettings.
+func (s Snapshot) AllSettings() map[string]any {
+	return s.c.AllSettings()
+}
+
+// Origin returns where the value of key in the snapshot came from. See Conflex.Origin.
+func (s Snapshot) Origin(key string) (string, bool) {
+	return s.c.Origin(key)
+}
+
+// Sub returns a view of the snapshot below key. See Conflex.Sub.
+func (s Snapshot) Sub(key string) *View {
+	return s.c.Sub(key)
+}
+
+// Bind decodes the snapshot into target, which must be a pointer, like View.Bind does for its subtree.
+func (s Snapshot) Bind(target any) error {
+	return s.Sub("").Bind(target)
+}
+
+// IsSet reports whether the snapshot has a value for key.
+func (s Snapshot) IsSet(key string) bool {
+	return s.Get(key) != nil
+}
+
+// Get is like Conflex.Get, reading from the snapshot.
+func (s Snapshot) Get(key string) any {
+	return s.c.Get(key)
+}
+
+// GetMany is like Conflex.GetMany, reading from the snapshot.
+func (s Snapshot) GetMany(keys ...string) map[string]any {
+	return s.c.GetMany(keys...)
+}
+
+// GetManyE is like Conflex.GetManyE, reading from the snapshot.
+func (s Snapshot) GetManyE(keys ...string) (map[string]any, error) {
+	return s.c.GetManyE(keys...)
+}
+
+// GetString is like Conflex.GetString, reading from the snapshot.
+func (s Snapshot) GetString(key string) string {
+	return s.c.GetString(key)
+}
+
+// GetStringE is like Conflex.GetStringE, reading from the snapshot.
+func (s Snapshot) GetStringE(key string) (string, error) {
+	return s.c.GetStringE(key)
+}
+
+// GetBool is like Conflex.GetBool, reading from the snapshot.
+func (s Snapshot) GetBool(key string) bool {
+	return s.c.GetBool(key)
+}
+
+// GetBoolE is like Conflex.GetBoolE, reading from the snapshot.
+func (s Snapshot) GetBoolE(key string) (bool, error) {
+	return s.c.GetBoolE(key)
+}
+
+// GetInt is like Conflex.GetInt, reading from the snapshot.
+func (s Snapshot) GetInt(key string) int {
+	return s.c.GetInt(key)
+}
+
+// GetIntE is like Conflex.GetIntE, reading from the snapshot.
+func (s Snapshot) GetIntE(key string) (int, error) {
+	return s.c.GetIntE(key)
+}
+
+// GetInt32 is like Conflex.GetInt32, reading from the snapshot.
+func (s Snapshot) GetInt32(key string) int32 {
+	return s.c.GetInt32(key)
+}
+
+// GetInt32E is like Conflex.GetInt32E, reading from the snapshot.
+func (s Snapshot) GetInt32E(key string) (int32, error) {
+	return s.c.GetInt32E(key)
+}
+
+// GetInt64 is like Conflex.GetInt64, reading from the snapshot.
+func (s Snapshot) GetInt64(key string) int64 {
+	return s.c.GetInt64(key)
+}
+
+// GetInt64E is like Conflex.GetInt64E, reading from the snapshot.
+func (s Snapshot) GetInt64E(key string) (int64, error) {
+	return s.c.GetInt64E(key)
+}
+
+// GetUint8 is like Conflex.GetUint8, reading from the snapshot.
+func (s Snapshot) GetUint8(key string) uint8 {
+	return s.c.GetUint8(key)
+}
+
+// GetUint8E is like Conflex.GetUint8E, reading from the snapshot.
+func (s Snapshot) GetUint8E(key string) (uint8, error) {
+	return s.c.GetUint8E(key)
+}
+
+// GetUint is like Conflex.GetUint, reading from the snapshot.
+func (s Snapshot) GetUint(key string) uint {
+	return s.c.GetUint(key)
+}
+
+// GetUintE is like Conflex.GetUintE, reading from the snapshot.
+func (s Snapshot) GetUintE(key string) (uint, error) {
+	return s.c.GetUintE(key)
+}
+
+// GetUint16 is like Conflex.GetUint16, reading from the snapshot.
+func (s Snapshot) GetUint16(key string) uint16 {
+	return s.c.GetUint16(key)
+}
+
+// GetUint16E is like Conflex.GetUint16E, reading from the snapshot.
+func (s Snapshot) GetUint16E(key string) (uint16, error) {
+	return s.c.GetUint16E(key)
+}
+
+// GetUint32 is like Conflex.GetUint32, reading from the snapshot.
+func (s Snapshot) GetUint32(key string) uint32 {
+	return s.c.GetUint32(key)
+}
+
+// GetUint32E is like Conflex.GetUint32E, reading from the snapshot.
+func (s Snapshot) GetUint32E(key string) (uint32, error) {
+	return s.c.GetUint32E(key)
+}
+
+// GetUint64 is like Conflex.GetUint64, reading from the snapshot.
+func (s Snapshot) GetUint64(key string) uint64 {
+	return s.c.GetUint64(key)
+}
+
+// GetUint64E is like Conflex.GetUint64E, reading from the snapshot.
+func (s Snapshot) GetUint64E(key string) (uint64, error) {
+	return s.c.GetUint64E(key)
+}
+
+// GetFloat64 is like Conflex.GetFloat64, reading from the snapshot.
+func (s Snapshot) GetFloat64(key string) float64 {
+	return s.c.GetFloat64(key)
+}
+
+// GetFloat64E is like Conflex.GetFloat64E, reading from the snapshot.
+func (s Snapshot) GetFloat64E(key string) (float64, error) {
+	return s.c.GetFloat64E(key)
+}
+
+// GetTime is like Conflex.GetTime, reading from the snapshot.
+func (s Snapshot) GetTime(key string) time.Time {
+	return s.c.GetTime(key)
+}
+
+// GetTimeE is like Conflex.GetTimeE, reading from the snapshot.
+func (s Snapshot) GetTimeE(key string) (time.Time, error) {
+	return s.c.GetTimeE(key)
+}
+
+// GetDuration is like Conflex.GetDuration, reading from the snapshot.
+func (s Snapshot) GetDuration(key string) time.Duration {
+	return s.c.GetDuration(key)
+}
+
+// GetDurationE is like Conflex.GetDurationE, reading from the snapshot.
+func (s Snapshot) GetDurationE(key string) (time.Duration, error) {
+	return s.c.GetDurationE(key)
+}
+
+// GetSizeInBytes is like Conflex.GetSizeInBytes, reading from the snapshot.
+func (s Snapshot) GetSizeInBytes(key string) int64 {
+	return s.c.GetSizeInBytes(key)
+}
+
+// GetSizeInBytesE is like Conflex.GetSizeInBytesE, reading from the snapshot.
+func (s Snapshot) GetSizeInBytesE(key string) (int64, error) {
+	return s.c.GetSizeInBytesE(key)
+}
+
+// GetIntSlice is like Conflex.GetIntSlice, reading from the snapshot.
+func (s Snapshot) GetIntSlice(key string) []int {
+	return s.c.GetIntSlice(key)
+}
+
+// GetIntSliceE is like Conflex.GetIntSliceE, reading from the snapshot.
+func (s Snapshot) GetIntSliceE(key string) ([]int, error) {
+	return s.c.GetIntSliceE(key)
+}
+
+// GetStringSlice is like Conflex.GetStringSlice, reading from the snapshot.
+func (s Snapshot) GetStringSlice(key string) []string {
+	return s.c.GetStringSlice(key)
+}
+
+// GetStringSliceE is like Conflex.GetStringSliceE, reading from the snapshot.
+func (s Snapshot) GetStringSliceE(key string) ([]string, error) {
+	return s.c.GetStringSliceE(key)
+}
+
+// GetStringMap is like Conflex.GetStringMap, reading from the snapshot.
+func (s Snapshot) GetStringMap(key string) map[string]any {
+	return s.c.GetStringMap(key)
+}
+
+// GetStringMapE is like Conflex.GetStringMapE, reading from the snapshot.
+func (s Snapshot) GetStringMapE(key string) (map[string]any, error) {
+	return s.c.GetStringMapE(key)
+}
+
+// GetStringMapString is like Conflex.GetStringMapString, reading from the snapshot.
+func (s Snapshot) GetStringMapString(key string) map[string]string {
+	return s.c.GetStringMapString(key)
+}
+
+// GetStringMapStringE is like Conflex.GetStringMapStringE, reading from the snapshot.
+func (s Snapshot) GetStringMapStringE(key string) (map[string]string, error) {
+	return s.c.GetStringMapStringE(key)
+}
+
+// GetStringMapStringSlice is like Conflex.GetStringMapStringSlice, reading from the snapshot.
+func (s Snapshot) GetStringMapStringSlice(key string) map[string][]string {
+	return s.c.GetStringMapStringSlice(key)
+}
+
+// GetStringMapStringSliceE is like Conflex.GetStringMapStringSliceE, reading from the snapshot.
+func (s Snapshot) GetStringMapStringSliceE(key string) (map[string][]string, error) {
+	return s.c.GetStringMapStringSliceE(key)
+}
+
+// GetStringMapInt is like Conflex.GetStringMapInt, reading from the snapshot.
+func (s Snapshot) GetStringMapInt(key string) map[string]int {
+	return s.c.GetStringMapInt(key)
+}
+
+// GetStringMapIntE is like Conflex.GetStringMapIntE, reading from the snapshot.
+func (s Snapshot) GetStringMapIntE(key string) (map[string]int, error) {
+	return s.c.GetStringMapIntE(key)
+}
+
+// GetStringMapBool is like Conflex.GetStringMapBool, reading from the snapshot.
+func (s Snapshot) GetStringMapBool(key string) map[string]bool {
+	return s.c.GetStringMapBool(key)
+}
+
+// GetStringMapBoolE is like Conflex.GetStringMapBoolE, reading from the snapshot.
+func (s Snapshot) GetStringMapBoolE(key string) (map[string]bool, error) {
+	return s.c.GetStringMapBoolE(key)
+}
+
+// GetStringMapDuration is like Conflex.GetStringMapDuration, reading from the snapshot.
+func (s Snapshot) GetStringMapDuration(key string) map[string]time.Duration {
+	return s.c.GetStringMapDuration(key)
+}
+
+// GetStringMapDurationE is like Conflex.GetStringMapDurationE, reading from the snapshot.
+func (s Snapshot) GetStringMapDurationE(key string) (map[string]time.Duration, error) {
+	return s.c.GetStringMapDurationE(key)
+}
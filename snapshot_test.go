@@ -0,0 +1,147 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type SnapshotTestSuite struct {
+	suite.Suite
+}
+
+func TestSnapshotTestSuite(t *testing.T) {
+	suite.Run(t, new(SnapshotTestSuite))
+}
+
+func (s *SnapshotTestSuite) TestDecoupledFromReloads() {
+	src := &mockSyncSource{conf: map[string]any{"server": map[string]any{"port": 8080, "host": "a"}}}
+	c, err := New(WithSource(src))
+	s.Require().NoError(err)
+	s.Equal(uint64(0), c.Snapshot().Version())
+
+	s.Require().NoError(c.Load(context.Background()))
+	snap := c.Snapshot()
+	s.Equal(uint64(1), snap.Version())
+
+	src.set(map[string]any{"server": map[string]any{"port": 9090}}, nil)
+	s.Require().NoError(c.Load(context.Background()))
+
+	s.Equal(9090, c.GetInt("server.port"))
+	s.Equal(8080, snap.GetInt("server.port"))
+	s.Equal("a", snap.Sub("server").GetString("host"))
+	s.True(snap.IsSet("server.host"))
+	s.Nil(c.Get("server.host"))
+	s.Equal([]string{"server.host", "server.port"}, snap.AllKeys())
+	s.Equal(uint64(1), snap.Version())
+	s.Equal(uint64(2), c.Snapshot().Version())
+
+	origin, ok := snap.Origin("server.host")
+	s.True(ok)
+	s.Equal("source[0]", origin)
+}
+
+func (s *SnapshotTestSuite) TestFailedLoadKeepsVersion() {
+	src := &mockSyncSource{conf: map[string]any{"port": 8080}}
+	c, err := New(WithSource(src))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	src.set(nil, errors.New("unavailable"))
+	s.Error(c.Load(context.Background()))
+	s.Equal(uint64(1), c.Snapshot().Version())
+}
+
+func (s *SnapshotTestSuite) TestAllSettingsIsACopy() {
+	c, err := New(WithSource(&mockSource{conf: map[string]any{"db": map[string]any{"host": "a"}}}))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	snap := c.Snapshot()
+	settings := snap.AllSettings()
+	settings["db"].(map[string]any)["host"] = "b"
+	s.Equal("a", snap.GetString("db.host"))
+}
+
+func (s *SnapshotTestSuite) TestReadsAreCopies() {
+	c, err := New(WithSource(&mockSource{conf: map[string]any{
+		"db": map[string]any{"host": "a", "replicas": []any{"r1"}},
+	}}))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	snap := c.Snapshot()
+	other := c.Snapshot()
+	snap.Get("db").(map[string]any)["host"] = "b"
+	snap.Get("db.replicas").([]any)[0] = "r2"
+	snap.GetStringMap("db")["host"] = "c"
+	snap.GetMany("db")["db"].(map[string]any)["host"] = "d"
+	snap.Sub("db").Values()["host"] = "e"
+
+	for _, values := range []interface{ GetString(string) string }{snap, other, c} {
+		s.Equal("a", values.GetString("db.host"))
+	}
+	s.Equal([]string{"r1"}, snap.GetStringSlice("db.replicas"))
+}
+
+func (s *SnapshotTestSuite) TestBind() {
+	c, err := New(WithSource(&mockSource{conf: map[string]any{"host": "db", "port": 5432}}))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	var cfg subDatabaseConfig
+	s.Require().NoError(c.Snapshot().Bind(&cfg))
+	s.Equal("db", cfg.Host)
+	s.Equal(5432, cfg.Port)
+}
+
+func (s *SnapshotTestSuite) TestZeroValue() {
+	var snap Snapshot
+	s.Equal(uint64(0), snap.Version())
+	s.Nil(snap.Get("a"))
+	s.Empty(snap.AllKeys())
+	s.Empty(snap.AllSettings())
+	_, err := snap.GetStringE("a")
+	s.Error(err)
+}
+
+func (s *SnapshotTestSuite) TestConcurrentReads() {
+	src := &mockSyncSource{conf: map[string]any{"n": 1}}
+	c, err := New(WithSource(src))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				snap := c.Snapshot()
+				s.Positive(snap.GetInt("n"))
+			}
+		}()
+	}
+	for i := 2; i < 20; i++ {
+		src.set(map[string]any{"n": i}, nil)
+		s.Require().NoError(c.Load(context.Background()))
+	}
+	wg.Wait()
+}
@@ -28,3 +28,33 @@ type Watcher interface {
 	// Watch starts watching for changes to configuration data.
 	Watch(ctx context.Context) error
 }
+
+// ChangeNotifier is implemented by sources that can push change notifications instead of being polled,
+// for example a source backed by Kubernetes informers or a Consul blocking query.
+// While Watch runs, every value received from the channel triggers a reload, debounced like file events.
+type ChangeNotifier interface {
+	// Changes returns a channel that receives a value whenever the data of the source may have changed.
+	// The source must stop sending once ctx is done; it may close the channel when it has nothing more to report.
+	Changes(ctx context.Context) (<-chan struct{}, error)
+}
+
+// changeErrorReporter is implemented by change notifiers that may close their channel because of an error, such
+// as source.Consul. Watch reports a non-nil ChangesErr to the OnWatchError handlers once the channel is closed.
+type changeErrorReporter interface {
+	ChangesErr() error
+}
+
+// changeCapability is implemented by sources that wrap another source and implement ChangeNotifier whatever
+// source they wrap, such as source.Cached. Watch only counts them as notifiers if NotifiesChanges returns true.
+type changeCapability interface {
+	NotifiesChanges() bool
+}
+
+// notifiesChanges reports whether src sends change notifications.
+func notifiesChanges(src Source) bool {
+	if _, ok := src.(ChangeNotifier); !ok {
+		return false
+	}
+	capability, ok := src.(changeCapability)
+	return !ok || capability.NotifiesChanges()
+}
@@ -0,0 +1,166 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package source
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// Cached is a source that wraps another source and reuses its last result for a time to live, so frequent
+// reloads across many instances do not overload a remote store such as Consul or Vault. See WithCache.
+type Cached struct {
+	source Loader
+	ttl    time.Duration
+	now    func() time.Time
+
+	mu       sync.Mutex
+	config   map[string]any
+	loadedAt time.Time
+}
+
+// WithCache returns a source that loads source at most once per ttl: Loads within ttl of the last successful
+// load return a copy of its result without calling source. Concurrent Loads of an expired cache wait for a
+// single load of source instead of each calling it. Failed loads are not cached, so the next Load tries again.
+//
+// The cache is meant for remote sources. Change notifications of the wrapped source, such as those of a Consul
+// source, are forwarded and drop the cached result (see Changes). Wrapping a file source hides its path from
+// Watch, since changes to the file would not be seen before the cache expires anyway.
+func WithCache(source Loader, ttl time.Duration) (*Cached, error) {
+	if source == nil {
+		return nil, errors.New("source cannot be nil")
+	}
+	if ttl <= 0 {
+		return nil, errors.New("cache ttl must be positive")
+	}
+	return &Cached{source: source, ttl: ttl, now: time.Now}, nil
+}
+
+// Load returns the cached result if it is younger than the time to live, and loads the wrapped source otherwise.
+func (c *Cached) Load(ctx context.Context) (map[string]any, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.config != nil && c.now().Sub(c.loadedAt) < c.ttl {
+		return copyCachedMap(c.config), nil
+	}
+
+	config, err := c.source.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if config == nil {
+		config = map[string]any{}
+	}
+	c.config = copyCachedMap(config)
+	c.loadedAt = c.now()
+	return config, nil
+}
+
+// changeNotifier is implemented by sources that push change notifications, like conflex.ChangeNotifier.
+type changeNotifier interface {
+	Changes(ctx context.Context) (<-chan struct{}, error)
+}
+
+// NotifiesChanges reports whether the wrapped source sends change notifications. Watch only subscribes to the
+// Changes of a cache whose wrapped source does, so a cache around a source without them is not mistaken for a
+// watchable source.
+func (c *Cached) NotifiesChanges() bool {
+	if capability, ok := c.source.(interface{ NotifiesChanges() bool }); ok && !capability.NotifiesChanges() {
+		return false
+	}
+	_, ok := c.source.(changeNotifier)
+	return ok
+}
+
+// ChangesErr returns the error that made the wrapped source close its changes channel, if it reports one.
+func (c *Cached) ChangesErr() error {
+	if reporter, ok := c.source.(interface{ ChangesErr() error }); ok {
+		return reporter.ChangesErr()
+	}
+	return nil
+}
+
+// Changes forwards the change notifications of the wrapped source, if it sends any, dropping the cached result
+// before each one, so a reload triggered by a change sees the new data. For other sources, the returned channel
+// is closed right away; see NotifiesChanges.
+func (c *Cached) Changes(ctx context.Context) (<-chan struct{}, error) {
+	notifier, ok := c.source.(changeNotifier)
+	if !ok {
+		changes := make(chan struct{})
+		close(changes)
+		return changes, nil
+	}
+
+	changes, err := notifier.Changes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make(chan struct{})
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case _, ok := <-changes:
+				if !ok {
+					return
+				}
+				c.Invalidate()
+				select {
+				case out <- struct{}{}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return out, nil
+}
+
+// Invalidate drops the cached result, so the next Load loads the wrapped source.
+func (c *Cached) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.config = nil
+}
+
+// copyCachedMap returns a deep copy of m, so callers cannot modify the cached result.
+func copyCachedMap(m map[string]any) map[string]any {
+	out := make(map[string]any, len(m))
+	for k, v := range m {
+		out[k] = copyCachedValue(v)
+	}
+	return out
+}
+
+func copyCachedValue(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		return copyCachedMap(val)
+	case []any:
+		out := make([]any, len(val))
+		for i, item := range val {
+			out[i] = copyCachedValue(item)
+		}
+		return out
+	default:
+		return v
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package source
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// countingLoader counts its loads and returns a fresh copy of config, or err.
+type countingLoader struct {
+	loads  atomic.Int32
+	delay  time.Duration
+	config map[string]any
+	err    error
+}
+
+func (l *countingLoader) Load(context.Context) (map[string]any, error) {
+	l.loads.Add(1)
+	time.Sleep(l.delay)
+	if l.err != nil {
+		return nil, l.err
+	}
+	return copyCachedMap(l.config), nil
+}
+
+type CachedSourceTestSuite struct {
+	suite.Suite
+	loader *countingLoader
+	now    time.Time
+	cached *Cached
+}
+
+func TestCachedSourceTestSuite(t *testing.T) {
+	suite.Run(t, new(CachedSourceTestSuite))
+}
+
+func (s *CachedSourceTestSuite) SetupTest() {
+	s.loader = &countingLoader{config: map[string]any{"server": map[string]any{"port": 8080}, "hosts": []any{"a"}}}
+	cached, err := WithCache(s.loader, time.Minute)
+	s.Require().NoError(err)
+	s.now = time.Now()
+	cached.now = func() time.Time { return s.now }
+	s.cached = cached
+}
+
+func (s *CachedSourceTestSuite) TestWithCache_Invalid() {
+	_, err := WithCache(nil, time.Minute)
+	s.ErrorContains(err, "source cannot be nil")
+
+	_, err = WithCache(s.loader, 0)
+	s.ErrorContains(err, "cache ttl must be positive")
+}
+
+func (s *CachedSourceTestSuite) TestLoad() {
+	config, err := s.cached.Load(context.Background())
+	s.Require().NoError(err)
+	s.Equal(s.loader.config, config)
+
+	// Modifying a result does not affect the cache.
+	config["server"].(map[string]any)["port"] = 9090
+	config["hosts"].([]any)[0] = "b"
+
+	s.now = s.now.Add(59 * time.Second)
+	config, err = s.cached.Load(context.Background())
+	s.Require().NoError(err)
+	s.Equal(s.loader.config, config)
+	s.Equal(int32(1), s.loader.loads.Load())
+
+	s.now = s.now.Add(time.Second)
+	_, err = s.cached.Load(context.Background())
+	s.Require().NoError(err)
+	s.Equal(int32(2), s.loader.loads.Load())
+}
+
+func (s *CachedSourceTestSuite) TestLoad_ErrorNotCached() {
+	s.loader.err = errors.New("connection refused")
+	_, err := s.cached.Load(context.Background())
+	s.ErrorContains(err, "connection refused")
+
+	s.loader.err = nil
+	_, err = s.cached.Load(context.Background())
+	s.Require().NoError(err)
+	s.Equal(int32(2), s.loader.loads.Load())
+}
+
+func (s *CachedSourceTestSuite) TestInvalidate() {
+	_, err := s.cached.Load(context.Background())
+	s.Require().NoError(err)
+	s.cached.Invalidate()
+	_, err = s.cached.Load(context.Background())
+	s.Require().NoError(err)
+	s.Equal(int32(2), s.loader.loads.Load())
+}
+
+func (s *CachedSourceTestSuite) TestLoad_Concurrent() {
+	s.loader.delay = 20 * time.Millisecond
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := s.cached.Load(context.Background())
+			s.NoError(err)
+		}()
+	}
+	wg.Wait()
+
+	s.Equal(int32(1), s.loader.loads.Load())
+}
+
+// notifyingLoader is a countingLoader that also sends change notifications.
+type notifyingLoader struct {
+	countingLoader
+	changes chan struct{}
+}
+
+func (l *notifyingLoader) Changes(context.Context) (<-chan struct{}, error) {
+	return l.changes, nil
+}
+
+func (s *CachedSourceTestSuite) TestChanges() {
+	loader := &notifyingLoader{countingLoader: countingLoader{config: map[string]any{"foo": "bar"}}, changes: make(chan struct{})}
+	cached, err := WithCache(loader, time.Hour)
+	s.Require().NoError(err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.True(cached.NotifiesChanges())
+	changes, err := cached.Changes(ctx)
+	s.Require().NoError(err)
+
+	_, err = cached.Load(context.Background())
+	s.Require().NoError(err)
+	loader.changes <- struct{}{}
+	select {
+	case <-changes:
+	case <-time.After(time.Second):
+		s.Fail("expected change notification")
+	}
+	_, err = cached.Load(context.Background())
+	s.Require().NoError(err)
+	s.Equal(int32(2), loader.loads.Load())
+
+	close(loader.changes)
+	select {
+	case _, ok := <-changes:
+		s.False(ok)
+	case <-time.After(time.Second):
+		s.Fail("expected closed channel")
+	}
+}
+
+func (s *CachedSourceTestSuite) TestChanges_NotNotifier() {
+	s.False(s.cached.NotifiesChanges())
+	nested, err := WithCache(s.cached, time.Hour)
+	s.Require().NoError(err)
+	s.False(nested.NotifiesChanges(), "a cache of a cache is only a notifier if the inner cache is one")
+
+	changes, err := s.cached.Changes(context.Background())
+	s.Require().NoError(err)
+	_, ok := <-changes
+	s.False(ok)
+}
@@ -0,0 +1,192 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package source
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrChaos is returned by Chaos for injected load failures.
+var ErrChaos = errors.New("chaos: injected failure")
+
+// Loader is implemented by every configuration source, including the sources in this package.
+type Loader interface {
+	Load(ctx context.Context) (map[string]any, error)
+}
+
+// ChaosConfig configures the faults injected by a Chaos source.
+type ChaosConfig struct {
+	Latency     time.Duration // Delay added to every load
+	Jitter      time.Duration // Random extra delay of up to Jitter added on top of Latency
+	ErrorRate   float64       // Probability, between 0 and 1, that a load fails with ErrChaos
+	CorruptRate float64       // Probability, between 0 and 1, that a successful load returns a corrupted payload
+	Seed        uint64        // Seed for the random decisions, for reproducible runs; 0 picks a random seed
+}
+
+// Chaos is a source that wraps another source and injects latency, errors and corrupted payloads into its loads.
+// It is meant for resilience testing: checking how reloads, validation, error handlers and fallbacks behave when
+// a configuration source misbehaves. It must not be used in production.
+type Chaos struct {
+	source Loader
+	config ChaosConfig
+	mu     sync.Mutex
+	rand   *rand.Rand
+}
+
+// NewChaos creates a Chaos source that wraps source and injects the faults described by config.
+func NewChaos(source Loader, config ChaosConfig) (*Chaos, error) {
+	if source == nil {
+		return nil, errors.New("source cannot be nil")
+	}
+	if config.Latency < 0 || config.Jitter < 0 {
+		return nil, errors.New("latency and jitter cannot be negative")
+	}
+	if config.ErrorRate < 0 || config.ErrorRate > 1 {
+		return nil, fmt.Errorf("error rate must be between 0 and 1, got %v", config.ErrorRate)
+	}
+	if config.CorruptRate < 0 || config.CorruptRate > 1 {
+		return nil, fmt.Errorf("corrupt rate must be between 0 and 1, got %v", config.CorruptRate)
+	}
+
+	seed := config.Seed
+	if seed == 0 {
+		seed = rand.Uint64()
+	}
+	return &Chaos{
+		source: source,
+		config: config,
+		rand:   rand.New(rand.NewPCG(seed, seed)),
+	}, nil
+}
+
+// Path returns the path of the wrapped source if it has one, so file watching keeps working.
+func (c *Chaos) Path() string {
+	if ps, ok := c.source.(interface{ Path() string }); ok {
+		return ps.Path()
+	}
+	return ""
+}
+
+// Paths returns the paths of the wrapped source if it has them, so files it includes are watched too.
+func (c *Chaos) Paths() []string {
+	if ps, ok := c.source.(interface{ Paths() []string }); ok {
+		return ps.Paths()
+	}
+	return nil
+}
+
+// Load waits for the configured latency, then either fails with ErrChaos or loads the wrapped source,
+// possibly corrupting the result. The delay is cut short if ctx is done.
+func (c *Chaos) Load(ctx context.Context) (map[string]any, error) {
+	c.mu.Lock()
+	delay := c.config.Latency
+	if c.config.Jitter > 0 {
+		delay += time.Duration(c.rand.Int64N(int64(c.config.Jitter) + 1))
+	}
+	fail := c.chance(c.config.ErrorRate)
+	corrupt := c.chance(c.config.CorruptRate)
+	c.mu.Unlock()
+
+	if delay > 0 {
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	if fail {
+		return nil, ErrChaos
+	}
+
+	config, err := c.source.Load(ctx)
+	if err != nil || !corrupt {
+		return config, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.corrupt(config), nil
+}
+
+// chance reports whether an event with probability p happens. The caller must hold c.mu.
+func (c *Chaos) chance(p float64) bool {
+	return p > 0 && c.rand.Float64() < p
+}
+
+// corrupt returns a copy of config in which one randomly chosen value is either removed or replaced
+// with a value of a different type. An empty configuration is corrupted into nil.
+// The caller must hold c.mu.
+func (c *Chaos) corrupt(config map[string]any) map[string]any {
+	corrupted := copyChaosMap(config)
+
+	var paths [][]string
+	collectChaosPaths(corrupted, nil, &paths)
+	if len(paths) == 0 {
+		return nil
+	}
+	sort.Slice(paths, func(i, j int) bool { return fmt.Sprint(paths[i]) < fmt.Sprint(paths[j]) })
+
+	path := paths[c.rand.IntN(len(paths))]
+	parent := corrupted
+	for _, key := range path[:len(path)-1] {
+		parent = parent[key].(map[string]any)
+	}
+	key := path[len(path)-1]
+
+	if c.rand.IntN(2) == 0 {
+		delete(parent, key)
+		return corrupted
+	}
+	if _, ok := parent[key].(string); ok {
+		parent[key] = []any{0}
+	} else {
+		parent[key] = "\x00corrupted"
+	}
+	return corrupted
+}
+
+// collectChaosPaths appends the paths of all leaf values in m to paths.
+func collectChaosPaths(m map[string]any, prefix []string, paths *[][]string) {
+	for key, value := range m {
+		path := append(append([]string(nil), prefix...), key)
+		if nested, ok := value.(map[string]any); ok && len(nested) > 0 {
+			collectChaosPaths(nested, path, paths)
+			continue
+		}
+		*paths = append(*paths, path)
+	}
+}
+
+// copyChaosMap returns a copy of m in which nested maps are copied, so corrupting it leaves the wrapped
+// source's data intact.
+func copyChaosMap(m map[string]any) map[string]any {
+	out := make(map[string]any, len(m))
+	for k, v := range m {
+		if nested, ok := v.(map[string]any); ok {
+			v = copyChaosMap(nested)
+		}
+		out[k] = v
+	}
+	return out
+}
@@ -0,0 +1,159 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package source
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.companyinfo.dev/conflex/codec"
+)
+
+type staticLoader struct {
+	config map[string]any
+	err    error
+}
+
+func (l staticLoader) Load(context.Context) (map[string]any, error) {
+	return l.config, l.err
+}
+
+type ChaosSourceTestSuite struct {
+	suite.Suite
+	config map[string]any
+}
+
+func TestChaosSourceTestSuite(t *testing.T) {
+	suite.Run(t, new(ChaosSourceTestSuite))
+}
+
+func (s *ChaosSourceTestSuite) SetupTest() {
+	s.config = map[string]any{
+		"name":   "app",
+		"server": map[string]any{"port": 8080, "host": "localhost"},
+	}
+}
+
+func (s *ChaosSourceTestSuite) TestNewChaos_Invalid() {
+	_, err := NewChaos(nil, ChaosConfig{})
+	s.Error(err)
+
+	for _, config := range []ChaosConfig{
+		{Latency: -time.Second},
+		{Jitter: -time.Second},
+		{ErrorRate: -0.1},
+		{ErrorRate: 1.5},
+		{CorruptRate: 2},
+	} {
+		_, err := NewChaos(staticLoader{}, config)
+		s.Error(err, "%+v", config)
+	}
+}
+
+func (s *ChaosSourceTestSuite) TestLoad_PassThrough() {
+	c, err := NewChaos(staticLoader{config: s.config}, ChaosConfig{})
+	s.Require().NoError(err)
+
+	config, err := c.Load(context.Background())
+	s.NoError(err)
+	s.Equal(s.config, config)
+
+	loadErr := errors.New("unavailable")
+	c, err = NewChaos(staticLoader{err: loadErr}, ChaosConfig{CorruptRate: 1})
+	s.Require().NoError(err)
+	_, err = c.Load(context.Background())
+	s.ErrorIs(err, loadErr)
+}
+
+func (s *ChaosSourceTestSuite) TestLoad_Errors() {
+	c, err := NewChaos(staticLoader{config: s.config}, ChaosConfig{ErrorRate: 1})
+	s.Require().NoError(err)
+
+	for range 10 {
+		_, err := c.Load(context.Background())
+		s.ErrorIs(err, ErrChaos)
+	}
+}
+
+func (s *ChaosSourceTestSuite) TestLoad_Corrupt() {
+	c, err := NewChaos(staticLoader{config: s.config}, ChaosConfig{CorruptRate: 1, Seed: 1})
+	s.Require().NoError(err)
+
+	for range 10 {
+		config, err := c.Load(context.Background())
+		s.NoError(err)
+		s.NotEqual(s.config, config)
+	}
+
+	// The wrapped source's data is left intact.
+	s.Equal(map[string]any{
+		"name":   "app",
+		"server": map[string]any{"port": 8080, "host": "localhost"},
+	}, s.config)
+
+	c, err = NewChaos(staticLoader{config: map[string]any{}}, ChaosConfig{CorruptRate: 1})
+	s.Require().NoError(err)
+	config, err := c.Load(context.Background())
+	s.NoError(err)
+	s.Nil(config)
+}
+
+func (s *ChaosSourceTestSuite) TestLoad_Seeded() {
+	outcomes := func() []bool {
+		c, err := NewChaos(staticLoader{config: s.config}, ChaosConfig{ErrorRate: 0.5, Seed: 42})
+		s.Require().NoError(err)
+		var failed []bool
+		for range 20 {
+			_, err := c.Load(context.Background())
+			failed = append(failed, err != nil)
+		}
+		return failed
+	}
+
+	first := outcomes()
+	s.Equal(first, outcomes())
+	s.Contains(first, true)
+	s.Contains(first, false)
+}
+
+func (s *ChaosSourceTestSuite) TestLoad_Latency() {
+	c, err := NewChaos(staticLoader{config: s.config}, ChaosConfig{Latency: 50 * time.Millisecond, Jitter: 10 * time.Millisecond})
+	s.Require().NoError(err)
+
+	start := time.Now()
+	_, err = c.Load(context.Background())
+	s.NoError(err)
+	s.GreaterOrEqual(time.Since(start), 50*time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	c, err = NewChaos(staticLoader{config: s.config}, ChaosConfig{Latency: time.Minute})
+	s.Require().NoError(err)
+	_, err = c.Load(ctx)
+	s.ErrorIs(err, context.DeadlineExceeded)
+}
+
+func (s *ChaosSourceTestSuite) TestPath() {
+	c, err := NewChaos(NewFile("config.json", codec.JSONCodec{}), ChaosConfig{})
+	s.Require().NoError(err)
+	s.Equal("config.json", c.Path())
+
+	c, err = NewChaos(staticLoader{}, ChaosConfig{})
+	s.Require().NoError(err)
+	s.Empty(c.Path())
+}
@@ -19,6 +19,8 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/hashicorp/consul/api"
 	"go.companyinfo.dev/conflex/codec"
@@ -31,11 +33,24 @@ type ConsulKV interface {
 
 // Consul is a struct that represents a Consul-based configuration source.
 type Consul struct {
-	client    *api.Client
-	kv        ConsulKV
-	path      string
+	client  *api.Client
+	kv      ConsulKV
+	path    string
+	decoder codec.Decoder
+
+	mu sync.Mutex
+	// lastIndex is the ModifyIndex of the KV pair decoded into config.
 	lastIndex uint64
-	decoder   codec.Decoder
+	lastSize  int
+	config    map[string]any
+	changed   bool
+	// watchErr is the error that stopped the watch plans started by Changes, reported by ChangesErr.
+	watchErr error
+
+	// watchFailover is the number of consecutive failed watch queries after which the watch plan is restarted.
+	watchFailover int
+	// watchRestartDelay is the delay before a stopped watch plan is started again.
+	watchRestartDelay time.Duration
 }
 
 // NewConsul creates a new Consul configuration source with the given path and decoder.
@@ -49,30 +64,73 @@ func NewConsul(path string, decoder codec.Decoder, kv ConsulKV) (*Consul, error)
 		kv = client.KV()
 	}
 	return &Consul{
-		client:  client,
-		kv:      kv,
-		path:    path,
-		decoder: decoder,
+		client:            client,
+		kv:                kv,
+		path:              path,
+		decoder:           decoder,
+		watchFailover:     defaultConsulWatchFailover,
+		watchRestartDelay: defaultConsulWatchRestartDelay,
 	}, nil
 }
 
+// Size returns the size in bytes of the value read by the last Load.
+func (c *Consul) Size() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.lastSize
+}
+
+// Changed reports whether the last Load returned new data. Load compares the ModifyIndex of the KV pair with
+// the one of the previous load, and only decodes the value again when it differs, so periodic reloads of an
+// unchanged key neither decode it nor report a change. The first successful Load, and a Load that finds the
+// key deleted, report a change.
+func (c *Consul) Changed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.changed
+}
+
 // Load retrieves the configuration data from the Consul key-value store at the specified path.
+// If the ModifyIndex of the key has not changed since the previous Load, a copy of the previous result is
+// returned without decoding the value again.
 func (c *Consul) Load(ctx context.Context) (map[string]any, error) {
-	pair, meta, err := c.kv.Get(c.path, (&api.QueryOptions{}).WithContext(ctx))
+	pair, _, err := c.kv.Get(c.path, (&api.QueryOptions{}).WithContext(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get consul key: %w", err)
 	}
 
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	if pair == nil {
+		// The key was already missing if the previous load found no pair either.
+		c.changed = c.config == nil || c.lastIndex != 0
+		c.lastIndex = 0
+		c.lastSize = 0
+		c.config = map[string]any{}
 		return make(map[string]any), nil
 	}
+	c.lastSize = len(pair.Value)
 
-	// Only update lastIndex if meta is not nil
-	if meta != nil {
-		c.lastIndex = meta.LastIndex
+	if pair.ModifyIndex != 0 && pair.ModifyIndex == c.lastIndex && c.config != nil {
+		c.changed = false
+		return copyCachedMap(c.config), nil
 	}
 
-	var config map[string]any
+	config, err := c.decode(pair)
+	if err != nil {
+		return nil, err
+	}
+	c.lastIndex = pair.ModifyIndex
+	c.config = copyCachedMap(config)
+	c.changed = true
+	return config, nil
+}
+
+// decode decodes the value of pair.
+func (c *Consul) decode(pair *api.KVPair) (map[string]any, error) {
 	caster, ok := c.decoder.(*codec.CasterCodec)
 	if ok {
 		var val any
@@ -81,15 +139,14 @@ func (c *Consul) Load(ctx context.Context) (map[string]any, error) {
 		keyParts := strings.Split(pair.Key, "/")
 		key := keyParts[len(keyParts)-1]
 
-		err = caster.Decode(pair.Value, &val)
-		if err != nil {
+		if err := caster.Decode(pair.Value, &val); err != nil {
 			return nil, fmt.Errorf("failed to decode consul value: %w", err)
 		}
 
-		config = map[string]any{key: val}
-		return config, nil
+		return map[string]any{key: val}, nil
 	}
 
+	var config map[string]any
 	if err := c.decoder.Decode(pair.Value, &config); err != nil {
 		return nil, fmt.Errorf("failed to decode consul value: %w", err)
 	}
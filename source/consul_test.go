@@ -540,3 +540,94 @@ func (m *mockConsulKV) Get(_ string, q *api.QueryOptions) (*api.KVPair, *api.Que
 	}
 	return nil, nil, nil
 }
+
+// pairConsulKV serves a single KV pair.
+type pairConsulKV struct {
+	pair *api.KVPair
+}
+
+func (m *pairConsulKV) Get(string, *api.QueryOptions) (*api.KVPair, *api.QueryMeta, error) {
+	return m.pair, &api.QueryMeta{}, nil
+}
+
+// countingDecoder is a JSON decoder that counts its decodes.
+type countingDecoder struct {
+	codec.JSONCodec
+	decodes int
+}
+
+func (d *countingDecoder) Decode(data []byte, v any) error {
+	d.decodes++
+	return d.JSONCodec.Decode(data, v)
+}
+
+// ConsulModifyIndexTestSuite tests the ModifyIndex-aware loads of the Consul source, without a Consul server.
+type ConsulModifyIndexTestSuite struct {
+	suite.Suite
+	kv      *pairConsulKV
+	decoder *countingDecoder
+	consul  *Consul
+}
+
+func TestConsulModifyIndexTestSuite(t *testing.T) {
+	suite.Run(t, new(ConsulModifyIndexTestSuite))
+}
+
+func (s *ConsulModifyIndexTestSuite) SetupTest() {
+	s.kv = &pairConsulKV{pair: &api.KVPair{Key: "app/config", Value: []byte(`{"foo": "bar"}`), ModifyIndex: 10}}
+	s.decoder = &countingDecoder{}
+	consul, err := NewConsul("app/config", s.decoder, s.kv)
+	s.Require().NoError(err)
+	s.consul = consul
+}
+
+func (s *ConsulModifyIndexTestSuite) load() map[string]any {
+	config, err := s.consul.Load(context.Background())
+	s.Require().NoError(err)
+	return config
+}
+
+func (s *ConsulModifyIndexTestSuite) TestUnchanged() {
+	s.Equal(map[string]any{"foo": "bar"}, s.load())
+	s.True(s.consul.Changed())
+
+	config := s.load()
+	s.Equal(map[string]any{"foo": "bar"}, config)
+	s.False(s.consul.Changed())
+	s.Equal(1, s.decoder.decodes)
+
+	// The result of an unchanged load is a copy.
+	config["foo"] = "modified"
+	s.Equal(map[string]any{"foo": "bar"}, s.load())
+}
+
+func (s *ConsulModifyIndexTestSuite) TestChanged() {
+	s.load()
+	s.kv.pair = &api.KVPair{Key: "app/config", Value: []byte(`{"foo": "baz"}`), ModifyIndex: 11}
+	s.Equal(map[string]any{"foo": "baz"}, s.load())
+	s.True(s.consul.Changed())
+	s.Equal(2, s.decoder.decodes)
+}
+
+func (s *ConsulModifyIndexTestSuite) TestDeleted() {
+	s.load()
+	s.kv.pair = nil
+	s.Empty(s.load())
+	s.True(s.consul.Changed())
+	s.Empty(s.load())
+	s.False(s.consul.Changed())
+
+	s.kv.pair = &api.KVPair{Key: "app/config", Value: []byte(`{"foo": "bar"}`), ModifyIndex: 12}
+	s.Equal(map[string]any{"foo": "bar"}, s.load())
+	s.True(s.consul.Changed())
+}
+
+func (s *ConsulModifyIndexTestSuite) TestDecodeErrorNotCached() {
+	s.kv.pair.Value = []byte(`{invalid`)
+	_, err := s.consul.Load(context.Background())
+	s.Require().Error(err)
+
+	s.kv.pair.Value = []byte(`{"foo": "bar"}`)
+	s.Equal(map[string]any{"foo": "bar"}, s.load())
+	s.True(s.consul.Changed())
+}
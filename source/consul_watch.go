@@ -0,0 +1,149 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package source
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/consul/api/watch"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	// defaultConsulWatchFailover is the default number of consecutive failed watch queries after which
+	// the watch plan is restarted. The plan backs off between failures (5s, 20s, 45s, ...), so restarting
+	// after a few failures reconnects quickly once the agent is back or has failed over.
+	defaultConsulWatchFailover = 3
+	// defaultConsulWatchRestartDelay is the default delay before a stopped watch plan is started again.
+	defaultConsulWatchRestartDelay = time.Second
+)
+
+// Changes watches the key of the source with a Consul watch plan and returns a channel that receives
+// a value whenever the key changes. This makes the source a change notifier: conflex reloads
+// it as soon as Consul reports a change, instead of waiting for the next poll.
+//
+// The plan is restarted when it stops unexpectedly or its queries keep failing, for example when the
+// agent restarts or fails over, so watching resumes once Consul is reachable again. A notification is sent
+// whenever a plan starts, because changes may have been missed in the meantime.
+// The channel is closed once ctx is done, or when a new plan cannot be created; ChangesErr reports why.
+func (c *Consul) Changes(ctx context.Context) (<-chan struct{}, error) {
+	if c.client == nil {
+		return nil, errors.New("consul client is not configured")
+	}
+	if _, err := c.newWatchPlan(nil, nil); err != nil {
+		return nil, err
+	}
+	c.setWatchErr(nil)
+
+	changes := make(chan struct{}, 1)
+	go c.runWatchPlans(ctx, changes)
+	return changes, nil
+}
+
+// ChangesErr returns the error that made Changes close its channel before ctx was done, or nil.
+func (c *Consul) ChangesErr() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.watchErr
+}
+
+// setWatchErr records the error returned by ChangesErr.
+func (c *Consul) setWatchErr(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.watchErr = err
+}
+
+// runWatchPlans runs watch plans until ctx is done, starting a new plan whenever the current one stops
+// or fails over. If a plan cannot be created, the error is recorded for ChangesErr and changes is closed.
+func (c *Consul) runWatchPlans(ctx context.Context, changes chan struct{}) {
+	defer close(changes)
+
+	notify := func() {
+		select {
+		case changes <- struct{}{}:
+		default:
+		}
+	}
+
+	for {
+		failover := make(chan struct{})
+		plan, err := c.newWatchPlan(notify, failover)
+		if err != nil {
+			c.setWatchErr(err)
+			return
+		}
+
+		stopped := make(chan struct{})
+		go func() {
+			defer close(stopped)
+			_ = plan.RunWithClientAndHclog(c.client, hclog.NewNullLogger())
+		}()
+
+		select {
+		case <-ctx.Done():
+			plan.Stop()
+			<-stopped
+			return
+		case <-failover:
+			plan.Stop()
+			<-stopped
+		case <-stopped:
+		}
+
+		timer := time.NewTimer(c.watchRestartDelay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// newWatchPlan creates a key watch plan for the path of the source, which is a single key rather than a tree.
+// notify is called for every result the plan reports, and failover is closed once the configured number of
+// consecutive queries have failed.
+func (c *Consul) newWatchPlan(notify func(), failover chan struct{}) (*watch.Plan, error) {
+	plan, err := watch.Parse(map[string]any{"type": "key", "key": c.path})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create consul watch plan: %w", err)
+	}
+
+	query := plan.Watcher
+	failures := 0
+	plan.Watcher = func(p *watch.Plan) (watch.BlockingParamVal, any, error) {
+		index, result, err := query(p)
+		if err == nil {
+			failures = 0
+			return index, result, nil
+		}
+		failures++
+		if failures == c.watchFailover && failover != nil {
+			close(failover)
+		}
+		return index, result, err
+	}
+	plan.HybridHandler = func(watch.BlockingParamVal, any) {
+		if notify != nil {
+			notify()
+		}
+	}
+
+	return plan, nil
+}
@@ -0,0 +1,204 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/consul/api"
+	"github.com/stretchr/testify/suite"
+	"go.companyinfo.dev/conflex/codec"
+)
+
+// fakeConsulKV serves the blocking KV queries used by watch plans.
+type fakeConsulKV struct {
+	mu        sync.Mutex
+	index     uint64
+	value     string
+	fail      int  // Number of upcoming queries that fail
+	recursive bool // Whether a query listed a key prefix
+	changed   chan struct{}
+}
+
+func newFakeConsulKV() *fakeConsulKV {
+	return &fakeConsulKV{index: 1, value: `{"foo":"bar"}`, changed: make(chan struct{})}
+}
+
+func (f *fakeConsulKV) set(value string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.index++
+	f.value = value
+	close(f.changed)
+	f.changed = make(chan struct{})
+}
+
+func (f *fakeConsulKV) failNext(n int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.fail = n
+	close(f.changed)
+	f.changed = make(chan struct{})
+}
+
+func (f *fakeConsulKV) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	wait, _ := strconv.ParseUint(r.URL.Query().Get("index"), 10, 64)
+
+	f.mu.Lock()
+	if r.URL.Query().Has("recurse") {
+		f.recursive = true
+	}
+	if wait == f.index && f.fail == 0 {
+		changed := f.changed
+		f.mu.Unlock()
+		select {
+		case <-changed:
+		case <-r.Context().Done():
+			return
+		case <-time.After(time.Second):
+		}
+		f.mu.Lock()
+	}
+	defer f.mu.Unlock()
+
+	if f.fail > 0 {
+		f.fail--
+		http.Error(w, "agent unavailable", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("X-Consul-Index", strconv.FormatUint(f.index, 10))
+	_ = json.NewEncoder(w).Encode([]*api.KVPair{{
+		Key:         strings.TrimPrefix(r.URL.Path, "/v1/kv/"),
+		Value:       []byte(f.value),
+		ModifyIndex: f.index,
+	}})
+}
+
+type ConsulWatchTestSuite struct {
+	suite.Suite
+	kv     *fakeConsulKV
+	server *httptest.Server
+	consul *Consul
+}
+
+func TestConsulWatchTestSuite(t *testing.T) {
+	suite.Run(t, new(ConsulWatchTestSuite))
+}
+
+func (s *ConsulWatchTestSuite) SetupTest() {
+	s.kv = newFakeConsulKV()
+	s.server = httptest.NewServer(s.kv)
+	s.T().Setenv("CONSUL_HTTP_ADDR", s.server.URL)
+
+	consul, err := NewConsul("app/config", &codec.JSONCodec{}, nil)
+	s.Require().NoError(err)
+	consul.watchFailover = 1
+	consul.watchRestartDelay = 10 * time.Millisecond
+	s.consul = consul
+}
+
+func (s *ConsulWatchTestSuite) TearDownTest() {
+	s.server.Close()
+}
+
+func (s *ConsulWatchTestSuite) expectChange(changes <-chan struct{}) {
+	s.T().Helper()
+	select {
+	case _, ok := <-changes:
+		s.True(ok, "changes channel closed")
+	case <-time.After(3 * time.Second):
+		s.Fail("expected change notification")
+	}
+}
+
+func (s *ConsulWatchTestSuite) TestSize() {
+	s.Zero(s.consul.Size())
+	_, err := s.consul.Load(context.Background())
+	s.Require().NoError(err)
+	s.Equal(len(`{"foo":"bar"}`), s.consul.Size())
+}
+
+func (s *ConsulWatchTestSuite) TestChanges() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changes, err := s.consul.Changes(ctx)
+	s.Require().NoError(err)
+
+	// The first result of a plan is always reported.
+	s.expectChange(changes)
+
+	s.kv.set(`{"foo":"baz"}`)
+	s.expectChange(changes)
+
+	// The source reads a single key, so the plan watches that key rather than a key prefix.
+	s.kv.mu.Lock()
+	s.False(s.kv.recursive)
+	s.kv.mu.Unlock()
+
+	cancel()
+	select {
+	case _, ok := <-changes:
+		for ok {
+			_, ok = <-changes
+		}
+	case <-time.After(3 * time.Second):
+		s.Fail("expected changes channel to be closed")
+	}
+}
+
+func (s *ConsulWatchTestSuite) TestChanges_Failover() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changes, err := s.consul.Changes(ctx)
+	s.Require().NoError(err)
+	s.expectChange(changes)
+
+	// A failed query restarts the plan right away instead of waiting for the plan's own backoff,
+	// and the new plan reports its first result.
+	s.kv.failNext(1)
+	s.expectChange(changes)
+}
+
+func (s *ConsulWatchTestSuite) TestChanges_Invalid() {
+	_, err := (&Consul{}).Changes(context.Background())
+	s.Error(err)
+
+	s.consul.path = ""
+	_, err = s.consul.Changes(context.Background())
+	s.Error(err)
+}
+
+func (s *ConsulWatchTestSuite) TestChanges_PlanError() {
+	s.NoError(s.consul.ChangesErr())
+
+	// A plan that cannot be created closes the channel and is reported by ChangesErr.
+	s.consul.path = ""
+	changes := make(chan struct{}, 1)
+	s.consul.runWatchPlans(context.Background(), changes)
+	_, ok := <-changes
+	s.False(ok)
+	s.ErrorContains(s.consul.ChangesErr(), "failed to create consul watch plan")
+}
@@ -24,10 +24,15 @@ import (
 	"go.companyinfo.dev/conflex/codec"
 )
 
+// fileSuffix marks environment variables naming a file to read the value from, as in DATABASE_PASSWORD_FILE.
+const fileSuffix = "_FILE"
+
 // OSEnvVar is a struct that represents an environment variable loader with a prefix.
 type OSEnvVar struct {
-	prefix  string
-	decoder codec.Decoder
+	prefix          string
+	decoder         codec.Decoder
+	fileIndirection bool
+	keyReplacer     func(string) string
 }
 
 // NewOSEnvVar creates a new OSEnvVar instance with the given prefix.
@@ -38,24 +43,127 @@ func NewOSEnvVar(prefix string) *OSEnvVar {
 	}
 }
 
+// WithFileIndirection enables the Docker secrets convention, in which a variable with the "_FILE" suffix names a
+// file holding the value: DATABASE_PASSWORD_FILE=/run/secrets/db stores the contents of /run/secrets/db, without
+// trailing newlines, under database.password. Setting both a variable and its "_FILE" variant is an error.
+// It returns e, so it can be chained with NewOSEnvVar.
+func (e *OSEnvVar) WithFileIndirection() *OSEnvVar {
+	e.fileIndirection = true
+	return e
+}
+
+// WithKeyReplacer sets the function that maps variable names, without the prefix, to dot-separated key paths.
+// By default every underscore separates two levels of nesting. To nest on double underscores only, so that
+// single underscores stay part of a key, use strings.NewReplacer("__", ".").Replace: DATABASE__MAX_CONNS then
+// becomes database.max_conns. Keys are lowercased after replacement, and empty path segments are dropped.
+// It returns e, so it can be chained with NewOSEnvVar.
+func (e *OSEnvVar) WithKeyReplacer(replacer func(string) string) *OSEnvVar {
+	e.keyReplacer = replacer
+	return e
+}
+
 // Load reads the environment variables with the specified prefix and decodes them into a map[string]any.
 func (e *OSEnvVar) Load(_ context.Context) (map[string]any, error) {
-	validEnv := make([]string, 0, len(os.Environ()))
+	environ := os.Environ()
+	validEnv := make([]string, 0, len(environ))
+	var files []string
 
-	for _, env := range os.Environ() {
+	for _, env := range environ {
 		if !strings.HasPrefix(env, e.prefix) {
 			continue
 		}
 
-		validEnv = append(validEnv, strings.TrimPrefix(env, e.prefix))
+		env = strings.TrimPrefix(env, e.prefix)
+		if key, _, _ := strings.Cut(env, "="); e.fileIndirection && strings.HasSuffix(key, fileSuffix) {
+			files = append(files, env)
+			continue
+		}
+		validEnv = append(validEnv, env)
 	}
 
-	data := strings.Join(validEnv, "\n")
-
 	var config map[string]any
-	if err := e.decoder.Decode([]byte(data), &config); err != nil {
+	if e.keyReplacer != nil {
+		config = make(map[string]any, len(validEnv))
+		for _, env := range validEnv {
+			key, value, _ := strings.Cut(env, "=")
+			setPath(config, e.keyPath(key), strings.TrimSpace(value))
+		}
+	} else if err := e.decode(validEnv, &config); err != nil {
 		return nil, fmt.Errorf("failed to decode environment variables: %w", err)
 	}
 
+	for _, env := range files {
+		if err := e.loadFile(config, env); err != nil {
+			return nil, err
+		}
+	}
+
 	return config, nil
 }
+
+// decode decodes the entries with the decoder, passing them directly if it is a codec.EntryDecoder, and joined
+// into lines otherwise.
+func (e *OSEnvVar) decode(entries []string, config *map[string]any) error {
+	if d, ok := e.decoder.(codec.EntryDecoder); ok {
+		return d.DecodeEntries(entries, config)
+	}
+	return e.decoder.Decode([]byte(strings.Join(entries, "\n")), config)
+}
+
+// loadFile stores the contents of the file named by env, a "KEY_FILE=path" pair without the prefix, in config.
+// The contents are stored directly rather than decoded, so they may span several lines.
+func (e *OSEnvVar) loadFile(config map[string]any, env string) error {
+	key, path, _ := strings.Cut(env, "=")
+	key = strings.TrimSuffix(key, fileSuffix)
+	if _, ok := os.LookupEnv(e.prefix + key); ok {
+		return fmt.Errorf("both %s and %s are set", e.prefix+key, e.prefix+key+fileSuffix)
+	}
+
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", e.prefix+key+fileSuffix, err)
+	}
+
+	setPath(config, e.keyPath(key), strings.TrimRight(string(data), "\r\n"))
+	return nil
+}
+
+// keyPath returns the key path for a variable name without the prefix, splitting it on underscores or
+// on the dots returned by the key replacer.
+func (e *OSEnvVar) keyPath(name string) []string {
+	sep := "_"
+	if e.keyReplacer != nil {
+		name, sep = e.keyReplacer(name), "."
+	}
+
+	var parts []string
+	for _, part := range strings.Split(strings.ToLower(name), sep) {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return parts
+}
+
+// setPath stores value at path in config, replacing values that are in the way of the intermediate maps.
+// An empty path is ignored.
+func setPath(config map[string]any, path []string, value any) {
+	if len(path) == 0 {
+		return
+	}
+
+	current := config
+	for _, part := range path[:len(path)-1] {
+		next, ok := current[part].(map[string]any)
+		if !ok {
+			next = make(map[string]any)
+			current[part] = next
+		}
+		current = next
+	}
+	current[path[len(path)-1]] = value
+}
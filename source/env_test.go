@@ -15,10 +15,16 @@
 package source
 
 import (
+	"context"
+	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
+
+	"go.companyinfo.dev/conflex/codec"
 )
 
 type OSEnvVarTestSuite struct {
@@ -85,3 +91,97 @@ func (s *OSEnvVarTestSuite) TestLoad_Prefix() {
 	s.Equal("baz", conf["bar"])
 	s.NotContains(conf, "other")
 }
+
+func (s *OSEnvVarTestSuite) TestLoad_FileIndirection() {
+	dir := s.T().TempDir()
+	secret := filepath.Join(dir, "db")
+	s.Require().NoError(os.WriteFile(secret, []byte("s3cret\nline2\n"), 0o600))
+
+	s.T().Setenv("APP_DATABASE_HOST", "localhost")
+	s.T().Setenv("APP_DATABASE_PASSWORD_FILE", secret)
+
+	conf, err := NewOSEnvVar("APP_").WithFileIndirection().Load(context.Background())
+	s.Require().NoError(err)
+	s.Equal(map[string]any{"host": "localhost", "password": "s3cret\nline2"}, conf["database"])
+}
+
+func (s *OSEnvVarTestSuite) TestLoad_FileIndirectionDisabled() {
+	s.T().Setenv("APP_DATABASE_PASSWORD_FILE", "/run/secrets/db")
+
+	conf, err := NewOSEnvVar("APP_").Load(context.Background())
+	s.Require().NoError(err)
+	s.Equal(map[string]any{"password": map[string]any{"file": "/run/secrets/db"}}, conf["database"])
+}
+
+func (s *OSEnvVarTestSuite) TestLoad_FileIndirectionErrors() {
+	s.T().Setenv("APP_TOKEN_FILE", filepath.Join(s.T().TempDir(), "missing"))
+	_, err := NewOSEnvVar("APP_").WithFileIndirection().Load(context.Background())
+	s.ErrorContains(err, "failed to read APP_TOKEN_FILE")
+
+	s.T().Setenv("APP_TOKEN", "inline")
+	_, err = NewOSEnvVar("APP_").WithFileIndirection().Load(context.Background())
+	s.ErrorContains(err, "both APP_TOKEN and APP_TOKEN_FILE are set")
+}
+
+func (s *OSEnvVarTestSuite) TestLoad_KeyReplacer() {
+	s.T().Setenv("APP_DATABASE__MAX_CONNS", "10")
+	s.T().Setenv("APP_DATABASE__PRIMARY__HOST_NAME", " db1 ")
+	s.T().Setenv("APP_LOG_LEVEL", "debug")
+	s.T().Setenv("APP___", "ignored")
+
+	conf, err := NewOSEnvVar("APP_").WithKeyReplacer(strings.NewReplacer("__", ".").Replace).Load(context.Background())
+	s.Require().NoError(err)
+	s.Equal(map[string]any{
+		"database": map[string]any{
+			"max_conns": "10",
+			"primary":   map[string]any{"host_name": "db1"},
+		},
+		"log_level": "debug",
+	}, conf)
+}
+
+func (s *OSEnvVarTestSuite) TestLoad_KeyReplacerWithFileIndirection() {
+	secret := filepath.Join(s.T().TempDir(), "db")
+	s.Require().NoError(os.WriteFile(secret, []byte("s3cret\n"), 0o600))
+	s.T().Setenv("APP_DATABASE__ADMIN_PASSWORD_FILE", secret)
+
+	conf, err := NewOSEnvVar("APP_").
+		WithKeyReplacer(strings.NewReplacer("__", ".").Replace).
+		WithFileIndirection().
+		Load(context.Background())
+	s.Require().NoError(err)
+	s.Equal(map[string]any{"admin_password": "s3cret"}, conf["database"])
+}
+
+// lineDecoder hides the DecodeEntries method of the codec it wraps.
+type lineDecoder struct {
+	codec.Decoder
+}
+
+func (s *OSEnvVarTestSuite) TestLoad_LineDecoder() {
+	s.T().Setenv("LINES_DB_HOST", "localhost")
+	s.T().Setenv("LINES_DB_PORT", "5432")
+	loader := NewOSEnvVar("LINES_")
+	want, err := loader.Load(context.Background())
+	s.Require().NoError(err)
+
+	loader.decoder = lineDecoder{codec.EnvVarCodec{}}
+	got, err := loader.Load(context.Background())
+	s.Require().NoError(err)
+	s.Equal(want, got)
+	s.Equal(map[string]any{"db": map[string]any{"host": "localhost", "port": "5432"}}, got)
+}
+
+func BenchmarkOSEnvVar_Load(b *testing.B) {
+	for i := range 5000 {
+		b.Setenv(fmt.Sprintf("BENCH_SERVICE%d_DATABASE_POOL_SIZE_%d", i%50, i), fmt.Sprint(i))
+	}
+	src := NewOSEnvVar("BENCH_")
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		if _, err := src.Load(context.Background()); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -18,19 +18,26 @@ package source
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"sync/atomic"
 
 	"go.companyinfo.dev/conflex/codec"
 )
 
 // File represents a configuration file that can be loaded.
 type File struct {
-	path    string
-	data    []byte
-	decoder codec.Decoder
+	path     string
+	data     []byte
+	size     atomic.Int64             // Size of the file read by the last Load
+	includes atomic.Pointer[[]string] // Files included by the last Load
+	decoder  codec.Decoder
 }
 
 // NewFile creates a new File instance with the given path and decoder.
+// The file is streamed into decoders that implement codec.StreamDecoder, such as the JSON codec, so a large file
+// is never held in memory in full next to the configuration decoded from it.
 func NewFile(path string, decoder codec.Decoder) *File {
 	return &File{
 		path:    path,
@@ -46,21 +53,93 @@ func NewFileContent(data []byte, decoder codec.Decoder) *File {
 	}
 }
 
+// Path returns the path of the configuration file, or an empty string if the File was created from content.
+func (f *File) Path() string {
+	return f.path
+}
+
+// Size returns the size in bytes of the configuration read by the last Load, without included files.
+func (f *File) Size() int {
+	if f.path == "" {
+		return len(f.data)
+	}
+	return int(f.size.Load())
+}
+
+// Paths returns the path of the configuration file followed by the absolute paths of the files included by the
+// last Load, so that watchers observe included files too. A File created from content only returns its includes.
+func (f *File) Paths() []string {
+	var paths []string
+	if f.path != "" {
+		paths = append(paths, f.path)
+	}
+	if includes := f.includes.Load(); includes != nil {
+		paths = append(paths, *includes...)
+	}
+	return paths
+}
+
 // Load reads the configuration file and decodes its contents into a map[string]any.
+// Include directives (see IncludeKey) are replaced with the contents of the included files, which are decoded
+// with the same decoder. Relative include paths are resolved against the directory of the file, or against the
+// working directory for a File created from content.
 func (f *File) Load(context.Context) (map[string]any, error) {
-	var err error
-
+	var config map[string]any
+	dir := "."
+	var stack []string
 	if f.path != "" {
-		f.data, err = os.ReadFile(f.path)
+		path, err := filepath.Abs(f.path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve file path: %w", err)
+		}
+		dir = filepath.Dir(path)
+		stack = []string{path}
+
+		file, err := os.Open(f.path)
 		if err != nil {
 			return nil, fmt.Errorf("failed to read file: %w", err)
 		}
-	}
+		defer file.Close()
 
-	var config map[string]any
-	if err := f.decoder.Decode(f.data, &config); err != nil {
+		var size int
+		config, size, err = decodeReader(file, f.decoder)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode file: %w", err)
+		}
+		f.size.Store(int64(size))
+	} else if err := f.decoder.Decode(f.data, &config); err != nil {
 		return nil, fmt.Errorf("failed to decode file: %w", err)
 	}
 
+	// The includes are recorded even if expanding them fails, so that fixing a broken include is observed.
+	var includes []string
+	defer func() { f.includes.Store(&includes) }()
+	if config != nil {
+		if err := f.expandIncludes(config, dir, stack, &includes); err != nil {
+			return nil, err
+		}
+	}
+
 	return config, nil
 }
+
+// decodeReader decodes the configuration read from r with decoder and returns it with the number of bytes read.
+func decodeReader(r io.Reader, decoder codec.Decoder) (map[string]any, int, error) {
+	counter := &countingReader{r: r}
+	var config map[string]any
+	err := codec.DecodeReader(decoder, counter, &config)
+	return config, counter.n, err
+}
+
+// countingReader counts the bytes read from an io.Reader.
+type countingReader struct {
+	r io.Reader
+	n int
+}
+
+// Read implements io.Reader.
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += n
+	return n, err
+}
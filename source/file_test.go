@@ -15,10 +15,18 @@
 package source
 
 import (
+	"context"
+	"fmt"
 	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
 	"testing"
 
+	"github.com/goccy/go-yaml"
 	"github.com/stretchr/testify/suite"
+
+	"go.companyinfo.dev/conflex/codec"
 )
 
 type FileSourceTestSuite struct {
@@ -79,6 +87,37 @@ func (s *FileSourceTestSuite) TestLoad_Content() {
 	s.Equal(map[string]any{"foo": "bar"}, conf)
 }
 
+func (s *FileSourceTestSuite) TestPath() {
+	s.Equal(s.tmpFile, NewFile(s.tmpFile, &mockDecoderFile{}).Path())
+	s.Empty(NewFileContent([]byte(`{}`), &mockDecoderFile{}).Path())
+}
+
+func (s *FileSourceTestSuite) TestSize() {
+	file := NewFile(s.tmpFile, &mockDecoderFile{decodeMap: map[string]any{"foo": "bar"}})
+	s.Zero(file.Size())
+	_, err := file.Load(nil)
+	s.Require().NoError(err)
+	s.Equal(len(`{"foo": "bar"}`), file.Size())
+
+	s.Equal(2, NewFileContent([]byte(`{}`), &mockDecoderFile{}).Size())
+}
+
+func (s *FileSourceTestSuite) TestSize_ConcurrentLoads() {
+	file := NewFile(s.tmpFile, codec.JSONCodec{})
+
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := file.Load(context.Background())
+			s.NoError(err)
+			s.Equal(len(`{"foo": "bar"}`), file.Size())
+		}()
+	}
+	wg.Wait()
+}
+
 func (s *FileSourceTestSuite) TestLoad_DecodeError() {
 	decoder := &mockDecoderFile{err: true}
 	file := NewFile(s.tmpFile, decoder)
@@ -86,6 +125,24 @@ func (s *FileSourceTestSuite) TestLoad_DecodeError() {
 	s.Error(err)
 }
 
+func (s *FileSourceTestSuite) TestLoad_Stream() {
+	dir := s.T().TempDir()
+	s.Require().NoError(os.WriteFile(filepath.Join(dir, "base.json"), []byte(`{"port": 8080, "tags": ["a"]}`), 0o600))
+	data := `{"$include": "base.json", "host": "localhost", "tags": ["b"]}`
+	path := filepath.Join(dir, "config.json")
+	s.Require().NoError(os.WriteFile(path, []byte(data), 0o600))
+
+	file := NewFile(path, codec.JSONCodec{})
+	conf, err := file.Load(nil)
+	s.Require().NoError(err)
+	s.Equal(map[string]any{"host": "localhost", "port": float64(8080), "tags": []any{"b"}}, conf)
+	s.Equal(len(data), file.Size())
+
+	s.Require().NoError(os.WriteFile(path, []byte(`{"host": "localhost"`), 0o600))
+	_, err = file.Load(nil)
+	s.ErrorContains(err, "failed to decode file")
+}
+
 // mockDecoderFile implements codec.Decoder for testing
 
 type mockDecoderFile struct {
@@ -103,3 +160,136 @@ func (m *mockDecoderFile) Decode(_ []byte, v any) error {
 	}
 	return os.ErrInvalid
 }
+
+// writeLargeJSON writes a JSON file of about size bytes, shaped like a feature flag export, and returns its path.
+func writeLargeJSON(b *testing.B, size int) string {
+	b.Helper()
+	return writeLargeConfig(b, size, codec.JSONCodec{}, "flags.json")
+}
+
+// writeLargeConfig writes a file of about size bytes in JSON terms, shaped like a feature flag export, with the
+// given encoder and returns its path.
+func writeLargeConfig(b *testing.B, size int, encoder codec.Encoder, name string) string {
+	b.Helper()
+	flags := make(map[string]any)
+	for i := 0; len(flags)*120 < size; i++ {
+		flags[fmt.Sprintf("flag-%06d", i)] = map[string]any{
+			"enabled": i%2 == 0,
+			"rollout": float64(i%100) / 100,
+			"owner":   "team-" + fmt.Sprint(i%17),
+			"tags":    []any{"web", "api"},
+		}
+	}
+	data, err := encoder.Encode(map[string]any{"flags": flags})
+	if err != nil {
+		b.Fatal(err)
+	}
+	path := filepath.Join(b.TempDir(), name)
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		b.Fatal(err)
+	}
+	return path
+}
+
+// liveHeap reports the heap still in use, after a garbage collection, while the result of load is alive.
+func liveHeap(b *testing.B, load func() any) {
+	b.Helper()
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	result := load()
+	runtime.GC()
+	runtime.ReadMemStats(&after)
+	runtime.KeepAlive(result)
+	b.ReportMetric(float64(after.HeapAlloc)-float64(before.HeapAlloc), "live-B")
+}
+
+// BenchmarkFileLoad_JSON compares streaming a large JSON file into the decoder with reading it in full first and
+// keeping the data, as File did before it streamed. B/op is what a load allocates; live-B is the memory the
+// loaded configuration keeps in use, which no longer includes the file itself.
+func BenchmarkFileLoad_JSON(b *testing.B) {
+	path := writeLargeJSON(b, 4<<20)
+
+	b.Run("Stream", func(b *testing.B) {
+		b.ReportAllocs()
+		file := NewFile(path, codec.JSONCodec{})
+		for range b.N {
+			if _, err := file.Load(nil); err != nil {
+				b.Fatal(err)
+			}
+		}
+		b.StopTimer()
+		liveHeap(b, func() any {
+			config, _ := file.Load(nil)
+			return config
+		})
+	})
+
+	b.Run("ReadFile", func(b *testing.B) {
+		b.ReportAllocs()
+		load := func() ([]byte, map[string]any) {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				b.Fatal(err)
+			}
+			var config map[string]any
+			if err := (codec.JSONCodec{}).Decode(data, &config); err != nil {
+				b.Fatal(err)
+			}
+			return data, config
+		}
+		for range b.N {
+			load()
+		}
+		b.StopTimer()
+		liveHeap(b, func() any {
+			data, config := load()
+			return []any{data, config}
+		})
+	})
+}
+
+// BenchmarkFileLoad_YAML compares loading a large YAML file, which File reads in full, with go-yaml's Decoder,
+// which accepts a reader. The Decoder reads the whole reader before parsing, so streaming a file into it would not
+// lower the memory a load uses; this is why the YAML codec does not implement codec.StreamDecoder.
+func BenchmarkFileLoad_YAML(b *testing.B) {
+	path := writeLargeConfig(b, 1<<20, codec.YAMLCodec{}, "flags.yaml")
+
+	b.Run("ReadFile", func(b *testing.B) {
+		b.ReportAllocs()
+		file := NewFile(path, codec.YAMLCodec{})
+		for range b.N {
+			if _, err := file.Load(nil); err != nil {
+				b.Fatal(err)
+			}
+		}
+		b.StopTimer()
+		liveHeap(b, func() any {
+			config, _ := file.Load(nil)
+			return config
+		})
+	})
+
+	b.Run("Decoder", func(b *testing.B) {
+		b.ReportAllocs()
+		load := func() map[string]any {
+			f, err := os.Open(path)
+			if err != nil {
+				b.Fatal(err)
+			}
+			defer f.Close()
+			var config map[string]any
+			if err := yaml.NewDecoder(f).Decode(&config); err != nil {
+				b.Fatal(err)
+			}
+			return config
+		}
+		for range b.N {
+			load()
+		}
+		b.StopTimer()
+		liveHeap(b, func() any {
+			return load()
+		})
+	})
+}
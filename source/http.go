@@ -0,0 +1,124 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package source
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"go.companyinfo.dev/conflex/codec"
+)
+
+// HTTP is a configuration source that downloads a document from an HTTP(S) URL.
+// Loads are conditional requests: once a document has been downloaded, the next request carries its ETag in
+// If-None-Match and its Last-Modified time in If-Modified-Since, and a 304 Not Modified response reuses the
+// previous result without downloading or decoding the document again, which keeps frequent polling cheap.
+type HTTP struct {
+	url     string
+	decoder codec.Decoder
+	client  *http.Client
+
+	mu           sync.Mutex
+	etag         string
+	lastModified string
+	config       map[string]any
+	size         int
+}
+
+// NewHTTP creates a new HTTP source that downloads the document at rawURL and decodes it with decoder.
+// If client is nil, http.DefaultClient is used.
+func NewHTTP(rawURL string, decoder codec.Decoder, client *http.Client) (*HTTP, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid url: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported url scheme %q", u.Scheme)
+	}
+	if decoder == nil {
+		return nil, errors.New("decoder cannot be nil")
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &HTTP{url: rawURL, decoder: decoder, client: client}, nil
+}
+
+// Size returns the size in bytes of the document downloaded by the last Load, which is 0 if the server
+// reported that it did not change.
+func (h *HTTP) Size() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.size
+}
+
+// Load downloads and decodes the document, or returns a copy of the previous result if the server responds
+// with 304 Not Modified. Any status other than 200 and 304 is an error.
+func (h *HTTP) Load(ctx context.Context) (map[string]any, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, h.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if h.config != nil {
+		if h.etag != "" {
+			req.Header.Set("If-None-Match", h.etag)
+		}
+		if h.lastModified != "" {
+			req.Header.Set("If-Modified-Since", h.lastModified)
+		}
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch configuration: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && h.config != nil:
+		h.size = 0
+		return copyCachedMap(h.config), nil
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("failed to fetch configuration: unexpected status %s", resp.Status)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	var config map[string]any
+	if err := h.decoder.Decode(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if config == nil {
+		config = map[string]any{}
+	}
+
+	h.etag = resp.Header.Get("ETag")
+	h.lastModified = resp.Header.Get("Last-Modified")
+	h.config = copyCachedMap(config)
+	h.size = len(data)
+	return config, nil
+}
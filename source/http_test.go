@@ -0,0 +1,167 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package source
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.companyinfo.dev/conflex/codec"
+)
+
+// configServer serves a JSON document with an ETag or a Last-Modified time, honoring conditional requests.
+type configServer struct {
+	mu           sync.Mutex
+	body         string
+	etag         string
+	lastModified time.Time
+	status       int
+	downloads    int
+	notModified  int
+}
+
+func (c *configServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.status != 0 {
+		w.WriteHeader(c.status)
+		return
+	}
+	if c.etag != "" {
+		w.Header().Set("ETag", c.etag)
+		if r.Header.Get("If-None-Match") == c.etag {
+			c.notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+	}
+	if !c.lastModified.IsZero() {
+		w.Header().Set("Last-Modified", c.lastModified.UTC().Format(http.TimeFormat))
+		if since, err := http.ParseTime(r.Header.Get("If-Modified-Since")); err == nil && !c.lastModified.Truncate(time.Second).After(since) {
+			c.notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+	}
+	c.downloads++
+	_, _ = w.Write([]byte(c.body))
+}
+
+func (c *configServer) set(body, etag string, lastModified time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.body, c.etag, c.lastModified = body, etag, lastModified
+}
+
+type HTTPSourceTestSuite struct {
+	suite.Suite
+	config *configServer
+	server *httptest.Server
+	source *HTTP
+}
+
+func TestHTTPSourceTestSuite(t *testing.T) {
+	suite.Run(t, new(HTTPSourceTestSuite))
+}
+
+func (s *HTTPSourceTestSuite) SetupTest() {
+	s.config = &configServer{body: `{"server": {"port": 8080}}`}
+	s.server = httptest.NewServer(s.config)
+	source, err := NewHTTP(s.server.URL+"/app.json", &codec.JSONCodec{}, s.server.Client())
+	s.Require().NoError(err)
+	s.source = source
+}
+
+func (s *HTTPSourceTestSuite) TearDownTest() {
+	s.server.Close()
+}
+
+func (s *HTTPSourceTestSuite) TestNewHTTP_Invalid() {
+	_, err := NewHTTP("ftp://config.internal/app.json", &codec.JSONCodec{}, nil)
+	s.ErrorContains(err, `unsupported url scheme "ftp"`)
+	_, err = NewHTTP("http://config.internal/%zz", &codec.JSONCodec{}, nil)
+	s.ErrorContains(err, "invalid url")
+	_, err = NewHTTP("http://config.internal/app.json", nil, nil)
+	s.ErrorContains(err, "decoder cannot be nil")
+}
+
+func (s *HTTPSourceTestSuite) TestLoad_ETag() {
+	s.config.set(`{"server": {"port": 8080}}`, `"v1"`, time.Time{})
+
+	config, err := s.source.Load(context.Background())
+	s.Require().NoError(err)
+	s.Equal(map[string]any{"server": map[string]any{"port": float64(8080)}}, config)
+	s.Equal(len(`{"server": {"port": 8080}}`), s.source.Size())
+
+	// A modified result does not leak into the next load.
+	config["server"].(map[string]any)["port"] = 1
+
+	config, err = s.source.Load(context.Background())
+	s.Require().NoError(err)
+	s.Equal(map[string]any{"server": map[string]any{"port": float64(8080)}}, config)
+	s.Zero(s.source.Size())
+	s.Equal(1, s.config.downloads)
+	s.Equal(1, s.config.notModified)
+
+	s.config.set(`{"server": {"port": 9090}}`, `"v2"`, time.Time{})
+	config, err = s.source.Load(context.Background())
+	s.Require().NoError(err)
+	s.Equal(map[string]any{"server": map[string]any{"port": float64(9090)}}, config)
+	s.Equal(2, s.config.downloads)
+}
+
+func (s *HTTPSourceTestSuite) TestLoad_LastModified() {
+	modified := time.Now().Add(-time.Hour)
+	s.config.set(`{"foo": "bar"}`, "", modified)
+
+	for range 3 {
+		config, err := s.source.Load(context.Background())
+		s.Require().NoError(err)
+		s.Equal(map[string]any{"foo": "bar"}, config)
+	}
+	s.Equal(1, s.config.downloads)
+	s.Equal(2, s.config.notModified)
+
+	s.config.set(`{"foo": "baz"}`, "", time.Now().Add(time.Minute))
+	config, err := s.source.Load(context.Background())
+	s.Require().NoError(err)
+	s.Equal(map[string]any{"foo": "baz"}, config)
+}
+
+func (s *HTTPSourceTestSuite) TestLoad_Errors() {
+	s.config.status = http.StatusNotFound
+	_, err := s.source.Load(context.Background())
+	s.ErrorContains(err, "unexpected status 404 Not Found")
+
+	// A 304 without a previous result is not a valid answer.
+	s.config.status = http.StatusNotModified
+	_, err = s.source.Load(context.Background())
+	s.ErrorContains(err, "unexpected status 304")
+
+	s.config.status = 0
+	s.config.set(`{invalid`, "", time.Time{})
+	_, err = s.source.Load(context.Background())
+	s.ErrorContains(err, "failed to decode response")
+
+	s.server.Close()
+	_, err = s.source.Load(context.Background())
+	s.ErrorContains(err, "failed to fetch configuration")
+}
@@ -0,0 +1,132 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package source
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// IncludeKey is the key of include directives in configuration files. Its value is the path of a file, or a list
+// of paths, whose contents are merged into the map holding the directive, as in `$include: database.yaml`.
+const IncludeKey = "$include"
+
+// expandIncludes replaces the include directives in config, and in the maps nested in it, with the contents of the
+// included files. The included files are merged in order, and the keys next to the directive take precedence over
+// all of them. Relative paths are resolved against dir, the directory of the including file. stack holds the files
+// being included, outermost first, to detect cycles. The path of every included file is added to includes.
+func (f *File) expandIncludes(config map[string]any, dir string, stack []string, includes *[]string) error {
+	for _, v := range config {
+		if nested, ok := v.(map[string]any); ok {
+			if err := f.expandIncludes(nested, dir, stack, includes); err != nil {
+				return err
+			}
+		}
+	}
+
+	raw, ok := config[IncludeKey]
+	if !ok {
+		return nil
+	}
+	delete(config, IncludeKey)
+
+	paths, err := includePaths(raw)
+	if err != nil {
+		return err
+	}
+
+	merged := make(map[string]any)
+	for _, path := range paths {
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+		if slices.Contains(stack, path) {
+			return fmt.Errorf("include cycle: %s", strings.Join(append(slices.Clone(stack), path), " -> "))
+		}
+		if !slices.Contains(*includes, path) {
+			*includes = append(*includes, path)
+		}
+
+		included, err := f.decodeInclude(path)
+		if err != nil {
+			return err
+		}
+		if included == nil {
+			included = make(map[string]any)
+		}
+		if err := f.expandIncludes(included, filepath.Dir(path), append(slices.Clone(stack), path), includes); err != nil {
+			return err
+		}
+		mergeIncluded(merged, included)
+	}
+
+	mergeIncluded(merged, config)
+	clear(config)
+	for k, v := range merged {
+		config[k] = v
+	}
+	return nil
+}
+
+// decodeInclude reads and decodes the included file at path.
+func (f *File) decodeInclude(path string) (map[string]any, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read include: %w", err)
+	}
+	defer file.Close()
+
+	included, _, err := decodeReader(file, f.decoder)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode include %s: %w", path, err)
+	}
+	return included, nil
+}
+
+// includePaths returns the paths of an include directive, which is a path or a list of paths.
+func includePaths(raw any) ([]string, error) {
+	switch v := raw.(type) {
+	case string:
+		return []string{v}, nil
+	case []any:
+		paths := make([]string, len(v))
+		for i, item := range v {
+			path, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s must be a path or a list of paths, got %T in the list", IncludeKey, item)
+			}
+			paths[i] = path
+		}
+		return paths, nil
+	default:
+		return nil, fmt.Errorf("%s must be a path or a list of paths, got %T", IncludeKey, raw)
+	}
+}
+
+// mergeIncluded merges src into dst. Nested maps are merged recursively; other values in src replace those in dst.
+func mergeIncluded(dst, src map[string]any) {
+	for k, v := range src {
+		srcMap, srcOK := v.(map[string]any)
+		dstMap, dstOK := dst[k].(map[string]any)
+		if srcOK && dstOK {
+			mergeIncluded(dstMap, srcMap)
+			continue
+		}
+		dst[k] = v
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package source
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.companyinfo.dev/conflex/codec"
+)
+
+type IncludeTestSuite struct {
+	suite.Suite
+	dir string
+}
+
+func TestIncludeTestSuite(t *testing.T) {
+	suite.Run(t, new(IncludeTestSuite))
+}
+
+func (s *IncludeTestSuite) SetupTest() {
+	s.dir = s.T().TempDir()
+}
+
+func (s *IncludeTestSuite) write(name, content string) string {
+	path := filepath.Join(s.dir, name)
+	s.Require().NoError(os.MkdirAll(filepath.Dir(path), 0o755))
+	s.Require().NoError(os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func (s *IncludeTestSuite) load(path string) (map[string]any, error) {
+	return NewFile(path, codec.YAMLCodec{}).Load(nil)
+}
+
+func (s *IncludeTestSuite) TestTopLevelInclude() {
+	s.write("base.yaml", "host: localhost\nport: 8080\nlog:\n  level: debug\n  format: json\n")
+	path := s.write("app.yaml", "$include: base.yaml\nport: 9090\nlog:\n  level: info\n")
+
+	conf, err := s.load(path)
+	s.Require().NoError(err)
+	s.Equal(map[string]any{
+		"host": "localhost",
+		"port": uint64(9090),
+		"log":  map[string]any{"level": "info", "format": "json"},
+	}, conf)
+}
+
+func (s *IncludeTestSuite) TestNestedIncludeAndRelativePaths() {
+	s.write("conf.d/db.yaml", "$include: ../common/pool.yaml\nhost: db.internal\n")
+	s.write("common/pool.yaml", "pool:\n  size: 10\n")
+	path := s.write("app.yaml", "name: app\ndatabase:\n  $include: conf.d/db.yaml\n  pool:\n    size: 20\n")
+
+	conf, err := s.load(path)
+	s.Require().NoError(err)
+	s.Equal(map[string]any{
+		"name": "app",
+		"database": map[string]any{
+			"host": "db.internal",
+			"pool": map[string]any{"size": uint64(20)},
+		},
+	}, conf)
+}
+
+func (s *IncludeTestSuite) TestListOfIncludes() {
+	s.write("a.yaml", "x: a\ny: a\n")
+	s.write("b.yaml", "y: b\n")
+	path := s.write("app.yaml", "$include: [a.yaml, b.yaml]\n")
+
+	conf, err := s.load(path)
+	s.Require().NoError(err)
+	s.Equal(map[string]any{"x": "a", "y": "b"}, conf)
+}
+
+func (s *IncludeTestSuite) TestSharedIncludeIsNotACycle() {
+	s.write("common.yaml", "shared: true\n")
+	s.write("a.yaml", "$include: common.yaml\n")
+	path := s.write("app.yaml", "$include: [a.yaml, common.yaml]\n")
+
+	conf, err := s.load(path)
+	s.Require().NoError(err)
+	s.Equal(map[string]any{"shared": true}, conf)
+}
+
+func (s *IncludeTestSuite) TestCycle() {
+	s.write("a.yaml", "$include: b.yaml\n")
+	s.write("b.yaml", "$include: a.yaml\n")
+	path := s.write("app.yaml", "$include: a.yaml\n")
+
+	_, err := s.load(path)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "include cycle: ")
+	s.Contains(err.Error(), filepath.Join(s.dir, "a.yaml")+" -> "+filepath.Join(s.dir, "b.yaml")+" -> "+filepath.Join(s.dir, "a.yaml"))
+}
+
+func (s *IncludeTestSuite) TestSelfInclude() {
+	path := s.write("app.yaml", "$include: app.yaml\n")
+
+	_, err := s.load(path)
+	s.ErrorContains(err, "include cycle: ")
+}
+
+func (s *IncludeTestSuite) TestErrors() {
+	_, err := s.load(s.write("missing.yaml", "$include: nope.yaml\n"))
+	s.ErrorContains(err, "failed to read include")
+
+	s.write("broken.yaml", "a: [")
+	_, err = s.load(s.write("decode.yaml", "$include: broken.yaml\n"))
+	s.ErrorContains(err, "failed to decode include")
+
+	_, err = s.load(s.write("type.yaml", "$include: 42\n"))
+	s.ErrorContains(err, "$include must be a path or a list of paths")
+
+	_, err = s.load(s.write("list.yaml", "$include: [a.yaml, 1]\n"))
+	s.ErrorContains(err, "$include must be a path or a list of paths")
+}
+
+func (s *IncludeTestSuite) TestContentWithAbsolutePath() {
+	path := s.write("base.yaml", "host: localhost\n")
+
+	conf, err := NewFileContent([]byte("$include: "+path+"\n"), codec.YAMLCodec{}).Load(nil)
+	s.Require().NoError(err)
+	s.Equal(map[string]any{"host": "localhost"}, conf)
+}
+
+func (s *IncludeTestSuite) TestPaths() {
+	pool := s.write("database/pool.yaml", "size: 10\n")
+	shared := s.write("shared.yaml", "$include: database/pool.yaml\n")
+	broken := s.write("broken.yaml", "a: [")
+	path := s.write("app.yaml", "$include: shared.yaml\ndatabase:\n  $include: [database/pool.yaml, shared.yaml]\n")
+
+	file := NewFile(path, codec.YAMLCodec{})
+	s.Equal([]string{path}, file.Paths())
+
+	_, err := file.Load(nil)
+	s.Require().NoError(err)
+	s.Equal([]string{path, pool, shared}, file.Paths())
+
+	// A broken include is reported, so that fixing it can be observed.
+	s.write("app.yaml", "$include: broken.yaml\n")
+	_, err = file.Load(nil)
+	s.Require().Error(err)
+	s.Equal([]string{path, broken}, file.Paths())
+}
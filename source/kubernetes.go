@@ -0,0 +1,404 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package source
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"go.companyinfo.dev/conflex/codec"
+)
+
+// serviceAccountDir is where Kubernetes mounts the credentials of the pod's service account.
+const serviceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+
+// Backoff between attempts to re-establish a Kubernetes watch.
+const (
+	kubernetesMinBackoff = time.Second
+	kubernetesMaxBackoff = 30 * time.Second
+)
+
+// KubernetesConfig holds how to reach the Kubernetes API server.
+type KubernetesConfig struct {
+	Host      string       // URL of the API server, e.g. "https://10.0.0.1:443"
+	Token     string       // Bearer token; ignored if TokenFile is set
+	TokenFile string       // File holding the bearer token, read on every request so rotated tokens are picked up
+	Client    *http.Client // Client for requests; nil means http.DefaultClient
+}
+
+// KubernetesInClusterConfig returns the configuration for reaching the API server from inside a pod, using the
+// KUBERNETES_SERVICE_HOST and KUBERNETES_SERVICE_PORT variables and the credentials of the pod's service account.
+func KubernetesInClusterConfig() (KubernetesConfig, error) {
+	host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+	if host == "" || port == "" {
+		return KubernetesConfig{}, errors.New("not running in a Kubernetes cluster")
+	}
+
+	ca, err := os.ReadFile(filepath.Join(serviceAccountDir, "ca.crt"))
+	if err != nil {
+		return KubernetesConfig{}, fmt.Errorf("failed to read service account CA: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return KubernetesConfig{}, errors.New("invalid service account CA")
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	return KubernetesConfig{
+		Host:      "https://" + net.JoinHostPort(host, port),
+		TokenFile: filepath.Join(serviceAccountDir, "token"),
+		Client:    &http.Client{Transport: transport},
+	}, nil
+}
+
+// Kubernetes is a configuration source that reads a ConfigMap or a Secret from the Kubernetes API server.
+// It implements change notifications with the list-and-watch protocol that client-go informers use: Changes
+// keeps a watch on the object open, resuming from the last resource version it saw, so updates propagate
+// within seconds without polling the API server.
+type Kubernetes struct {
+	config    KubernetesConfig
+	resource  string // "configmaps" or "secrets"
+	namespace string
+	name      string
+	key       string
+	decoder   codec.Decoder
+
+	mu              sync.Mutex
+	resourceVersion string
+}
+
+// NewKubernetesConfigMap creates a source reading the ConfigMap name in namespace. If key is empty, every entry
+// of the ConfigMap becomes a top-level string value. Otherwise the entry key holds a document, such as
+// "config.yaml", which is decoded with decoder.
+func NewKubernetesConfigMap(config KubernetesConfig, namespace, name, key string, decoder codec.Decoder) (*Kubernetes, error) {
+	return newKubernetes(config, "configmaps", namespace, name, key, decoder)
+}
+
+// NewKubernetesSecret creates a source reading the Secret name in namespace, like NewKubernetesConfigMap.
+// The values of the Secret are base64-decoded before use.
+func NewKubernetesSecret(config KubernetesConfig, namespace, name, key string, decoder codec.Decoder) (*Kubernetes, error) {
+	return newKubernetes(config, "secrets", namespace, name, key, decoder)
+}
+
+func newKubernetes(config KubernetesConfig, resource, namespace, name, key string, decoder codec.Decoder) (*Kubernetes, error) {
+	u, err := url.Parse(config.Host)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, fmt.Errorf("invalid kubernetes host %q", config.Host)
+	}
+	if namespace == "" || name == "" {
+		return nil, errors.New("namespace and name cannot be empty")
+	}
+	if key != "" && decoder == nil {
+		return nil, errors.New("decoder cannot be nil")
+	}
+	if config.Client == nil {
+		config.Client = http.DefaultClient
+	}
+	config.Host = strings.TrimSuffix(config.Host, "/")
+	return &Kubernetes{config: config, resource: resource, namespace: namespace, name: name, key: key, decoder: decoder}, nil
+}
+
+// Path returns the path of the object in the API, such as "namespaces/default/configmaps/app".
+func (k *Kubernetes) Path() string {
+	return "namespaces/" + k.namespace + "/" + k.resource + "/" + k.name
+}
+
+// kubernetesObject holds the parts of a ConfigMap or Secret the source uses.
+type kubernetesObject struct {
+	Metadata struct {
+		Name            string `json:"name"`
+		ResourceVersion string `json:"resourceVersion"`
+	} `json:"metadata"`
+	Data map[string]string `json:"data"`
+}
+
+// Load reads the object and returns its data.
+func (k *Kubernetes) Load(ctx context.Context) (map[string]any, error) {
+	var object kubernetesObject
+	if err := k.get(ctx, "/api/v1/"+k.Path(), &object); err != nil {
+		return nil, err
+	}
+
+	k.mu.Lock()
+	k.resourceVersion = object.Metadata.ResourceVersion
+	k.mu.Unlock()
+
+	return k.decode(object)
+}
+
+// decode returns the configuration held by object.
+func (k *Kubernetes) decode(object kubernetesObject) (map[string]any, error) {
+	value := func(key string) (string, error) {
+		v := object.Data[key]
+		if k.resource != "secrets" {
+			return v, nil
+		}
+		decoded, err := base64.StdEncoding.DecodeString(v)
+		if err != nil {
+			return "", fmt.Errorf("invalid secret value %q: %w", key, err)
+		}
+		return string(decoded), nil
+	}
+
+	if k.key == "" {
+		config := make(map[string]any, len(object.Data))
+		for key := range object.Data {
+			v, err := value(key)
+			if err != nil {
+				return nil, err
+			}
+			config[key] = v
+		}
+		return config, nil
+	}
+
+	if _, ok := object.Data[k.key]; !ok {
+		return nil, fmt.Errorf("key %q not found in %s", k.key, k.Path())
+	}
+	data, err := value(k.key)
+	if err != nil {
+		return nil, err
+	}
+	var config map[string]any
+	if err := k.decoder.Decode([]byte(data), &config); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", k.key, err)
+	}
+	return config, nil
+}
+
+// Changes watches the object and sends a notification whenever it is modified, deleted or recreated.
+// The watch resumes from the resource version of the last Load, or of the last change it saw, and is
+// re-established with exponential backoff when the API server closes it. If the resource version has expired,
+// the object is read again, and a notification is sent because changes may have been missed.
+// The channel is closed once ctx is done.
+func (k *Kubernetes) Changes(ctx context.Context) (<-chan struct{}, error) {
+	changes := make(chan struct{}, 1)
+	go k.runWatch(ctx, changes)
+	return changes, nil
+}
+
+// runWatch keeps a watch on the object open until ctx is done.
+func (k *Kubernetes) runWatch(ctx context.Context, changes chan struct{}) {
+	defer close(changes)
+
+	notify := func() {
+		select {
+		case changes <- struct{}{}:
+		default:
+		}
+	}
+
+	backoff := kubernetesMinBackoff
+	for ctx.Err() == nil {
+		k.mu.Lock()
+		version := k.resourceVersion
+		k.mu.Unlock()
+
+		if version == "" {
+			// List first, so the watch starts from the current state rather than replaying history.
+			var object kubernetesObject
+			err := k.get(ctx, "/api/v1/"+k.Path(), &object)
+			var status *kubernetesStatusError
+			switch {
+			case err == nil:
+				version = object.Metadata.ResourceVersion
+			case errors.As(err, &status) && status.code == http.StatusNotFound:
+				version = "0" // Watch for the object to be created
+			default:
+				if !sleepContext(ctx, backoff) {
+					return
+				}
+				backoff = min(2*backoff, kubernetesMaxBackoff)
+				continue
+			}
+			k.setResourceVersion(version)
+		}
+
+		received, err := k.watch(ctx, version, notify)
+		if received {
+			backoff = kubernetesMinBackoff
+		}
+		var status *kubernetesStatusError
+		if errors.As(err, &status) && status.code == http.StatusGone {
+			// The resource version is too old to resume from: read the object again and report a change.
+			k.setResourceVersion("")
+			notify()
+			continue
+		}
+		if err != nil && !sleepContext(ctx, backoff) {
+			return
+		}
+		if err != nil {
+			backoff = min(2*backoff, kubernetesMaxBackoff)
+		}
+	}
+}
+
+// setResourceVersion records the resource version to resume watching from.
+func (k *Kubernetes) setResourceVersion(version string) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	k.resourceVersion = version
+}
+
+// kubernetesEvent is an event of a watch stream.
+type kubernetesEvent struct {
+	Type   string          `json:"type"`
+	Object json.RawMessage `json:"object"`
+}
+
+// watch streams the events of the object from version on, calling notify for every change, until the server
+// closes the stream or ctx is done. It reports whether any event was received.
+func (k *Kubernetes) watch(ctx context.Context, version string, notify func()) (bool, error) {
+	query := url.Values{
+		"watch":               {"true"},
+		"fieldSelector":       {"metadata.name=" + k.name},
+		"resourceVersion":     {version},
+		"allowWatchBookmarks": {"true"},
+	}
+	resp, err := k.do(ctx, "/api/v1/namespaces/"+k.namespace+"/"+k.resource+"?"+query.Encode())
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	received := false
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 16<<20)
+	for scanner.Scan() {
+		var event kubernetesEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			return received, fmt.Errorf("invalid watch event: %w", err)
+		}
+		received = true
+
+		if event.Type == "ERROR" {
+			var status kubernetesStatus
+			_ = json.Unmarshal(event.Object, &status)
+			return received, &kubernetesStatusError{code: status.Code, message: status.Message}
+		}
+
+		var object kubernetesObject
+		if err := json.Unmarshal(event.Object, &object); err != nil {
+			return received, fmt.Errorf("invalid watch event: %w", err)
+		}
+		k.setResourceVersion(object.Metadata.ResourceVersion)
+		switch event.Type {
+		case "ADDED", "MODIFIED", "DELETED":
+			if object.Metadata.Name == k.name {
+				notify()
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil && ctx.Err() == nil {
+		return received, err
+	}
+	return received, nil
+}
+
+// kubernetesStatus is the Status object the API server returns for failed requests.
+type kubernetesStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// kubernetesStatusError is a failed request to the API server.
+type kubernetesStatusError struct {
+	code    int
+	message string
+}
+
+func (e *kubernetesStatusError) Error() string {
+	if e.message == "" {
+		return fmt.Sprintf("kubernetes api: %d %s", e.code, http.StatusText(e.code))
+	}
+	return fmt.Sprintf("kubernetes api: %d %s", e.code, e.message)
+}
+
+// get reads the JSON document at path into v.
+func (k *Kubernetes) get(ctx context.Context, path string, v any) error {
+	resp, err := k.do(ctx, path)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("invalid response from kubernetes api: %w", err)
+	}
+	return nil
+}
+
+// do sends a GET request for path and returns the response, or an error if its status is not 200 OK.
+func (k *Kubernetes) do(ctx context.Context, path string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, k.config.Host+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	token := k.config.Token
+	if k.config.TokenFile != "" {
+		data, err := os.ReadFile(k.config.TokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read token: %w", err)
+		}
+		token = strings.TrimSpace(string(data))
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := k.config.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		var status kubernetesStatus
+		_ = json.NewDecoder(resp.Body).Decode(&status)
+		return nil, &kubernetesStatusError{code: resp.StatusCode, message: status.Message}
+	}
+	return resp, nil
+}
+
+// sleepContext waits for d, and reports false if ctx is done first.
+func sleepContext(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
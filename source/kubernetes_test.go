@@ -0,0 +1,242 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package source
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.companyinfo.dev/conflex/codec"
+)
+
+// apiServer is a fake Kubernetes API server holding a single object, which streams its changes to watchers.
+type apiServer struct {
+	mu       sync.Mutex
+	data     map[string]string
+	version  int
+	gone     bool // answer the next watch with a 410 Gone event
+	watchers []chan string
+	lists    int
+	token    string
+}
+
+func (a *apiServer) object() string {
+	object := map[string]any{
+		"metadata": map[string]any{"name": "app", "resourceVersion": strconv.Itoa(a.version)},
+		"data":     a.data,
+	}
+	data, _ := json.Marshal(object)
+	return string(data)
+}
+
+func (a *apiServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer "+a.token {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	a.mu.Lock()
+	if r.URL.Query().Get("watch") != "true" {
+		a.lists++
+		if a.data == nil {
+			a.mu.Unlock()
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"kind":"Status","code":404,"message":"configmaps \"app\" not found"}`))
+			return
+		}
+		body := a.object()
+		a.mu.Unlock()
+		_, _ = w.Write([]byte(body))
+		return
+	}
+	if a.gone {
+		a.gone = false
+		a.mu.Unlock()
+		_, _ = w.Write([]byte(`{"type":"ERROR","object":{"kind":"Status","code":410,"message":"too old resource version"}}` + "\n"))
+		return
+	}
+	events := make(chan string, 10)
+	a.watchers = append(a.watchers, events)
+	a.mu.Unlock()
+
+	w.WriteHeader(http.StatusOK)
+	w.(http.Flusher).Flush()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event := <-events:
+			_, _ = w.Write([]byte(event + "\n"))
+			w.(http.Flusher).Flush()
+		}
+	}
+}
+
+// update changes the object and sends an event of kind to the open watches.
+func (a *apiServer) update(kind string, data map[string]string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.data = data
+	a.version++
+	event := fmt.Sprintf(`{"type":%q,"object":%s}`, kind, a.object())
+	for _, watcher := range a.watchers {
+		watcher <- event
+	}
+}
+
+func (a *apiServer) watching() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return len(a.watchers)
+}
+
+type KubernetesSourceTestSuite struct {
+	suite.Suite
+	api    *apiServer
+	server *httptest.Server
+	config KubernetesConfig
+}
+
+func TestKubernetesSourceTestSuite(t *testing.T) {
+	suite.Run(t, new(KubernetesSourceTestSuite))
+}
+
+func (s *KubernetesSourceTestSuite) SetupTest() {
+	s.api = &apiServer{data: map[string]string{"config.json": `{"server": {"port": 8080}}`}, version: 1, token: "secret"}
+	s.server = httptest.NewServer(s.api)
+	s.config = KubernetesConfig{Host: s.server.URL, Token: "secret", Client: s.server.Client()}
+}
+
+func (s *KubernetesSourceTestSuite) TearDownTest() {
+	s.server.CloseClientConnections()
+	s.server.Close()
+}
+
+func (s *KubernetesSourceTestSuite) TestNew_Invalid() {
+	_, err := NewKubernetesConfigMap(KubernetesConfig{Host: "ftp://cluster"}, "default", "app", "", nil)
+	s.ErrorContains(err, `invalid kubernetes host "ftp://cluster"`)
+	_, err = NewKubernetesConfigMap(s.config, "", "app", "", nil)
+	s.ErrorContains(err, "namespace and name cannot be empty")
+	_, err = NewKubernetesSecret(s.config, "default", "app", "config.json", nil)
+	s.ErrorContains(err, "decoder cannot be nil")
+}
+
+func (s *KubernetesSourceTestSuite) TestLoad_Key() {
+	source, err := NewKubernetesConfigMap(s.config, "default", "app", "config.json", &codec.JSONCodec{})
+	s.Require().NoError(err)
+	s.Equal("namespaces/default/configmaps/app", source.Path())
+
+	config, err := source.Load(context.Background())
+	s.Require().NoError(err)
+	s.Equal(map[string]any{"server": map[string]any{"port": float64(8080)}}, config)
+
+	source, err = NewKubernetesConfigMap(s.config, "default", "app", "config.yaml", &codec.YAMLCodec{})
+	s.Require().NoError(err)
+	_, err = source.Load(context.Background())
+	s.ErrorContains(err, `key "config.yaml" not found in namespaces/default/configmaps/app`)
+}
+
+func (s *KubernetesSourceTestSuite) TestLoad_Secret() {
+	s.api.data = map[string]string{"password": base64.StdEncoding.EncodeToString([]byte("hunter2"))}
+
+	source, err := NewKubernetesSecret(s.config, "default", "app", "", nil)
+	s.Require().NoError(err)
+	config, err := source.Load(context.Background())
+	s.Require().NoError(err)
+	s.Equal(map[string]any{"password": "hunter2"}, config)
+
+	s.api.data = map[string]string{"password": "not base64!"}
+	_, err = source.Load(context.Background())
+	s.ErrorContains(err, `invalid secret value "password"`)
+}
+
+func (s *KubernetesSourceTestSuite) TestLoad_Errors() {
+	source, err := NewKubernetesConfigMap(s.config, "default", "app", "", nil)
+	s.Require().NoError(err)
+
+	s.api.data = nil
+	_, err = source.Load(context.Background())
+	s.EqualError(err, `kubernetes api: 404 configmaps "app" not found`)
+
+	s.config.Token = "wrong"
+	source, err = NewKubernetesConfigMap(s.config, "default", "app", "", nil)
+	s.Require().NoError(err)
+	_, err = source.Load(context.Background())
+	s.EqualError(err, "kubernetes api: 401 Unauthorized")
+}
+
+func (s *KubernetesSourceTestSuite) TestChanges() {
+	source, err := NewKubernetesConfigMap(s.config, "default", "app", "", nil)
+	s.Require().NoError(err)
+	_, err = source.Load(context.Background())
+	s.Require().NoError(err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	changes, err := source.Changes(ctx)
+	s.Require().NoError(err)
+	s.Eventually(func() bool { return s.api.watching() == 1 }, time.Second, 10*time.Millisecond)
+
+	s.api.update("MODIFIED", map[string]string{"level": "debug"})
+	s.Require().Eventually(func() bool {
+		select {
+		case <-changes:
+			return true
+		default:
+			return false
+		}
+	}, time.Second, 10*time.Millisecond)
+
+	config, err := source.Load(context.Background())
+	s.Require().NoError(err)
+	s.Equal(map[string]any{"level": "debug"}, config)
+
+	cancel()
+	s.Eventually(func() bool {
+		_, open := <-changes
+		return !open
+	}, time.Second, 10*time.Millisecond)
+}
+
+func (s *KubernetesSourceTestSuite) TestChanges_Gone() {
+	source, err := NewKubernetesConfigMap(s.config, "default", "app", "", nil)
+	s.Require().NoError(err)
+	s.api.gone = true
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	changes, err := source.Changes(ctx)
+	s.Require().NoError(err)
+
+	// The expired resource version is reported as a change, and the object is listed again.
+	select {
+	case <-changes:
+	case <-time.After(time.Second):
+		s.Fail("expected a change after the watch expired")
+	}
+	s.Eventually(func() bool { return s.api.watching() == 1 }, time.Second, 10*time.Millisecond)
+	s.api.mu.Lock()
+	s.Equal(2, s.api.lists)
+	s.api.mu.Unlock()
+}
@@ -0,0 +1,156 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package source
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Transformer decodes string values marked with a prefix into native values.
+type Transformer struct {
+	Prefix string                          // Prefix marking the values to decode, e.g. "base64:"
+	Decode func(value string) (any, error) // Decodes a value, without its prefix
+}
+
+var (
+	// Base64Transformer decodes standard base64 values such as "base64:aGVsbG8=" into strings.
+	Base64Transformer = Transformer{Prefix: "base64:", Decode: func(value string) (any, error) {
+		data, err := base64.StdEncoding.DecodeString(value)
+		return string(data), err
+	}}
+
+	// HexTransformer decodes hexadecimal values such as "hex:68656c6c6f" into strings.
+	HexTransformer = Transformer{Prefix: "hex:", Decode: func(value string) (any, error) {
+		data, err := hex.DecodeString(value)
+		return string(data), err
+	}}
+
+	// JSONTransformer decodes JSON values such as `json:{"a": 1}` into maps, lists and scalars.
+	JSONTransformer = Transformer{Prefix: "json:", Decode: func(value string) (any, error) {
+		var v any
+		err := json.Unmarshal([]byte(value), &v)
+		return v, err
+	}}
+)
+
+// DefaultTransformers are the transformers used by NewTransformed when none are given.
+var DefaultTransformers = []Transformer{Base64Transformer, HexTransformer, JSONTransformer}
+
+// Transformed is a source that wraps another source and decodes its prefixed string values into native values,
+// such as "base64:aGVsbG8=" into "hello" or `json:{"a": 1}` into a map. Transformers are configured per source,
+// so only the sources expected to contain encoded values are affected.
+type Transformed struct {
+	source       Loader
+	transformers []Transformer
+}
+
+// NewTransformed creates a Transformed source that wraps source and applies the given transformers, or the
+// DefaultTransformers if none are given. The first transformer whose prefix matches a value decodes it.
+func NewTransformed(source Loader, transformers ...Transformer) (*Transformed, error) {
+	if source == nil {
+		return nil, errors.New("source cannot be nil")
+	}
+	if len(transformers) == 0 {
+		transformers = DefaultTransformers
+	}
+	for i, t := range transformers {
+		if t.Prefix == "" || t.Decode == nil {
+			return nil, fmt.Errorf("transformer %d must have a prefix and a decode function", i)
+		}
+	}
+	return &Transformed{source: source, transformers: transformers}, nil
+}
+
+// Path returns the path of the wrapped source if it has one, so file watching keeps working.
+func (t *Transformed) Path() string {
+	if ps, ok := t.source.(interface{ Path() string }); ok {
+		return ps.Path()
+	}
+	return ""
+}
+
+// Paths returns the paths of the wrapped source if it has them, so files it includes are watched too.
+func (t *Transformed) Paths() []string {
+	if ps, ok := t.source.(interface{ Paths() []string }); ok {
+		return ps.Paths()
+	}
+	return nil
+}
+
+// Load loads the wrapped source and decodes its prefixed values, in maps and lists at any depth.
+// It fails if a prefixed value cannot be decoded.
+func (t *Transformed) Load(ctx context.Context) (map[string]any, error) {
+	config, err := t.source.Load(ctx)
+	if err != nil || config == nil {
+		return config, err
+	}
+
+	out, err := t.transform("", config)
+	if err != nil {
+		return nil, err
+	}
+	return out.(map[string]any), nil
+}
+
+// transform returns a copy of v, the value at key, with its prefixed values decoded.
+func (t *Transformed) transform(key string, v any) (any, error) {
+	switch val := v.(type) {
+	case string:
+		for _, transformer := range t.transformers {
+			encoded, ok := strings.CutPrefix(val, transformer.Prefix)
+			if !ok {
+				continue
+			}
+			decoded, err := transformer.Decode(encoded)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode %s value of %s: %w", strings.TrimSuffix(transformer.Prefix, ":"), key, err)
+			}
+			return decoded, nil
+		}
+		return val, nil
+	case map[string]any:
+		out := make(map[string]any, len(val))
+		for k, item := range val {
+			itemKey := k
+			if key != "" {
+				itemKey = key + "." + k
+			}
+			transformed, err := t.transform(itemKey, item)
+			if err != nil {
+				return nil, err
+			}
+			out[k] = transformed
+		}
+		return out, nil
+	case []any:
+		out := make([]any, len(val))
+		for i, item := range val {
+			transformed, err := t.transform(key+"."+strconv.Itoa(i), item)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = transformed
+		}
+		return out, nil
+	}
+	return v, nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package source
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.companyinfo.dev/conflex/codec"
+)
+
+type TransformedTestSuite struct {
+	suite.Suite
+}
+
+func TestTransformedTestSuite(t *testing.T) {
+	suite.Run(t, new(TransformedTestSuite))
+}
+
+func (s *TransformedTestSuite) TestDefaultTransformers() {
+	src, err := NewTransformed(staticLoader{config: map[string]any{
+		"cert":    "base64:aGVsbG8=",
+		"key":     "hex:776f726c64",
+		"limits":  `json:{"rps": 10, "burst": [1, 2]}`,
+		"plain":   "https://example.com",
+		"port":    8080,
+		"servers": []any{map[string]any{"token": "base64:dG9rZW4="}},
+	}})
+	s.Require().NoError(err)
+
+	conf, err := src.Load(context.Background())
+	s.Require().NoError(err)
+	s.Equal("hello", conf["cert"])
+	s.Equal("world", conf["key"])
+	s.Equal(map[string]any{"rps": float64(10), "burst": []any{float64(1), float64(2)}}, conf["limits"])
+	s.Equal("https://example.com", conf["plain"])
+	s.Equal(8080, conf["port"])
+	s.Equal([]any{map[string]any{"token": "token"}}, conf["servers"])
+}
+
+func (s *TransformedTestSuite) TestCustomTransformers() {
+	upper := Transformer{Prefix: "upper:", Decode: func(value string) (any, error) {
+		return strings.ToUpper(value), nil
+	}}
+	src, err := NewTransformed(staticLoader{config: map[string]any{"a": "upper:abc", "b": "base64:aGVsbG8="}}, upper)
+	s.Require().NoError(err)
+
+	conf, err := src.Load(context.Background())
+	s.Require().NoError(err)
+	s.Equal(map[string]any{"a": "ABC", "b": "base64:aGVsbG8="}, conf)
+}
+
+func (s *TransformedTestSuite) TestErrors() {
+	src, err := NewTransformed(staticLoader{config: map[string]any{"db": map[string]any{"password": "base64:!!"}}})
+	s.Require().NoError(err)
+	_, err = src.Load(context.Background())
+	s.ErrorContains(err, "failed to decode base64 value of db.password")
+
+	loadErr := errors.New("unavailable")
+	src, err = NewTransformed(staticLoader{err: loadErr})
+	s.Require().NoError(err)
+	_, err = src.Load(context.Background())
+	s.ErrorIs(err, loadErr)
+}
+
+func (s *TransformedTestSuite) TestInvalid() {
+	_, err := NewTransformed(nil)
+	s.Error(err)
+	_, err = NewTransformed(staticLoader{}, Transformer{Prefix: "x:"})
+	s.Error(err)
+}
+
+func (s *TransformedTestSuite) TestPath() {
+	src, err := NewTransformed(NewFile("config.yaml", codec.YAMLCodec{}))
+	s.Require().NoError(err)
+	s.Equal("config.yaml", src.Path())
+
+	src, err = NewTransformed(staticLoader{})
+	s.Require().NoError(err)
+	s.Empty(src.Path())
+}
@@ -0,0 +1,164 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SourceInfo describes a configured source, for operational tooling and startup logs.
+type SourceInfo struct {
+	Name     string            // The name of the source, as used in errors and by Reload (e.g., "consul-app" or "source[0]")
+	Type     string            // The kind of source: "file", "content", "env", "consul", "http", or the Go type of a custom source
+	Target   string            // What the source reads: a file path, an environment variable prefix, a Consul key, or a URL
+	Options  map[string]string // Source-specific settings, such as the codec
+	Optional bool              // Whether Load continues without the source when it fails to load
+}
+
+// String returns a one-line description of the source, such as `source[0] file config.yaml (codec=yaml)`.
+func (i SourceInfo) String() string {
+	var b strings.Builder
+	b.WriteString(i.Name)
+	b.WriteString(" ")
+	b.WriteString(i.Type)
+	if i.Target != "" {
+		b.WriteString(" ")
+		b.WriteString(i.Target)
+	}
+	if len(i.Options) > 0 {
+		keys := make([]string, 0, len(i.Options))
+		for k := range i.Options {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		options := make([]string, len(keys))
+		for j, k := range keys {
+			options[j] = k + "=" + i.Options[k]
+		}
+		fmt.Fprintf(&b, " (%s)", strings.Join(options, ", "))
+	}
+	return b.String()
+}
+
+// Sources returns descriptions of the configured sources in the order they are merged,
+// so later entries take precedence over earlier ones.
+func (c *Conflex) Sources() []SourceInfo {
+	if c == nil {
+		return nil
+	}
+
+	infos := make([]SourceInfo, len(c.sources))
+	for i := range c.sources {
+		infos[i] = c.sourceInfo(i)
+	}
+	return infos
+}
+
+// sourceInfo returns a copy of the description of source i, with its name filled in.
+func (c *Conflex) sourceInfo(i int) SourceInfo {
+	info := c.sourceInfos[i]
+	info.Name = c.sourceName(i)
+	if info.Options != nil {
+		options := make(map[string]string, len(info.Options))
+		for k, v := range info.Options {
+			options[k] = v
+		}
+		info.Options = options
+	}
+	return info
+}
+
+// WithNamedSource adds a source under the given name. The name is used instead of the source's position,
+// such as "source[2]", in errors, in Sources and by Reload, so operational tooling can address the source
+// by a name that does not change when other sources are added. Names must be unique.
+func WithNamedSource(name string, src Source) Option {
+	return func(c *Conflex) error {
+		if src == nil {
+			return errors.New("source cannot be nil")
+		}
+		if err := c.checkSourceName(name); err != nil {
+			return err
+		}
+
+		info := describeCustomSource(src)
+		info.Name = name
+		c.addSource(src, info)
+		return nil
+	}
+}
+
+// checkSourceName returns an error if name cannot be used as the name of a new source.
+func (c *Conflex) checkSourceName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return errors.New("source name cannot be empty")
+	}
+	if strings.HasPrefix(name, "source[") {
+		return fmt.Errorf("source name %q is reserved for unnamed sources", name)
+	}
+	for i := range c.sources {
+		if c.sourceName(i) == name {
+			return fmt.Errorf("duplicate source name %q", name)
+		}
+	}
+	return nil
+}
+
+// WithRequiredSource adds a source that must load for Load to succeed. This is the same as WithSource,
+// since sources are required unless added with WithOptionalSource; it makes the intent explicit when
+// required and optional sources are mixed.
+func WithRequiredSource(src Source) Option {
+	return func(c *Conflex) error {
+		if src == nil {
+			return errors.New("source cannot be nil")
+		}
+		c.addSource(src, describeCustomSource(src))
+		return nil
+	}
+}
+
+// WithOptionalSource adds a source that Load can do without, such as a remote store that may be briefly
+// unavailable while local files provide working defaults. When the source fails to load, the failure is
+// logged as a warning and the source's result from the last successful load is used instead; before the
+// source has loaded once, it contributes no values. A context that is canceled or expired still fails
+// the load, and so does a source that loads but fails per-source validation.
+func WithOptionalSource(src Source) Option {
+	return func(c *Conflex) error {
+		if src == nil {
+			return errors.New("source cannot be nil")
+		}
+		info := describeCustomSource(src)
+		info.Optional = true
+		c.addSource(src, info)
+		return nil
+	}
+}
+
+// addSource appends a source together with its description.
+func (c *Conflex) addSource(src Source, info SourceInfo) {
+	c.sources = append(c.sources, src)
+	c.sourceInfos = append(c.sourceInfos, info)
+}
+
+// describeCustomSource describes a source added with WithSource.
+func describeCustomSource(src Source) SourceInfo {
+	info := SourceInfo{Type: fmt.Sprintf("%T", src)}
+	if ps, ok := src.(pathSource); ok {
+		info.Target = ps.Path()
+	}
+	return info
+}
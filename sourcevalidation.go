@@ -0,0 +1,95 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// sourceValidation holds the validation attached to a single source with WithValidatedSource.
+type sourceValidation struct {
+	schema     *jsonschema.Schema
+	validators []func(map[string]any) error
+}
+
+// WithValidatedSource adds a source whose data is validated on its own, before it is merged with the other
+// sources, so an error points at the backend that produced the invalid data rather than at the merged result.
+// The data is validated against schema, if not nil, and by each of the validators. Like the merged
+// configuration, the data is validated with lowercase keys. A validation failure fails the load with a
+// ConfigError naming the source, and the current configuration stays in effect.
+//
+//	conflex.WithValidatedSource(source.NewOSEnvVar("MYAPP_"), envSchema)
+func WithValidatedSource(src Source, schema []byte, validators ...func(map[string]any) error) Option {
+	return func(c *Conflex) error {
+		if src == nil {
+			return errors.New("source cannot be nil")
+		}
+		if schema == nil && len(validators) == 0 {
+			return errors.New("a schema or at least one validator is required")
+		}
+
+		validation := sourceValidation{validators: validators}
+		if schema != nil {
+			compiled, err := CompileJSONSchema(schema)
+			if err != nil {
+				return NewConfigError("source-schema", "compile", err)
+			}
+			validation.schema = compiled
+		}
+
+		if c.sourceValidations == nil {
+			c.sourceValidations = make(map[int]sourceValidation)
+		}
+		c.sourceValidations[len(c.sources)] = validation
+		c.addSource(src, describeCustomSource(src))
+		return nil
+	}
+}
+
+// validateSource validates the loaded data of the source at index i, if validation is attached to it.
+// All failures are reported, joined.
+func (c *Conflex) validateSource(i int, values map[string]any) error {
+	validation, ok := c.sourceValidations[i]
+	if !ok {
+		return nil
+	}
+
+	var errs []error
+	if validation.schema != nil {
+		if err := validation.schema.Validate(values); err != nil {
+			details := schemaValidationErrors(err, values)
+			for _, detail := range details {
+				detail.Source = c.sourceName(i)
+			}
+			errs = append(errs, withDetails(err, details))
+		}
+	}
+	for j, fn := range validation.validators {
+		if fn == nil {
+			continue
+		}
+		if err := callValidator(fn, values); err != nil {
+			errs = append(errs, fmt.Errorf("validator[%d]: %w", j, err))
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return NewConfigError(c.sourceName(i), "validate", errors.Join(errs...))
+}
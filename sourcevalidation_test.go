@@ -0,0 +1,99 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type SourceValidationTestSuite struct {
+	suite.Suite
+}
+
+func TestSourceValidationTestSuite(t *testing.T) {
+	suite.Run(t, new(SourceValidationTestSuite))
+}
+
+const portSchema = `{"type":"object","properties":{"port":{"type":"integer"}}}`
+
+func (s *SourceValidationTestSuite) TestPinpointsSource() {
+	c, err := New(
+		WithSource(&mockSource{conf: map[string]any{"port": 8080}}),
+		WithValidatedSource(&mockSource{conf: map[string]any{"PORT": "http"}}, []byte(portSchema)),
+	)
+	s.Require().NoError(err)
+
+	err = c.Load(context.Background())
+	s.Require().Error(err)
+
+	var configErr *ConfigError
+	s.Require().ErrorAs(err, &configErr)
+	s.Equal("source[1]", configErr.Source)
+	s.Equal("validate", configErr.Operation)
+}
+
+func (s *SourceValidationTestSuite) TestValidOverridesInvalidMergedResult() {
+	// Validation runs before merging, so a later source cannot hide invalid data in an earlier one.
+	c, err := New(
+		WithValidatedSource(&mockSource{conf: map[string]any{"port": "http"}}, []byte(portSchema)),
+		WithSource(&mockSource{conf: map[string]any{"port": 8080}}),
+	)
+	s.Require().NoError(err)
+	s.Error(c.Load(context.Background()))
+}
+
+func (s *SourceValidationTestSuite) TestValidators() {
+	called := 0
+	c, err := New(
+		WithValidatedSource(&mockSource{conf: map[string]any{"port": 8080}}, nil,
+			func(values map[string]any) error {
+				called++
+				return nil
+			},
+			func(values map[string]any) error { return errors.New("port is reserved") },
+			func(values map[string]any) error { panic("boom") },
+		),
+	)
+	s.Require().NoError(err)
+
+	err = c.Load(context.Background())
+	s.Require().Error(err)
+	s.Equal(1, called)
+	s.Contains(err.Error(), "validator[1]: port is reserved")
+	s.Contains(err.Error(), "validator[2]: validator panic: boom")
+}
+
+func (s *SourceValidationTestSuite) TestValid() {
+	c, err := New(WithValidatedSource(&mockSource{conf: map[string]any{"port": 8080}}, []byte(portSchema)))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+	s.Equal(8080, c.GetInt("port"))
+	s.Len(c.Sources(), 1)
+}
+
+func (s *SourceValidationTestSuite) TestInvalidOptions() {
+	_, err := NewStrict(WithValidatedSource(nil, []byte(portSchema)))
+	s.Error(err)
+
+	_, err = NewStrict(WithValidatedSource(&mockSource{}, nil))
+	s.Error(err)
+
+	_, err = NewStrict(WithValidatedSource(&mockSource{}, []byte(`{"type":`)))
+	s.Error(err)
+}
@@ -0,0 +1,64 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/go-viper/mapstructure/v2"
+)
+
+// WithSliceSeparator makes binding split strings on sep into any slice or array field, so that
+// WEBAPP_ROLES=admin,user binds into Roles []string and WEBAPP_PORTS=80,443 into Ports []int.
+// Whitespace around the elements is trimmed and empty elements are dropped; each element is then decoded
+// like any other value, so durations, sizes and numbers work as elements too.
+//
+// Without this option, only []string fields are split, on commas and without trimming.
+func WithSliceSeparator(sep string) Option {
+	return func(c *Conflex) error {
+		if sep == "" {
+			return errors.New("slice separator cannot be empty")
+		}
+		c.sliceSeparator = sep
+		return nil
+	}
+}
+
+// splitStringHookFunc returns a decode hook that splits strings on sep into slices and arrays of any type.
+// Byte slices are left alone, so strings still decode into []byte as their bytes.
+func splitStringHookFunc(sep string) mapstructure.DecodeHookFuncType {
+	return func(from reflect.Type, to reflect.Type, data any) (any, error) {
+		if from.Kind() != reflect.String || (to.Kind() != reflect.Slice && to.Kind() != reflect.Array) {
+			return data, nil
+		}
+		if to.Elem().Kind() == reflect.Uint8 {
+			return data, nil
+		}
+
+		raw, ok := data.(string)
+		if !ok {
+			return data, nil
+		}
+		elems := []string{}
+		for _, elem := range strings.Split(raw, sep) {
+			if elem = strings.TrimSpace(elem); elem != "" {
+				elems = append(elems, elem)
+			}
+		}
+		return elems, nil
+	}
+}
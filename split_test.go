@@ -0,0 +1,87 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type splitConfig struct {
+	Roles    []string        `conflex:"roles"`
+	Ports    []int           `conflex:"ports"`
+	Timeouts []time.Duration `conflex:"timeouts"`
+	Pair     [2]string       `conflex:"pair"`
+	Key      []byte          `conflex:"key"`
+	Hosts    []string        `conflex:"hosts"`
+}
+
+type SplitTestSuite struct {
+	suite.Suite
+}
+
+func TestSplitTestSuite(t *testing.T) {
+	suite.Run(t, new(SplitTestSuite))
+}
+
+func (s *SplitTestSuite) bind(conf map[string]any, opts ...Option) (splitConfig, error) {
+	var cfg splitConfig
+	c, err := New(append([]Option{WithSource(&mockSource{conf: conf}), WithBinding(&cfg)}, opts...)...)
+	s.Require().NoError(err)
+	return cfg, c.Load(context.Background())
+}
+
+func (s *SplitTestSuite) TestWithSliceSeparator() {
+	cfg, err := s.bind(map[string]any{
+		"roles":    "admin, user,",
+		"ports":    "80,443",
+		"timeouts": "1s,2m",
+		"pair":     "a,b",
+		"key":      "a,b",
+		"hosts":    []any{"x", "y"},
+	}, WithSliceSeparator(","))
+	s.Require().NoError(err)
+
+	s.Equal([]string{"admin", "user"}, cfg.Roles)
+	s.Equal([]int{80, 443}, cfg.Ports)
+	s.Equal([]time.Duration{time.Second, 2 * time.Minute}, cfg.Timeouts)
+	s.Equal([2]string{"a", "b"}, cfg.Pair)
+	s.Equal([]byte("a,b"), cfg.Key)
+	s.Equal([]string{"x", "y"}, cfg.Hosts)
+}
+
+func (s *SplitTestSuite) TestCustomSeparator() {
+	cfg, err := s.bind(map[string]any{"roles": "admin;user", "ports": ""}, WithSliceSeparator(";"))
+	s.Require().NoError(err)
+	s.Equal([]string{"admin", "user"}, cfg.Roles)
+	s.Empty(cfg.Ports)
+}
+
+func (s *SplitTestSuite) TestDefaultSplitsStringSlicesOnly() {
+	cfg, err := s.bind(map[string]any{"roles": "admin,user"})
+	s.Require().NoError(err)
+	s.Equal([]string{"admin", "user"}, cfg.Roles)
+
+	_, err = s.bind(map[string]any{"ports": "80,443"})
+	s.Error(err)
+}
+
+func (s *SplitTestSuite) TestInvalidSeparator() {
+	_, err := NewStrict(WithSliceSeparator(""))
+	s.ErrorContains(err, "slice separator cannot be empty")
+}
@@ -0,0 +1,84 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"regexp"
+	"time"
+
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/spf13/cast"
+)
+
+var (
+	locationType = reflect.TypeOf(time.Location{})
+	regexpType   = reflect.TypeOf(regexp.Regexp{})
+	bigIntType   = reflect.TypeOf(big.Int{})
+	bigFloatType = reflect.TypeOf(big.Float{})
+)
+
+// stdTypesHookFunc returns a decode hook that converts strings to *time.Location (by IANA name, such as
+// "Europe/Amsterdam"), *regexp.Regexp, *big.Int and *big.Float. Fields may be of the type itself or a pointer
+// to it. Big numbers also accept integers and, for *big.Float, floating-point numbers.
+func stdTypesHookFunc() mapstructure.DecodeHookFuncType {
+	return func(from reflect.Type, to reflect.Type, data any) (any, error) {
+		if to.Kind() == reflect.Ptr {
+			to = to.Elem()
+		}
+		if from == to || from == reflect.PointerTo(to) {
+			return data, nil
+		}
+
+		switch to {
+		case locationType:
+			name, err := cast.ToStringE(data)
+			if err != nil {
+				return nil, err
+			}
+			return time.LoadLocation(name)
+		case regexpType:
+			expr, err := cast.ToStringE(data)
+			if err != nil {
+				return nil, err
+			}
+			return regexp.Compile(expr)
+		case bigIntType:
+			s, err := cast.ToStringE(data)
+			if err != nil {
+				return nil, err
+			}
+			n, ok := new(big.Int).SetString(s, 0)
+			if !ok {
+				return nil, fmt.Errorf("invalid integer %q", s)
+			}
+			return n, nil
+		case bigFloatType:
+			s, err := cast.ToStringE(data)
+			if err != nil {
+				return nil, err
+			}
+			f, _, err := big.ParseFloat(s, 10, 0, big.ToNearestEven)
+			if err != nil {
+				return nil, fmt.Errorf("invalid number %q: %w", s, err)
+			}
+			return f, nil
+		default:
+			return data, nil
+		}
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"context"
+	"math/big"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type stdTypesConfig struct {
+	Zone   *time.Location   `conflex:"zone"`
+	Local  time.Location    `conflex:"local"`
+	Match  *regexp.Regexp   `conflex:"match"`
+	Rules  []*regexp.Regexp `conflex:"rules"`
+	Supply *big.Int         `conflex:"supply"`
+	Count  big.Int          `conflex:"count"`
+	Ratio  *big.Float       `conflex:"ratio"`
+}
+
+type StdTypesTestSuite struct {
+	suite.Suite
+}
+
+func TestStdTypesTestSuite(t *testing.T) {
+	suite.Run(t, new(StdTypesTestSuite))
+}
+
+func (s *StdTypesTestSuite) bind(conf map[string]any) (stdTypesConfig, error) {
+	var cfg stdTypesConfig
+	c, err := New(WithSource(&mockSource{conf: conf}), WithBinding(&cfg))
+	s.Require().NoError(err)
+	return cfg, c.Load(context.Background())
+}
+
+func (s *StdTypesTestSuite) TestDecode() {
+	cfg, err := s.bind(map[string]any{
+		"zone":   "Europe/Amsterdam",
+		"local":  "UTC",
+		"match":  "^foo-[0-9]+$",
+		"rules":  []any{"^a", "b$"},
+		"supply": "123456789012345678901234567890",
+		"count":  42,
+		"ratio":  "3.14159265358979323846",
+	})
+	s.Require().NoError(err)
+
+	s.Require().NotNil(cfg.Zone)
+	s.Equal("Europe/Amsterdam", cfg.Zone.String())
+	s.Equal("UTC", cfg.Local.String())
+	s.Require().NotNil(cfg.Match)
+	s.True(cfg.Match.MatchString("foo-12"))
+	s.False(cfg.Match.MatchString("bar-12"))
+	s.Require().Len(cfg.Rules, 2)
+	s.Equal("b$", cfg.Rules[1].String())
+	s.Equal("123456789012345678901234567890", cfg.Supply.String())
+	s.Equal(int64(42), cfg.Count.Int64())
+	s.Equal("3.141592654", cfg.Ratio.Text('f', 9))
+}
+
+func (s *StdTypesTestSuite) TestBigIntPrefixes() {
+	cfg, err := s.bind(map[string]any{"supply": "0xff"})
+	s.Require().NoError(err)
+	s.Equal(int64(255), cfg.Supply.Int64())
+}
+
+func (s *StdTypesTestSuite) TestInvalidValues() {
+	for key, value := range map[string]any{
+		"zone":   "Mars/Olympus_Mons",
+		"match":  "([a-z",
+		"supply": "12.5",
+		"ratio":  "pi",
+	} {
+		_, err := s.bind(map[string]any{key: value})
+		s.Error(err, key)
+	}
+}
+
+func (s *StdTypesTestSuite) TestDefaults() {
+	type withDefaults struct {
+		Zone  *time.Location `conflex:"zone,default=UTC"`
+		Match *regexp.Regexp `conflex:"match,default=^v[0-9]+$"`
+	}
+	var cfg withDefaults
+	c, err := New(WithSource(&mockSource{conf: map[string]any{}}), WithBinding(&cfg))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	s.Equal("UTC", cfg.Zone.String())
+	s.True(cfg.Match.MatchString("v2"))
+}
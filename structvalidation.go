@@ -0,0 +1,168 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// StructValidator validates a struct, typically against rules declared in `validate` struct tags.
+// Conflex does not read the tags itself: *validator.Validate from github.com/go-playground/validator/v10
+// implements StructValidator, and the validators/playground module provides one ready to use, so conflex
+// does not depend on the validator:
+//
+//	cfg, err := conflex.New(
+//		conflex.WithFileSource("config.yaml", codec.TypeYAML),
+//		conflex.WithBinding(&appConfig),
+//		playground.WithValidation(),
+//	)
+type StructValidator interface {
+	Struct(s any) error
+}
+
+// validationFieldError is the subset of go-playground's validator.FieldError used to address
+// validation errors by configuration key.
+type validationFieldError interface {
+	StructNamespace() string
+	Tag() string
+	Param() string
+	Value() any
+}
+
+// WithStructValidation validates the bound struct with v each time the configuration is loaded,
+// after decoding and before the configuration is applied, so an invalid configuration never replaces
+// the current one. It has no effect without WithBinding.
+// Validation errors reported by go-playground's validator are returned as one ConfigError per field,
+// addressed by the configuration key of the field (e.g. "binding.server.port"), joined together.
+func WithStructValidation(v StructValidator) Option {
+	return func(c *Conflex) error {
+		if v == nil {
+			return errors.New("struct validator cannot be nil")
+		}
+		c.structValidator = v
+		return nil
+	}
+}
+
+// validateStruct runs the struct validator on the decoded binding target, which is bound below prefix.
+func (c *Conflex) validateStruct(target any, prefix string) error {
+	if c.structValidator == nil {
+		return nil
+	}
+
+	err := c.structValidator.Struct(target)
+	if err == nil {
+		return nil
+	}
+
+	fieldErrors := reflect.ValueOf(err)
+	if fieldErrors.Kind() != reflect.Slice {
+		return err
+	}
+
+	var errs []error
+	for i := 0; i < fieldErrors.Len(); i++ {
+		fieldErr, ok := fieldErrors.Index(i).Interface().(validationFieldError)
+		if !ok {
+			return err
+		}
+		rule := fieldErr.Tag()
+		if fieldErr.Param() != "" {
+			rule += "=" + fieldErr.Param()
+		}
+		key := validationKey(reflect.TypeOf(target), fieldErr.StructNamespace())
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		ruleErr := fmt.Errorf("failed the %q rule", rule)
+		detail := &ValidationError{
+			Path:     jsonPointer(strings.Split(key, ".")),
+			Expected: rule,
+			Actual:   fieldErr.Value(),
+			Message:  ruleErr.Error(),
+		}
+		errs = append(errs, NewConfigFieldError("binding", key, "validate", withDetails(ruleErr, []*ValidationError{detail})))
+	}
+
+	return errors.Join(errs...)
+}
+
+// validationKey converts a validator struct namespace, such as "AppConfig.Servers[0].Port", into the
+// configuration key of the field in the struct type t, such as "servers.0.port".
+// Segments that do not match a bound field are lowercased.
+func validationKey(t reflect.Type, namespace string) string {
+	// The namespace starts with the name of the validated struct type.
+	_, namespace, _ = strings.Cut(namespace, ".")
+
+	// goPath is the path of Go field names below the struct type t, whose fields are keyed below base.
+	var base, goPath, key string
+	fields := structFieldKeys(t)
+	for _, segment := range strings.Split(namespace, ".") {
+		name, index, indexed := strings.Cut(segment, "[")
+
+		if goPath != "" {
+			goPath += "."
+		}
+		goPath += name
+
+		field, ok := fields[goPath]
+		switch {
+		case ok:
+			key = base + field.path
+		case key == "":
+			key = strings.ToLower(name)
+		default:
+			key += "." + strings.ToLower(name)
+		}
+
+		if !indexed {
+			continue
+		}
+		key += "." + strings.TrimSuffix(index, "]")
+
+		// Continue with the fields of the element type.
+		fields = nil
+		if ok {
+			elem := field.typ
+			for elem.Kind() == reflect.Ptr {
+				elem = elem.Elem()
+			}
+			if kind := elem.Kind(); kind == reflect.Slice || kind == reflect.Array || kind == reflect.Map {
+				fields = structFieldKeys(elem.Elem())
+			}
+		}
+		base, goPath = key+".", ""
+	}
+
+	return key
+}
+
+// fieldKey is the configuration key path and type of a bound struct field.
+type fieldKey struct {
+	path string
+	typ  reflect.Type
+}
+
+// structFieldKeys maps the Go field paths of the struct type t, such as "Server.Port", to their keys.
+func structFieldKeys(t reflect.Type) map[string]fieldKey {
+	fields := make(map[string]fieldKey)
+	walkFieldPaths(t, func(goPath, path string, field reflect.StructField, _ fieldTag) {
+		fields[goPath] = fieldKey{path: path, typ: field.Type}
+	})
+	return fields
+}
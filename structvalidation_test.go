@@ -0,0 +1,176 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// fakeFieldError mimics validator.FieldError from go-playground/validator.
+type fakeFieldError struct {
+	namespace string
+	tag       string
+	param     string
+	value     any
+}
+
+func (e fakeFieldError) StructNamespace() string { return e.namespace }
+func (e fakeFieldError) Tag() string             { return e.tag }
+func (e fakeFieldError) Param() string           { return e.param }
+func (e fakeFieldError) Value() any              { return e.value }
+func (e fakeFieldError) Error() string {
+	return fmt.Sprintf("Key: '%s' Error:Field validation failed on the '%s' tag", e.namespace, e.tag)
+}
+
+// fakeValidationErrors mimics validator.ValidationErrors, a slice of field errors.
+type fakeValidationErrors []fakeFieldError
+
+func (e fakeValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, fe := range e {
+		msgs[i] = fe.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+type validatedServer struct {
+	Port int `conflex:"listen_port"`
+}
+
+type validatedConfig struct {
+	Name    string            `conflex:"name"`
+	Server  validatedServer   `conflex:"server"`
+	Servers []validatedServer `conflex:"servers"`
+}
+
+// portValidator reports the rules broken by a *validatedConfig the way go-playground's validator does.
+type portValidator struct {
+	err error
+}
+
+func (v portValidator) Struct(s any) error {
+	if v.err != nil {
+		return v.err
+	}
+	cfg := s.(*validatedConfig)
+	var errs fakeValidationErrors
+	if cfg.Name == "" {
+		errs = append(errs, fakeFieldError{namespace: "validatedConfig.Name", tag: "required"})
+	}
+	if cfg.Server.Port < 1 {
+		errs = append(errs, fakeFieldError{namespace: "validatedConfig.Server.Port", tag: "min", param: "1", value: cfg.Server.Port})
+	}
+	for i, server := range cfg.Servers {
+		if server.Port < 1 {
+			errs = append(errs, fakeFieldError{namespace: fmt.Sprintf("validatedConfig.Servers[%d].Port", i), tag: "min", param: "1", value: server.Port})
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+type StructValidationTestSuite struct {
+	suite.Suite
+}
+
+func TestStructValidationTestSuite(t *testing.T) {
+	suite.Run(t, new(StructValidationTestSuite))
+}
+
+func (s *StructValidationTestSuite) TestValid() {
+	var cfg validatedConfig
+	c, err := New(
+		WithSource(&mockSource{conf: map[string]any{"name": "app", "server": map[string]any{"listen_port": 8080}}}),
+		WithBinding(&cfg),
+		WithStructValidation(portValidator{}),
+	)
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+	s.Equal(8080, cfg.Server.Port)
+}
+
+func (s *StructValidationTestSuite) TestFieldAddressedErrors() {
+	cfg := validatedConfig{Name: "previous"}
+	c, err := New(
+		WithSource(&mockSource{conf: map[string]any{
+			"server":  map[string]any{"listen_port": 0},
+			"servers": []any{map[string]any{"listen_port": 80}, map[string]any{"listen_port": 0}},
+		}}),
+		WithBinding(&cfg),
+		WithStructValidation(portValidator{}),
+	)
+	s.Require().NoError(err)
+
+	err = c.Load(context.Background())
+	s.Require().Error(err)
+	s.Equal("previous", cfg.Name, "the binding must not be modified")
+
+	var fields []string
+	for _, e := range err.(*ConfigError).Err.(interface{ Unwrap() []error }).Unwrap() {
+		var fieldErr *ConfigError
+		s.Require().True(errors.As(e, &fieldErr))
+		fields = append(fields, fieldErr.Field)
+	}
+	s.Equal([]string{"name", "server.listen_port", "servers.1.listen_port"}, fields)
+	s.Contains(err.Error(), `config error in binding.server.listen_port during validate: failed the "min=1" rule`)
+
+	details := ValidationErrors(err)
+	s.Require().Len(details, 3)
+	s.Equal("/servers/1/listen_port", details[2].Path)
+	s.Equal("source[0]", details[2].Source)
+	s.Equal("min=1", details[2].Expected)
+	s.Equal(0, details[2].Actual)
+}
+
+func (s *StructValidationTestSuite) TestOtherErrors() {
+	validatorErr := errors.New("validator misconfigured")
+	c, err := New(
+		WithSource(&mockSource{conf: map[string]any{}}),
+		WithBinding(&validatedConfig{}),
+		WithStructValidation(portValidator{err: validatorErr}),
+	)
+	s.Require().NoError(err)
+	s.ErrorIs(c.Load(context.Background()), validatorErr)
+}
+
+func (s *StructValidationTestSuite) TestNilValidator() {
+	_, err := NewStrict(WithStructValidation(nil))
+	s.Error(err)
+}
+
+func (s *StructValidationTestSuite) TestValidationKey() {
+	type config struct {
+		Server  validatedServer            `conflex:"server"`
+		Servers []*validatedServer         `conflex:"servers"`
+		Labels  map[string]string          `conflex:"labels"`
+		Zones   map[string]validatedServer `conflex:"zones"`
+	}
+	t := reflect.TypeOf(&config{})
+
+	s.Equal("server.listen_port", validationKey(t, "config.Server.Port"))
+	s.Equal("servers.2.listen_port", validationKey(t, "config.Servers[2].Port"))
+	s.Equal("labels.env", validationKey(t, "config.Labels[env]"))
+	s.Equal("zones.eu.listen_port", validationKey(t, "config.Zones[eu].Port"))
+	s.Equal("other.field", validationKey(t, "config.Other.Field"))
+}
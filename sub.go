@@ -0,0 +1,348 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"fmt"
+	"time"
+)
+
+// View is a read-only view of the configuration below a key prefix, created with Conflex.Sub.
+// Keys passed to its getters are relative to the prefix, so a library can take a *View and read
+// "host" without knowing that the application keeps its settings under "database".
+//
+// A View holds no values of its own: every call reads from the parent's current configuration,
+// so it reflects reloads the same way the parent does.
+type View struct {
+	c      *Conflex
+	prefix string
+}
+
+// Sub returns a view of the configuration below key. The key does not have to exist yet;
+// lookups through the view simply find nothing until it does.
+func (c *Conflex) Sub(key string) *View {
+	return &View{c: c, prefix: key}
+}
+
+// Sub returns a view of the configuration below key, relative to this view's prefix.
+func (v *View) Sub(key string) *View {
+	return &View{c: v.c, prefix: v.key(key)}
+}
+
+// Prefix returns the key this view is scoped to, relative to the root of the configuration.
+func (v *View) Prefix() string {
+	return v.prefix
+}
+
+// key returns the full key for a key relative to the view. The empty key refers to the prefix itself.
+func (v *View) key(key string) string {
+	switch {
+	case key == "":
+		return v.prefix
+	case v.prefix == "":
+		return key
+	default:
+		return v.prefix + "." + key
+	}
+}
+
+// Values returns a copy of the configuration below the view's prefix.
+// It returns an empty map if the prefix does not hold a map.
+func (v *View) Values() map[string]any {
+	if v.prefix == "" {
+		return v.c.AllSettings()
+	}
+	values, _ := v.c.Get(v.prefix).(map[string]any)
+	if values == nil {
+		return map[string]any{}
+	}
+	return copyMap(values)
+}
+
+// IsSet reports whether the view has a value for key.
+func (v *View) IsSet(key string) bool {
+	return v.Get(key) != nil
+}
+
+// Bind decodes the configuration below the view's prefix into target, which must be a pointer.
+// It uses the same decoder settings as WithBinding. If target implements Validator, it is validated
+// after decoding. A prefix that does not exist decodes as an empty map, leaving target unchanged.
+func (v *View) Bind(target any) error {
+	if v.c == nil {
+		return fmt.Errorf("conflex instance is nil")
+	}
+
+	values := v.Values()
+	if err := v.c.decode(values, target); err != nil {
+		return NewConfigFieldError("sub", v.prefix, "bind", err)
+	}
+	if val, ok := target.(Validator); ok {
+		if err := val.Validate(); err != nil {
+			return NewConfigFieldError("sub", v.prefix, "validate", err)
+		}
+	}
+	return nil
+}
+
+// Get returns the value associated with the given key, relative to the view's prefix.
+// The empty key returns the value at the prefix itself.
+func (v *View) Get(key string) any {
+	return v.c.Get(v.key(key))
+}
+
+// GetMany returns the values associated with the given keys, keyed by the keys as passed.
+// See Conflex.GetMany.
+func (v *View) GetMany(keys ...string) map[string]any {
+	values, _ := v.GetManyE(keys...)
+	return values
+}
+
+// GetManyE returns the values associated with the given keys, keyed by the keys as passed,
+// and an error naming the full keys that were not found. See Conflex.GetManyE.
+func (v *View) GetManyE(keys ...string) (map[string]any, error) {
+	full := make([]string, len(keys))
+	for i, key := range keys {
+		full[i] = v.key(key)
+	}
+
+	values, err := v.c.GetManyE(full...)
+	result := make(map[string]any, len(values))
+	for i, key := range keys {
+		if value, ok := values[full[i]]; ok {
+			result[key] = value
+		}
+	}
+	return result, err
+}
+
+// GetString is like Conflex.GetString, with key relative to the view's prefix.
+func (v *View) GetString(key string) string {
+	return v.c.GetString(v.key(key))
+}
+
+// GetStringE is like Conflex.GetStringE, with key relative to the view's prefix.
+func (v *View) GetStringE(key string) (string, error) {
+	return v.c.GetStringE(v.key(key))
+}
+
+// GetBool is like Conflex.GetBool, with key relative to the view's prefix.
+func (v *View) GetBool(key string) bool {
+	return v.c.GetBool(v.key(key))
+}
+
+// GetBoolE is like Conflex.GetBoolE, with key relative to the view's prefix.
+func (v *View) GetBoolE(key string) (bool, error) {
+	return v.c.GetBoolE(v.key(key))
+}
+
+// GetInt is like Conflex.GetInt, with key relative to the view's prefix.
+func (v *View) GetInt(key string) int {
+	return v.c.GetInt(v.key(key))
+}
+
+// GetIntE is like Conflex.GetIntE, with key relative to the view's prefix.
+func (v *View) GetIntE(key string) (int, error) {
+	return v.c.GetIntE(v.key(key))
+}
+
+// GetInt32 is like Conflex.GetInt32, with key relative to the view's prefix.
+func (v *View) GetInt32(key string) int32 {
+	return v.c.GetInt32(v.key(key))
+}
+
+// GetInt32E is like Conflex.GetInt32E, with key relative to the view's prefix.
+func (v *View) GetInt32E(key string) (int32, error) {
+	return v.c.GetInt32E(v.key(key))
+}
+
+// GetInt64 is like Conflex.GetInt64, with key relative to the view's prefix.
+func (v *View) GetInt64(key string) int64 {
+	return v.c.GetInt64(v.key(key))
+}
+
+// GetInt64E is like Conflex.GetInt64E, with key relative to the view's prefix.
+func (v *View) GetInt64E(key string) (int64, error) {
+	return v.c.GetInt64E(v.key(key))
+}
+
+// GetUint8 is like Conflex.GetUint8, with key relative to the view's prefix.
+func (v *View) GetUint8(key string) uint8 {
+	return v.c.GetUint8(v.key(key))
+}
+
+// GetUint8E is like Conflex.GetUint8E, with key relative to the view's prefix.
+func (v *View) GetUint8E(key string) (uint8, error) {
+	return v.c.GetUint8E(v.key(key))
+}
+
+// GetUint is like Conflex.GetUint, with key relative to the view's prefix.
+func (v *View) GetUint(key string) uint {
+	return v.c.GetUint(v.key(key))
+}
+
+// GetUintE is like Conflex.GetUintE, with key relative to the view's prefix.
+func (v *View) GetUintE(key string) (uint, error) {
+	return v.c.GetUintE(v.key(key))
+}
+
+// GetUint16 is like Conflex.GetUint16, with key relative to the view's prefix.
+func (v *View) GetUint16(key string) uint16 {
+	return v.c.GetUint16(v.key(key))
+}
+
+// GetUint16E is like Conflex.GetUint16E, with key relative to the view's prefix.
+func (v *View) GetUint16E(key string) (uint16, error) {
+	return v.c.GetUint16E(v.key(key))
+}
+
+// GetUint32 is like Conflex.GetUint32, with key relative to the view's prefix.
+func (v *View) GetUint32(key string) uint32 {
+	return v.c.GetUint32(v.key(key))
+}
+
+// GetUint32E is like Conflex.GetUint32E, with key relative to the view's prefix.
+func (v *View) GetUint32E(key string) (uint32, error) {
+	return v.c.GetUint32E(v.key(key))
+}
+
+// GetUint64 is like Conflex.GetUint64, with key relative to the view's prefix.
+func (v *View) GetUint64(key string) uint64 {
+	return v.c.GetUint64(v.key(key))
+}
+
+// GetUint64E is like Conflex.GetUint64E, with key relative to the view's prefix.
+func (v *View) GetUint64E(key string) (uint64, error) {
+	return v.c.GetUint64E(v.key(key))
+}
+
+// GetFloat64 is like Conflex.GetFloat64, with key relative to the view's prefix.
+func (v *View) GetFloat64(key string) float64 {
+	return v.c.GetFloat64(v.key(key))
+}
+
+// GetFloat64E is like Conflex.GetFloat64E, with key relative to the view's prefix.
+func (v *View) GetFloat64E(key string) (float64, error) {
+	return v.c.GetFloat64E(v.key(key))
+}
+
+// GetTime is like Conflex.GetTime, with key relative to the view's prefix.
+func (v *View) GetTime(key string) time.Time {
+	return v.c.GetTime(v.key(key))
+}
+
+// GetTimeE is like Conflex.GetTimeE, with key relative to the view's prefix.
+func (v *View) GetTimeE(key string) (time.Time, error) {
+	return v.c.GetTimeE(v.key(key))
+}
+
+// GetDuration is like Conflex.GetDuration, with key relative to the view's prefix.
+func (v *View) GetDuration(key string) time.Duration {
+	return v.c.GetDuration(v.key(key))
+}
+
+// GetDurationE is like Conflex.GetDurationE, with key relative to the view's prefix.
+func (v *View) GetDurationE(key string) (time.Duration, error) {
+	return v.c.GetDurationE(v.key(key))
+}
+
+// GetSizeInBytes is like Conflex.GetSizeInBytes, with key relative to the view's prefix.
+func (v *View) GetSizeInBytes(key string) int64 {
+	return v.c.GetSizeInBytes(v.key(key))
+}
+
+// GetSizeInBytesE is like Conflex.GetSizeInBytesE, with key relative to the view's prefix.
+func (v *View) GetSizeInBytesE(key string) (int64, error) {
+	return v.c.GetSizeInBytesE(v.key(key))
+}
+
+// GetIntSlice is like Conflex.GetIntSlice, with key relative to the view's prefix.
+func (v *View) GetIntSlice(key string) []int {
+	return v.c.GetIntSlice(v.key(key))
+}
+
+// GetIntSliceE is like Conflex.GetIntSliceE, with key relative to the view's prefix.
+func (v *View) GetIntSliceE(key string) ([]int, error) {
+	return v.c.GetIntSliceE(v.key(key))
+}
+
+// GetStringSlice is like Conflex.GetStringSlice, with key relative to the view's prefix.
+func (v *View) GetStringSlice(key string) []string {
+	return v.c.GetStringSlice(v.key(key))
+}
+
+// GetStringSliceE is like Conflex.GetStringSliceE, with key relative to the view's prefix.
+func (v *View) GetStringSliceE(key string) ([]string, error) {
+	return v.c.GetStringSliceE(v.key(key))
+}
+
+// GetStringMap is like Conflex.GetStringMap, with key relative to the view's prefix.
+func (v *View) GetStringMap(key string) map[string]any {
+	return v.c.GetStringMap(v.key(key))
+}
+
+// GetStringMapE is like Conflex.GetStringMapE, with key relative to the view's prefix.
+func (v *View) GetStringMapE(key string) (map[string]any, error) {
+	return v.c.GetStringMapE(v.key(key))
+}
+
+// GetStringMapString is like Conflex.GetStringMapString, with key relative to the view's prefix.
+func (v *View) GetStringMapString(key string) map[string]string {
+	return v.c.GetStringMapString(v.key(key))
+}
+
+// GetStringMapStringE is like Conflex.GetStringMapStringE, with key relative to the view's prefix.
+func (v *View) GetStringMapStringE(key string) (map[string]string, error) {
+	return v.c.GetStringMapStringE(v.key(key))
+}
+
+// GetStringMapStringSlice is like Conflex.GetStringMapStringSlice, with key relative to the view's prefix.
+func (v *View) GetStringMapStringSlice(key string) map[string][]string {
+	return v.c.GetStringMapStringSlice(v.key(key))
+}
+
+// GetStringMapStringSliceE is like Conflex.GetStringMapStringSliceE, with key relative to the view's prefix.
+func (v *View) GetStringMapStringSliceE(key string) (map[string][]string, error) {
+	return v.c.GetStringMapStringSliceE(v.key(key))
+}
+
+// GetStringMapInt is like Conflex.GetStringMapInt, with key relative to the view's prefix.
+func (v *View) GetStringMapInt(key string) map[string]int {
+	return v.c.GetStringMapInt(v.key(key))
+}
+
+// GetStringMapIntE is like Conflex.GetStringMapIntE, with key relative to the view's prefix.
+func (v *View) GetStringMapIntE(key string) (map[string]int, error) {
+	return v.c.GetStringMapIntE(v.key(key))
+}
+
+// GetStringMapBool is like Conflex.GetStringMapBool, with key relative to the view's prefix.
+func (v *View) GetStringMapBool(key string) map[string]bool {
+	return v.c.GetStringMapBool(v.key(key))
+}
+
+// GetStringMapBoolE is like Conflex.GetStringMapBoolE, with key relative to the view's prefix.
+func (v *View) GetStringMapBoolE(key string) (map[string]bool, error) {
+	return v.c.GetStringMapBoolE(v.key(key))
+}
+
+// GetStringMapDuration is like Conflex.GetStringMapDuration, with key relative to the view's prefix.
+func (v *View) GetStringMapDuration(key string) map[string]time.Duration {
+	return v.c.GetStringMapDuration(v.key(key))
+}
+
+// GetStringMapDurationE is like Conflex.GetStringMapDurationE, with key relative to the view's prefix.
+func (v *View) GetStringMapDurationE(key string) (map[string]time.Duration, error) {
+	return v.c.GetStringMapDurationE(v.key(key))
+}
@@ -0,0 +1,143 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type subDatabaseConfig struct {
+	Host    string        `conflex:"host"`
+	Port    int           `conflex:"port"`
+	Timeout time.Duration `conflex:"timeout"`
+}
+
+func (d *subDatabaseConfig) Validate() error {
+	if d.Host == "" {
+		return errors.New("host is required")
+	}
+	return nil
+}
+
+type SubTestSuite struct {
+	suite.Suite
+}
+
+func TestSubTestSuite(t *testing.T) {
+	suite.Run(t, new(SubTestSuite))
+}
+
+func (s *SubTestSuite) load(conf map[string]any) (*Conflex, *mockSyncSource) {
+	src := &mockSyncSource{conf: conf}
+	c, err := New(WithSource(src))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+	return c, src
+}
+
+func (s *SubTestSuite) TestGettersAreRelative() {
+	c, _ := s.load(map[string]any{
+		"database": map[string]any{
+			"host":    "db.internal",
+			"port":    5432,
+			"timeout": "5s",
+			"replica": map[string]any{"host": "replica.internal"},
+		},
+		"host": "root",
+	})
+
+	db := c.Sub("database")
+	s.Equal("database", db.Prefix())
+	s.Equal("db.internal", db.GetString("host"))
+	s.Equal(5432, db.GetInt("port"))
+	s.Equal(5*time.Second, db.GetDuration("timeout"))
+	s.True(db.IsSet("port"))
+	s.False(db.IsSet("missing"))
+
+	replica := db.Sub("replica")
+	s.Equal("database.replica", replica.Prefix())
+	s.Equal("replica.internal", replica.GetString("host"))
+
+	_, err := db.GetStringE("missing")
+	s.ErrorContains(err, `"database.missing"`)
+
+	s.Equal(map[string]any{"host": "db.internal", "port": 5432}, db.GetMany("host", "port"))
+	values, err := db.GetManyE("host", "missing")
+	s.Equal(map[string]any{"host": "db.internal"}, values)
+	s.ErrorContains(err, `"database.missing"`)
+}
+
+func (s *SubTestSuite) TestEmptyKeyReturnsSubtree() {
+	c, _ := s.load(map[string]any{"database": map[string]any{"host": "db.internal"}})
+	s.Equal(map[string]any{"host": "db.internal"}, c.Sub("database").Get(""))
+	s.Equal("db.internal", c.Sub("").GetString("database.host"))
+	s.Equal(c.AllSettings(), c.Sub("").Values())
+}
+
+func (s *SubTestSuite) TestValuesIsCopy() {
+	c, _ := s.load(map[string]any{"database": map[string]any{"host": "db.internal"}})
+
+	values := c.Sub("database").Values()
+	values["host"] = "changed"
+	s.Equal("db.internal", c.GetString("database.host"))
+
+	s.Empty(c.Sub("missing").Values())
+	s.Empty(c.Sub("database.host").Values())
+}
+
+func (s *SubTestSuite) TestBind() {
+	c, _ := s.load(map[string]any{
+		"database": map[string]any{"host": "db.internal", "port": "5432", "timeout": "2s"},
+	})
+
+	var cfg subDatabaseConfig
+	s.Require().NoError(c.Sub("database").Bind(&cfg))
+	s.Equal(subDatabaseConfig{Host: "db.internal", Port: 5432, Timeout: 2 * time.Second}, cfg)
+}
+
+func (s *SubTestSuite) TestBindValidates() {
+	c, _ := s.load(map[string]any{"database": map[string]any{"port": 5432}})
+
+	var cfg subDatabaseConfig
+	err := c.Sub("database").Bind(&cfg)
+	s.ErrorContains(err, "host is required")
+
+	var configErr *ConfigError
+	s.Require().ErrorAs(err, &configErr)
+	s.Equal("database", configErr.Field)
+}
+
+func (s *SubTestSuite) TestFollowsReload() {
+	c, src := s.load(map[string]any{"database": map[string]any{"host": "old"}})
+	db := c.Sub("database")
+	s.Equal("old", db.GetString("host"))
+
+	src.set(map[string]any{"database": map[string]any{"host": "new"}}, nil)
+	s.Require().NoError(c.Load(context.Background()))
+	s.Equal("new", db.GetString("host"))
+}
+
+func (s *SubTestSuite) TestNilConflex() {
+	var c *Conflex
+	db := c.Sub("database")
+	s.Nil(db.Get("host"))
+	s.Empty(db.GetString("host"))
+	s.Error(db.Bind(&subDatabaseConfig{}))
+}
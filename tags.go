@@ -0,0 +1,167 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/go-viper/mapstructure/v2"
+)
+
+// tagName is the struct tag used to map configuration keys to struct fields.
+const tagName = "conflex"
+
+// fieldTag holds the parsed contents of a conflex struct tag, e.g. `conflex:"password,sensitivity=secret"`.
+type fieldTag struct {
+	name    string
+	options map[string]string
+}
+
+// parseFieldTag parses the conflex tag of a struct field.
+// Options without a value, such as "squash", are stored with an empty value.
+func parseFieldTag(field reflect.StructField) fieldTag {
+	parts := strings.Split(field.Tag.Get(tagName), ",")
+	tag := fieldTag{name: parts[0], options: make(map[string]string, len(parts)-1)}
+	for _, part := range parts[1:] {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		key, value, _ := strings.Cut(part, "=")
+		tag.options[key] = value
+	}
+	return tag
+}
+
+// has reports whether the tag contains the given option.
+func (t fieldTag) has(option string) bool {
+	_, ok := t.options[option]
+	return ok
+}
+
+// walkFields calls fn for every exported field of the struct type t that is bound from configuration,
+// passing the dot-separated key path of the field. Nested structs are visited recursively, and embedded
+// or squashed structs contribute their fields to the enclosing path, mirroring the binding rules.
+func walkFields(t reflect.Type, prefix string, fn func(path string, field reflect.StructField, tag fieldTag)) {
+	walkFieldsVisited(t, "", prefix, func(_, path string, field reflect.StructField, tag fieldTag) {
+		fn(path, field, tag)
+	}, map[reflect.Type]bool{})
+}
+
+// walkFieldPaths is like walkFields, but also passes the path of Go field names leading to the field,
+// e.g. "Server.Port" for the key "server.port". Embedded and squashed fields appear in the Go path only.
+func walkFieldPaths(t reflect.Type, fn func(goPath, path string, field reflect.StructField, tag fieldTag)) {
+	walkFieldsVisited(t, "", "", fn, map[reflect.Type]bool{})
+}
+
+func walkFieldsVisited(t reflect.Type, goPrefix, prefix string, fn func(goPath, path string, field reflect.StructField, tag fieldTag), visiting map[reflect.Type]bool) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || visiting[t] {
+		return
+	}
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+
+		// Embedded structs of unexported types still contribute their exported fields.
+		embedded := field.Anonymous && fieldType.Kind() == reflect.Struct
+		if !field.IsExported() && !embedded {
+			continue
+		}
+
+		tag := parseFieldTag(field)
+		if tag.name == omittedKey || tag.has("remain") {
+			continue
+		}
+
+		goPath := field.Name
+		if goPrefix != "" {
+			goPath = goPrefix + "." + field.Name
+		}
+
+		if tag.has("squash") || embedded {
+			walkFieldsVisited(fieldType, goPath, prefix, fn, visiting)
+			continue
+		}
+
+		// Untagged fields are matched case-insensitively by the decoder, so they get a lowercase key.
+		// Tag names are kept as written, so they still match when keys are case-sensitive.
+		path := tag.name
+		if path == "" {
+			path = strings.ToLower(field.Name)
+		}
+		if prefix != "" {
+			path = prefix + "." + path
+		}
+
+		fn(goPath, path, field, tag)
+
+		if fieldType.Kind() == reflect.Struct && fieldType.PkgPath() != "time" {
+			walkFieldsVisited(fieldType, goPath, path, fn, visiting)
+		}
+	}
+}
+
+// omittedKey is the tag name that excludes a field from binding, as in `conflex:"-"`.
+const omittedKey = "-"
+
+// hasOmittedField reports whether the struct type t has a field tagged `conflex:"-"`.
+func hasOmittedField(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		if parseFieldTag(t.Field(i)).name == omittedKey {
+			return true
+		}
+	}
+	return false
+}
+
+// omittedFieldsHookFunc returns a decode hook that keeps fields tagged `conflex:"-"` out of binding.
+// The decoder only skips such fields when encoding, and otherwise takes "-" for the name of the key
+// to decode them from, so a "-" key is removed from the input of structs with such a field.
+func omittedFieldsHookFunc() mapstructure.DecodeHookFuncType {
+	return func(_ reflect.Type, to reflect.Type, data any) (any, error) {
+		if to.Kind() != reflect.Struct {
+			return data, nil
+		}
+		// Bindings are decoded from a pointer to the values.
+		m, ok := data.(map[string]any)
+		if p, isPtr := data.(*map[string]any); isPtr && p != nil {
+			m, ok = *p, true
+		}
+		if !ok {
+			return data, nil
+		}
+		if _, ok := m[omittedKey]; !ok || !hasOmittedField(to) {
+			return data, nil
+		}
+
+		out := make(map[string]any, len(m)-1)
+		for k, v := range m {
+			if k != omittedKey {
+				out[k] = v
+			}
+		}
+		return out, nil
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TagsTestSuite struct {
+	suite.Suite
+}
+
+func TestTagsTestSuite(t *testing.T) {
+	suite.Run(t, new(TagsTestSuite))
+}
+
+type tagsInner struct {
+	Value string `conflex:"value"`
+}
+
+type tagsEmbedded struct {
+	Shared string `conflex:"shared"`
+}
+
+type tagsRecursive struct {
+	Name  string         `conflex:"name"`
+	Child *tagsRecursive `conflex:"child"`
+}
+
+type tagsStruct struct {
+	tagsEmbedded
+	Name      string `conflex:"name,sensitivity=secret"`
+	NoTag     int
+	Inner     tagsInner      `conflex:"inner"`
+	InnerPtr  *tagsInner     `conflex:"innerptr"`
+	Squashed  tagsInner      `conflex:",squash"`
+	Skipped   string         `conflex:"-"`
+	Remaining map[string]any `conflex:",remain"`
+	Started   time.Time      `conflex:"started"`
+	Recursive tagsRecursive  `conflex:"recursive"`
+	hidden    string
+	Labels    map[string]string `conflex:"labels"`
+}
+
+func (s *TagsTestSuite) TestWalkFields() {
+	var paths []string
+	walkFields(reflect.TypeOf(&tagsStruct{}), "root", func(path string, _ reflect.StructField, _ fieldTag) {
+		paths = append(paths, path)
+	})
+
+	s.Equal([]string{
+		"root.shared",
+		"root.name",
+		"root.notag",
+		"root.inner",
+		"root.inner.value",
+		"root.innerptr",
+		"root.innerptr.value",
+		"root.value",
+		"root.started",
+		"root.recursive",
+		"root.recursive.name",
+		"root.recursive.child",
+		"root.labels",
+	}, paths)
+}
+
+func (s *TagsTestSuite) TestParseFieldTag() {
+	field, ok := reflect.TypeOf(tagsStruct{}).FieldByName("Name")
+	s.Require().True(ok)
+
+	tag := parseFieldTag(field)
+	s.Equal("name", tag.name)
+	s.True(tag.has("sensitivity"))
+	s.Equal("secret", tag.options["sensitivity"])
+	s.False(tag.has("required"))
+}
+
+func (s *TagsTestSuite) TestBindRemainAndOmittedFields() {
+	type Plugin struct {
+		Name    string         `conflex:"name"`
+		Options map[string]any `conflex:",remain"`
+	}
+	type Config struct {
+		Name    string         `conflex:"name"`
+		Token   string         `conflex:"-"`
+		Plugins []Plugin       `conflex:"plugins"`
+		Extra   map[string]any `conflex:",remain"`
+	}
+
+	src := &mockSource{conf: map[string]any{
+		"name":  "api",
+		"token": "from-config",
+		"-":     "dash",
+		"debug": true,
+		"plugins": []any{
+			map[string]any{"name": "cache", "ttl": "5m", "size": 100},
+		},
+	}}
+	cfg := Config{Token: "from-code"}
+	c, err := New(WithSource(src), WithBinding(&cfg))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	s.Equal("api", cfg.Name)
+	s.Equal("from-code", cfg.Token)
+	s.Equal(map[string]any{"token": "from-config", "debug": true}, cfg.Extra)
+	s.Equal([]Plugin{{Name: "cache", Options: map[string]any{"ttl": "5m", "size": 100}}}, cfg.Plugins)
+
+	var view Config
+	s.Require().NoError(c.Sub("").Bind(&view))
+	s.Empty(view.Token)
+	s.Equal(cfg.Extra, view.Extra)
+}
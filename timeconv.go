@@ -0,0 +1,108 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/spf13/cast"
+)
+
+// unixMillisThreshold is the magnitude from which integer timestamps are interpreted as Unix milliseconds
+// rather than seconds. As seconds it corresponds to the year 5138; as milliseconds to March 1973.
+const unixMillisThreshold = 100_000_000_000
+
+// toTimeE converts v to a time.Time. Besides the formats supported by cast.ToTimeE,
+// it accepts Unix timestamps in seconds or milliseconds, as numbers or strings of digits.
+func toTimeE(v any) (time.Time, error) {
+	if t, ok := unixTime(v); ok {
+		return t, nil
+	}
+	return cast.ToTimeE(v)
+}
+
+// unixTime interprets v as a Unix timestamp. Values whose magnitude is at least unixMillisThreshold
+// are taken to be milliseconds, smaller values seconds. The result is in UTC.
+func unixTime(v any) (time.Time, bool) {
+	switch n := v.(type) {
+	case json.Number:
+		if i, err := n.Int64(); err == nil {
+			return unixTimeFromInt(i), true
+		}
+		if f, err := n.Float64(); err == nil {
+			return unixTimeFromFloat(f), true
+		}
+		return time.Time{}, false
+	case string:
+		i, err := strconv.ParseInt(n, 10, 64)
+		if err != nil {
+			return time.Time{}, false
+		}
+		return unixTimeFromInt(i), true
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return unixTimeFromInt(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if rv.Uint() > math.MaxInt64 {
+			return time.Time{}, false
+		}
+		return unixTimeFromInt(int64(rv.Uint())), true
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		if math.IsNaN(f) || math.Abs(f) >= math.MaxInt64 {
+			return time.Time{}, false
+		}
+		return unixTimeFromFloat(f), true
+	default:
+		return time.Time{}, false
+	}
+}
+
+func unixTimeFromInt(i int64) time.Time {
+	if i >= unixMillisThreshold || i <= -unixMillisThreshold {
+		return time.UnixMilli(i).UTC()
+	}
+	return time.Unix(i, 0).UTC()
+}
+
+func unixTimeFromFloat(f float64) time.Time {
+	whole, frac := math.Modf(f)
+	if math.Abs(f) >= unixMillisThreshold {
+		return time.UnixMilli(int64(whole)).Add(time.Duration(math.Round(frac * 1e6))).UTC()
+	}
+	return time.Unix(int64(whole), int64(math.Round(frac*1e9))).UTC()
+}
+
+// unixTimeHookFunc returns a decode hook that converts Unix timestamps in seconds or milliseconds to time.Time.
+func unixTimeHookFunc() mapstructure.DecodeHookFuncType {
+	timeType := reflect.TypeOf(time.Time{})
+	return func(_ reflect.Type, to reflect.Type, data any) (any, error) {
+		if to != timeType {
+			return data, nil
+		}
+		if t, ok := unixTime(data); ok {
+			return t, nil
+		}
+		return data, nil
+	}
+}
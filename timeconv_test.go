@@ -0,0 +1,106 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TimeConvTestSuite struct {
+	suite.Suite
+}
+
+func TestTimeConvTestSuite(t *testing.T) {
+	suite.Run(t, new(TimeConvTestSuite))
+}
+
+func (s *TimeConvTestSuite) TestToTimeE() {
+	seconds := time.Date(2023, 11, 14, 22, 13, 20, 0, time.UTC)
+	millis := time.Date(2023, 11, 14, 22, 13, 20, 123000000, time.UTC)
+
+	tests := []struct {
+		name     string
+		input    any
+		expected time.Time
+	}{
+		{"int seconds", 1700000000, seconds},
+		{"int64 millis", int64(1700000000123), millis},
+		{"uint seconds", uint32(1700000000), seconds},
+		{"float seconds", float64(1700000000), seconds},
+		{"float millis", float64(1700000000123), millis},
+		{"fractional seconds", 1700000000.5, seconds.Add(500 * time.Millisecond)},
+		{"string seconds", "1700000000", seconds},
+		{"string millis", "1700000000123", millis},
+		{"json number", json.Number("1700000000"), seconds},
+		{"negative seconds", -86400, time.Date(1969, 12, 31, 0, 0, 0, 0, time.UTC)},
+		{"rfc3339", "2023-11-14T22:13:20Z", seconds},
+		{"time", seconds, seconds},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			t, err := toTimeE(tt.input)
+			s.Require().NoError(err)
+			s.True(tt.expected.Equal(t), "expected %v, got %v", tt.expected, t)
+		})
+	}
+
+	_, err := toTimeE("not a time")
+	s.Error(err)
+	_, err = toTimeE(true)
+	s.Error(err)
+}
+
+func (s *TimeConvTestSuite) TestGetTime_Unix() {
+	c, err := New(WithSource(&mockSource{conf: map[string]any{
+		"seconds": 1700000000,
+		"millis":  "1700000000123",
+	}}))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	s.Equal(time.Date(2023, 11, 14, 22, 13, 20, 0, time.UTC), c.GetTime("seconds"))
+	t, err := c.GetTimeE("millis")
+	s.NoError(err)
+	s.Equal(time.Date(2023, 11, 14, 22, 13, 20, 123000000, time.UTC), t)
+}
+
+func (s *TimeConvTestSuite) TestBinding_Unix() {
+	var bind struct {
+		Seconds time.Time `conflex:"seconds"`
+		Millis  time.Time `conflex:"millis"`
+		RFC3339 time.Time `conflex:"rfc3339"`
+	}
+	c, err := New(
+		WithSource(&mockSource{conf: map[string]any{
+			"seconds": float64(1700000000),
+			"millis":  "1700000000123",
+			"rfc3339": "2023-11-14T22:13:20Z",
+		}}),
+		WithBinding(&bind),
+	)
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	expected := time.Date(2023, 11, 14, 22, 13, 20, 0, time.UTC)
+	s.Equal(expected, bind.Seconds)
+	s.Equal(expected.Add(123*time.Millisecond), bind.Millis)
+	s.True(expected.Equal(bind.RFC3339))
+}
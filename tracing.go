@@ -0,0 +1,98 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName is the instrumentation name of the tracer that creates the spans of Load, Reload, Dump and Watch.
+const TracerName = "go.companyinfo.dev/conflex"
+
+// Attribute keys set on the spans created for sources and dumpers.
+const (
+	AttributeSourceName     = attribute.Key("conflex.source.name")
+	AttributeSourceType     = attribute.Key("conflex.source.type")
+	AttributeSourceTarget   = attribute.Key("conflex.source.target")
+	AttributeSourceOptional = attribute.Key("conflex.source.optional")
+	AttributeSourceBytes    = attribute.Key("conflex.source.bytes")
+	AttributeDumperName     = attribute.Key("conflex.dumper.name")
+)
+
+// sizedSource is implemented by sources that can report the size of the data they read, such as source.File
+// and source.Consul.
+type sizedSource interface {
+	// Size returns the size in bytes of the data read by the last Load.
+	Size() int
+}
+
+// WithTracerProvider sets the OpenTelemetry tracer provider used to trace Load, Reload, Dump and Watch.
+// Each of them creates a span, with a child span for every source loaded and every dumper called, so slow
+// remote sources show up in traces. Source spans carry the name, type and target of the source and, for
+// sources that report it, the size in bytes of the data read.
+// Without this option the global tracer provider is used, which does nothing unless one is installed with
+// otel.SetTracerProvider.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(c *Conflex) error {
+		if provider == nil {
+			return errors.New("tracer provider cannot be nil")
+		}
+		c.tracerProvider = provider
+		return nil
+	}
+}
+
+// tracer returns the tracer that creates the spans of c.
+func (c *Conflex) tracer() trace.Tracer {
+	provider := c.tracerProvider
+	if provider == nil {
+		provider = otel.GetTracerProvider()
+	}
+	return provider.Tracer(TracerName)
+}
+
+// startSpan starts a span named name as a child of the span in ctx, if any.
+func (c *Conflex) startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return c.tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// startSourceSpan starts the span of loading source i.
+func (c *Conflex) startSourceSpan(ctx context.Context, i int) (context.Context, trace.Span) {
+	info := c.sourceInfos[i]
+	attrs := []attribute.KeyValue{
+		AttributeSourceName.String(c.sourceName(i)),
+		AttributeSourceType.String(info.Type),
+		AttributeSourceOptional.Bool(info.Optional),
+	}
+	if info.Target != "" {
+		attrs = append(attrs, AttributeSourceTarget.String(info.Target))
+	}
+	return c.startSpan(ctx, "conflex.source.load", attrs...)
+}
+
+// endSpan records err, if any, on span and ends it.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
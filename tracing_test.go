@@ -0,0 +1,208 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.companyinfo.dev/conflex/codec"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type TracingTestSuite struct {
+	suite.Suite
+	recorder *tracetest.SpanRecorder
+	provider *sdktrace.TracerProvider
+}
+
+func TestTracingTestSuite(t *testing.T) {
+	suite.Run(t, new(TracingTestSuite))
+}
+
+func (s *TracingTestSuite) SetupTest() {
+	s.recorder = tracetest.NewSpanRecorder()
+	s.provider = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(s.recorder))
+}
+
+// spans returns the ended spans with the given name.
+func (s *TracingTestSuite) spans(name string) []sdktrace.ReadOnlySpan {
+	var spans []sdktrace.ReadOnlySpan
+	for _, span := range s.recorder.Ended() {
+		if span.Name() == name {
+			spans = append(spans, span)
+		}
+	}
+	return spans
+}
+
+// attributes returns the attributes of span as a map.
+func attributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func (s *TracingTestSuite) TestLoad() {
+	path := filepath.Join(s.T().TempDir(), "config.json")
+	content := `{"foo": "bar"}`
+	s.Require().NoError(os.WriteFile(path, []byte(content), 0o600))
+
+	c, err := New(
+		WithTracerProvider(s.provider),
+		WithFileSource(path, codec.TypeJSON),
+		WithNamedSource("remote", &mockSource{conf: map[string]any{"baz": "qux"}}),
+	)
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	loads := s.spans("conflex.Load")
+	s.Require().Len(loads, 1)
+	s.Equal(codes.Unset, loads[0].Status().Code)
+
+	sources := s.spans("conflex.source.load")
+	s.Require().Len(sources, 2)
+	for _, span := range sources {
+		s.Equal(loads[0].SpanContext().SpanID(), span.Parent().SpanID())
+	}
+
+	file := attributes(sources[0])
+	s.Equal("source[0]", file[AttributeSourceName].AsString())
+	s.Equal("file", file[AttributeSourceType].AsString())
+	s.Equal(path, file[AttributeSourceTarget].AsString())
+	s.Equal(int64(len(content)), file[AttributeSourceBytes].AsInt64())
+	s.False(file[AttributeSourceOptional].AsBool())
+
+	remote := attributes(sources[1])
+	s.Equal("remote", remote[AttributeSourceName].AsString())
+	s.Equal("*conflex.mockSource", remote[AttributeSourceType].AsString())
+	s.NotContains(remote, AttributeSourceBytes)
+}
+
+func (s *TracingTestSuite) TestLoad_Error() {
+	c, err := New(
+		WithTracerProvider(s.provider),
+		WithSource(&mockSource{err: errors.New("connection refused")}),
+	)
+	s.Require().NoError(err)
+	s.Require().Error(c.Load(context.Background()))
+
+	for _, name := range []string{"conflex.Load", "conflex.source.load"} {
+		spans := s.spans(name)
+		s.Require().Len(spans, 1, name)
+		s.Equal(codes.Error, spans[0].Status().Code, name)
+		s.Contains(spans[0].Status().Description, "connection refused", name)
+	}
+}
+
+func (s *TracingTestSuite) TestNilContext() {
+	c, err := New(
+		WithTracerProvider(s.provider),
+		WithSource(&mockSource{conf: map[string]any{"foo": "bar"}}),
+	)
+	s.Require().NoError(err)
+
+	s.EqualError(c.Load(nil), "context cannot be nil")
+	s.EqualError(c.Reload(nil, "source[0]"), "context cannot be nil")
+	s.Empty(s.recorder.Ended())
+}
+
+func (s *TracingTestSuite) TestReload() {
+	c, err := New(
+		WithTracerProvider(s.provider),
+		WithNamedSource("local", &mockSource{conf: map[string]any{"foo": "bar"}}),
+		WithNamedSource("remote", &mockSource{conf: map[string]any{"baz": "qux"}}),
+	)
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+	s.recorder = tracetest.NewSpanRecorder()
+	s.provider.RegisterSpanProcessor(s.recorder)
+
+	s.Require().NoError(c.Reload(context.Background(), "local"))
+
+	reloads := s.spans("conflex.Reload")
+	s.Require().Len(reloads, 1)
+	s.Equal("local", attributes(reloads[0])[AttributeSourceName].AsString())
+
+	// Only the reloaded source is loaded again.
+	sources := s.spans("conflex.source.load")
+	s.Require().Len(sources, 1)
+	s.Equal("local", attributes(sources[0])[AttributeSourceName].AsString())
+}
+
+func (s *TracingTestSuite) TestDump() {
+	c, err := New(
+		WithTracerProvider(s.provider),
+		WithSource(&mockSource{conf: map[string]any{"foo": "bar"}}),
+		WithDumper(&mockDumper{}),
+		WithNamedDumper("backup", &mockDumper{err: errors.New("disk full")}),
+	)
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+	s.Require().Error(c.Dump(context.Background()))
+
+	dumps := s.spans("conflex.Dump")
+	s.Require().Len(dumps, 1)
+	s.Equal(codes.Error, dumps[0].Status().Code)
+
+	dumpers := make(map[string]sdktrace.ReadOnlySpan)
+	for _, span := range s.spans("conflex.dumper.dump") {
+		s.Equal(dumps[0].SpanContext().SpanID(), span.Parent().SpanID())
+		dumpers[attributes(span)[AttributeDumperName].AsString()] = span
+	}
+	s.Require().Len(dumpers, 2)
+	s.Equal(codes.Unset, dumpers["dumper[0]"].Status().Code)
+	s.Equal(codes.Error, dumpers["backup"].Status().Code)
+}
+
+func (s *TracingTestSuite) TestWatch() {
+	src := &mockSyncSource{conf: map[string]any{"foo": "bar"}}
+	c, err := New(WithTracerProvider(s.provider), WithSource(src), WithPollInterval(20*time.Millisecond))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- c.Watch(ctx)
+	}()
+	s.Eventually(func() bool { return len(s.spans("conflex.Watch.reload")) > 0 }, 2*time.Second, 10*time.Millisecond)
+	cancel()
+	s.Require().NoError(<-done)
+
+	reload := s.spans("conflex.Watch.reload")[0]
+	var found bool
+	for _, span := range s.spans("conflex.Load") {
+		if span.Parent().SpanID() == reload.SpanContext().SpanID() {
+			found = true
+		}
+	}
+	s.True(found, "the reload span has no Load child span")
+}
+
+func (s *TracingTestSuite) TestWithTracerProvider_Invalid() {
+	_, err := New(WithTracerProvider(nil))
+	s.ErrorContains(err, "tracer provider cannot be nil")
+}
@@ -0,0 +1,102 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ValidateTestSuite struct {
+	suite.Suite
+}
+
+func TestValidateTestSuite(t *testing.T) {
+	suite.Run(t, new(ValidateTestSuite))
+}
+
+func (s *ValidateTestSuite) TestDoesNotApply() {
+	src := &mockSyncSource{conf: map[string]any{"port": 8080}}
+	var cfg struct {
+		Port int `conflex:"port"`
+	}
+	c, err := New(WithSource(src), WithBinding(&cfg))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	changes := 0
+	c.OnChange(func(_, _ map[string]any) { changes++ })
+	status := c.ReloadStatus()
+
+	src.set(map[string]any{"port": 9090}, nil)
+	s.Require().NoError(c.Validate(context.Background()))
+
+	s.Equal(8080, c.GetInt("port"))
+	s.Equal(8080, cfg.Port)
+	s.Zero(changes)
+	s.Equal(status, c.ReloadStatus())
+}
+
+func (s *ValidateTestSuite) TestReportsErrors() {
+	src := &mockSyncSource{conf: map[string]any{"port": 8080}}
+	c, err := New(
+		WithSource(src),
+		WithValidator(func(values map[string]any) error {
+			if values["port"] == 0 {
+				return errors.New("port must be set")
+			}
+			return nil
+		}),
+		WithBinding(&struct {
+			Name string `conflex:"name,required"`
+		}{}),
+	)
+	s.Require().NoError(err)
+
+	src.set(map[string]any{"port": 0}, nil)
+	err = c.Validate(context.Background())
+	s.Require().Error(err)
+	s.Contains(err.Error(), "port must be set")
+	s.ErrorIs(err, ErrRequiredKeyMissing)
+
+	loadErr := errors.New("unavailable")
+	src.set(nil, loadErr)
+	s.ErrorIs(c.Validate(context.Background()), loadErr)
+}
+
+func (s *ValidateTestSuite) TestAppliesDefaultsAndDeprecationsWithoutWarning() {
+	var cfg struct {
+		Port int    `conflex:"port,default=8080,required"`
+		Host string `conflex:"host,required"`
+	}
+	c, err := New(
+		WithSource(&mockSource{conf: map[string]any{"hostname": "localhost"}}),
+		WithDeprecatedKey("hostname", "host", ""),
+		WithBinding(&cfg),
+	)
+	s.Require().NoError(err)
+
+	s.NoError(c.Validate(context.Background()))
+	s.Empty(c.deprecationsWarned)
+}
+
+func (s *ValidateTestSuite) TestNilContext() {
+	c, err := New()
+	s.Require().NoError(err)
+	s.Error(c.Validate(nil))
+}
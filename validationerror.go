@@ -0,0 +1,253 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/santhosh-tekuri/jsonschema/v6/kind"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// ValidationError describes a single invalid configuration value, addressed by its key path.
+// The errors returned by Load and Validate carry one ValidationError for every problem reported by the
+// JSON Schema, the required keys, decoding into the binding and the struct validator. Use ValidationErrors
+// to extract them, e.g. to render them in a CLI or an admin UI.
+type ValidationError struct {
+	Path     string // JSON pointer to the offending key (e.g., "/server/port")
+	Source   string // The source that supplied the value (e.g., "source[1]"), if known
+	Expected string // The expected type or rule (e.g., "integer", "min=1"), if known
+	Actual   any    // The offending value, nil if the key is missing
+	Message  string // Description of the problem
+}
+
+// Error returns the path followed by the description of the problem.
+func (e *ValidationError) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return e.Path + ": " + e.Message
+}
+
+// Key returns the dot-separated configuration key of the offending value, e.g. "server.port". Dots inside
+// segments are escaped with a backslash, so the key can be passed to Get.
+func (e *ValidationError) Key() string {
+	return joinKey(pointerSegments(e.Path))
+}
+
+// ValidationErrors returns every ValidationError in the tree of err, in order.
+// It returns nil if err does not describe any invalid value.
+func ValidationErrors(err error) []*ValidationError {
+	var found []*ValidationError
+	var walk func(err error)
+	walk = func(err error) {
+		switch e := err.(type) {
+		case nil:
+		case *ValidationError:
+			found = append(found, e)
+		case interface{ Unwrap() []error }:
+			for _, inner := range e.Unwrap() {
+				walk(inner)
+			}
+		case interface{ Unwrap() error }:
+			walk(e.Unwrap())
+		}
+	}
+	walk(err)
+	return found
+}
+
+// jsonPointer returns the JSON pointer (RFC 6901) of the key path.
+func jsonPointer(path []string) string {
+	var sb strings.Builder
+	for _, segment := range path {
+		sb.WriteByte('/')
+		segment = strings.ReplaceAll(segment, "~", "~0")
+		sb.WriteString(strings.ReplaceAll(segment, "/", "~1"))
+	}
+	return sb.String()
+}
+
+// pointerSegments returns the key path of the JSON pointer.
+func pointerSegments(pointer string) []string {
+	if pointer == "" {
+		return nil
+	}
+	segments := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	for i, segment := range segments {
+		segment = strings.ReplaceAll(segment, "~1", "/")
+		segments[i] = strings.ReplaceAll(segment, "~0", "~")
+	}
+	return segments
+}
+
+// detailedError keeps the message of err while exposing the ValidationErrors describing it.
+type detailedError struct {
+	err     error
+	details []*ValidationError
+}
+
+func (e *detailedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *detailedError) Unwrap() []error {
+	errs := make([]error, 0, len(e.details)+1)
+	errs = append(errs, e.err)
+	for _, detail := range e.details {
+		errs = append(errs, detail)
+	}
+	return errs
+}
+
+// withDetails attaches details to err, keeping its message. It returns err unchanged without details.
+func withDetails(err error, details []*ValidationError) error {
+	if len(details) == 0 {
+		return err
+	}
+	return &detailedError{err: err, details: details}
+}
+
+// valueAt returns the value at the key path in v, descending into maps and slices.
+func valueAt(v any, path []string) any {
+	for _, segment := range path {
+		switch node := v.(type) {
+		case map[string]any:
+			v = node[segment]
+		case []any:
+			i, err := strconv.Atoi(segment)
+			if err != nil || i < 0 || i >= len(node) {
+				return nil
+			}
+			v = node[i]
+		default:
+			return nil
+		}
+	}
+	return v
+}
+
+// missingKeyError describes the missing key at the key path.
+func missingKeyError(path []string) *ValidationError {
+	return &ValidationError{Path: jsonPointer(path), Expected: "required", Message: "required key is missing"}
+}
+
+// schemaPrinter renders JSON Schema error messages.
+var schemaPrinter = message.NewPrinter(language.English)
+
+// schemaValidationErrors describes every failed assertion of a JSON Schema validation error of values.
+// It returns nil if err is not a JSON Schema validation error.
+func schemaValidationErrors(err error, values map[string]any) []*ValidationError {
+	var schemaErr *jsonschema.ValidationError
+	if !errors.As(err, &schemaErr) {
+		return nil
+	}
+
+	var details []*ValidationError
+	var walk func(e *jsonschema.ValidationError)
+	walk = func(e *jsonschema.ValidationError) {
+		if len(e.Causes) > 0 {
+			for _, cause := range e.Causes {
+				walk(cause)
+			}
+			return
+		}
+
+		if k, ok := e.ErrorKind.(*kind.Required); ok {
+			for _, missing := range k.Missing {
+				path := append(append([]string(nil), e.InstanceLocation...), missing)
+				details = append(details, missingKeyError(path))
+			}
+			return
+		}
+
+		detail := &ValidationError{
+			Path:    jsonPointer(e.InstanceLocation),
+			Actual:  valueAt(values, e.InstanceLocation),
+			Message: e.ErrorKind.LocalizedString(schemaPrinter),
+		}
+		switch k := e.ErrorKind.(type) {
+		case *kind.Type:
+			detail.Expected = strings.Join(k.Want, " or ")
+		case *kind.Format:
+			detail.Expected = k.Want
+		}
+		details = append(details, detail)
+	}
+	walk(schemaErr)
+	return details
+}
+
+// decodeValidationErrors describes every value of err, an error decoding into the binding, that could not
+// be converted to the type of its field.
+func decodeValidationErrors(err error) []*ValidationError {
+	var details []*ValidationError
+	var walk func(err error, name string)
+	walk = func(err error, name string) {
+		switch e := err.(type) {
+		case nil:
+		case *mapstructure.DecodeError:
+			walk(e.Unwrap(), e.Name())
+		case *mapstructure.UnconvertibleTypeError:
+			details = append(details, &ValidationError{
+				Path:     decodePointer(name),
+				Expected: e.Expected.Type().String(),
+				Actual:   e.Value,
+				Message:  e.Error(),
+			})
+		case *mapstructure.ParseError:
+			details = append(details, &ValidationError{
+				Path:     decodePointer(name),
+				Expected: e.Expected.Type().String(),
+				Actual:   e.Value,
+				Message:  e.Error(),
+			})
+		case interface{ Unwrap() []error }:
+			for _, inner := range e.Unwrap() {
+				walk(inner, name)
+			}
+		case interface{ Unwrap() error }:
+			walk(e.Unwrap(), name)
+		}
+	}
+	walk(err, "")
+	return details
+}
+
+// prefixDetails moves the paths of details below the key prefix, for errors reported by a binding of the
+// subtree at prefix. It returns details.
+func prefixDetails(details []*ValidationError, prefix string) []*ValidationError {
+	if prefix == "" {
+		return details
+	}
+	for _, detail := range details {
+		detail.Path = jsonPointer(append(splitKey(prefix), pointerSegments(detail.Path)...))
+	}
+	return details
+}
+
+// decodePointer converts a mapstructure field name, such as "servers[0].port", into a JSON pointer.
+func decodePointer(name string) string {
+	if name == "" {
+		return ""
+	}
+	name = strings.ReplaceAll(name, "]", "")
+	return jsonPointer(strings.FieldsFunc(name, func(r rune) bool { return r == '.' || r == '[' }))
+}
@@ -0,0 +1,173 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ValidationErrorTestSuite struct {
+	suite.Suite
+}
+
+func TestValidationErrorTestSuite(t *testing.T) {
+	suite.Run(t, new(ValidationErrorTestSuite))
+}
+
+func (s *ValidationErrorTestSuite) TestError() {
+	err := &ValidationError{Path: "/server/port", Message: "got string, want integer"}
+	s.Equal("/server/port: got string, want integer", err.Error())
+	s.Equal("server.port", err.Key())
+	s.Equal(`labels.app\.kubernetes\.io/name`, (&ValidationError{Path: "/labels/app.kubernetes.io~1name"}).Key())
+
+	s.Equal("invalid", (&ValidationError{Message: "invalid"}).Error())
+}
+
+func (s *ValidationErrorTestSuite) TestPrefixDetails() {
+	details := prefixDetails([]*ValidationError{{Path: "/port"}}, `services.api\.v1`)
+	s.Equal("/services/api.v1/port", details[0].Path)
+	s.Equal(`services.api\.v1.port`, details[0].Key())
+}
+
+func (s *ValidationErrorTestSuite) TestJSONPointer() {
+	s.Equal("", jsonPointer(nil))
+	s.Equal("/a~1b/c~0d/0", jsonPointer([]string{"a/b", "c~d", "0"}))
+	s.Equal([]string{"a/b", "c~d", "0"}, pointerSegments("/a~1b/c~0d/0"))
+	s.Nil(pointerSegments(""))
+}
+
+func (s *ValidationErrorTestSuite) TestValidationErrors() {
+	first := &ValidationError{Path: "/a"}
+	second := &ValidationError{Path: "/b"}
+	err := fmt.Errorf("wrapped: %w", errors.Join(
+		NewConfigError("json-schema", "validate", withDetails(errors.New("invalid"), []*ValidationError{first})),
+		second,
+	))
+
+	s.Equal([]*ValidationError{first, second}, ValidationErrors(err))
+	s.Nil(ValidationErrors(errors.New("plain")))
+	s.Nil(ValidationErrors(nil))
+}
+
+func (s *ValidationErrorTestSuite) TestWithDetailsKeepsMessage() {
+	base := errors.New("invalid")
+	err := withDetails(base, []*ValidationError{{Path: "/a"}})
+	s.Equal("invalid", err.Error())
+	s.ErrorIs(err, base)
+	s.Same(base, withDetails(base, nil))
+}
+
+func (s *ValidationErrorTestSuite) TestJSONSchema() {
+	schema := []byte(`{
+		"type": "object",
+		"properties": {
+			"server": {
+				"type": "object",
+				"properties": {"port": {"type": "integer"}},
+				"required": ["host"]
+			}
+		}
+	}`)
+	c, err := New(
+		WithSource(&mockSource{conf: map[string]any{"server": map[string]any{"host": "localhost"}}}),
+		WithSource(&mockSource{conf: map[string]any{"server": map[string]any{"port": "http"}}}),
+		WithJSONSchema(schema),
+	)
+	s.Require().NoError(err)
+
+	err = c.Load(context.Background())
+	s.Require().Error(err)
+	details := ValidationErrors(err)
+	s.Require().Len(details, 1)
+	s.Equal("/server/port", details[0].Path)
+	s.Equal("source[1]", details[0].Source)
+	s.Equal("integer", details[0].Expected)
+	s.Equal("http", details[0].Actual)
+	s.Contains(details[0].Message, "want integer")
+}
+
+func (s *ValidationErrorTestSuite) TestJSONSchemaRequired() {
+	schema := []byte(`{"type": "object", "required": ["name", "port"]}`)
+	c, err := New(
+		WithSource(&mockSource{conf: map[string]any{"port": 8080}}),
+		WithJSONSchema(schema),
+	)
+	s.Require().NoError(err)
+
+	details := ValidationErrors(c.Load(context.Background()))
+	s.Require().Len(details, 1)
+	s.Equal("/name", details[0].Path)
+	s.Equal("required", details[0].Expected)
+	s.Empty(details[0].Source)
+	s.Nil(details[0].Actual)
+}
+
+func (s *ValidationErrorTestSuite) TestRequiredKeys() {
+	c, err := New(
+		WithSource(&mockSource{conf: map[string]any{}}),
+		WithBinding(&struct {
+			Server struct {
+				Host string `conflex:"host,required"`
+			} `conflex:"server"`
+		}{}),
+	)
+	s.Require().NoError(err)
+
+	err = c.Load(context.Background())
+	s.ErrorIs(err, ErrRequiredKeyMissing)
+	details := ValidationErrors(err)
+	s.Require().Len(details, 1)
+	s.Equal("/server/host", details[0].Path)
+	s.Equal("required key is missing", details[0].Message)
+}
+
+func (s *ValidationErrorTestSuite) TestDecode() {
+	c, err := New(
+		WithSource(&mockSource{conf: map[string]any{"servers": []any{map[string]any{"port": "http"}}}}),
+		WithBinding(&struct {
+			Servers []struct {
+				Port int `conflex:"port"`
+			} `conflex:"servers"`
+		}{}),
+	)
+	s.Require().NoError(err)
+
+	details := ValidationErrors(c.Load(context.Background()))
+	s.Require().Len(details, 1)
+	s.Equal("/servers/0/port", details[0].Path)
+	s.Equal("source[0]", details[0].Source)
+	s.Equal("int", details[0].Expected)
+	s.Equal("http", details[0].Actual)
+}
+
+func (s *ValidationErrorTestSuite) TestSourceValidation() {
+	schema := []byte(`{"type": "object", "properties": {"port": {"type": "integer"}}}`)
+	c, err := New(
+		WithSource(&mockSource{conf: map[string]any{"port": 8080}}),
+		WithValidatedSource(&mockSource{conf: map[string]any{"port": true}}, schema),
+	)
+	s.Require().NoError(err)
+
+	details := ValidationErrors(c.Load(context.Background()))
+	s.Require().Len(details, 1)
+	s.Equal("/port", details[0].Path)
+	s.Equal("source[1]", details[0].Source)
+	s.Equal(true, details[0].Actual)
+}
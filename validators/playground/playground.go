@@ -0,0 +1,50 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package playground checks the `validate` struct tags of a conflex binding with go-playground's validator
+// (github.com/go-playground/validator/v10):
+//
+//	type AppConfig struct {
+//		Port int    `conflex:"port" validate:"min=1,max=65535"`
+//		Mode string `conflex:"mode" validate:"oneof=dev prod"`
+//	}
+//
+//	cfg, err := conflex.New(
+//		conflex.WithFileSource("config.yaml", codec.TypeYAML),
+//		conflex.WithBinding(&appConfig),
+//		playground.WithValidation(),
+//	)
+//
+// Each failing field is reported as a conflex.ConfigError addressed by the configuration key of the field.
+//
+// It is a separate module, so programs that do not validate tags do not depend on the validator.
+package playground
+
+import (
+	"github.com/go-playground/validator/v10"
+	"go.companyinfo.dev/conflex"
+)
+
+// New returns a validator for `validate` tags, with required checks enabled on nested structs.
+// Custom rules can be registered with its RegisterValidation method before it is passed to
+// conflex.WithStructValidation.
+func New() *validator.Validate {
+	return validator.New(validator.WithRequiredStructEnabled())
+}
+
+// WithValidation validates the `validate` tags of the bound struct each time the configuration is loaded,
+// using a validator returned by New. It has no effect without conflex.WithBinding.
+func WithValidation() conflex.Option {
+	return conflex.WithStructValidation(New())
+}
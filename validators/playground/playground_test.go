@@ -0,0 +1,96 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package playground
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/suite"
+	"go.companyinfo.dev/conflex"
+	"go.companyinfo.dev/conflex/codec"
+)
+
+type serverConfig struct {
+	Port int `conflex:"listen_port" validate:"min=1,max=65535"`
+}
+
+type appConfig struct {
+	Mode    string         `conflex:"mode" validate:"oneof=dev prod"`
+	Server  serverConfig   `conflex:"server"`
+	Servers []serverConfig `conflex:"servers" validate:"dive"`
+}
+
+type PlaygroundTestSuite struct {
+	suite.Suite
+}
+
+func TestPlaygroundTestSuite(t *testing.T) {
+	suite.Run(t, new(PlaygroundTestSuite))
+}
+
+func (s *PlaygroundTestSuite) load(content string, options ...conflex.Option) (*appConfig, error) {
+	var cfg appConfig
+	c, err := conflex.New(append([]conflex.Option{
+		conflex.WithContentSource([]byte(content), codec.TypeJSON),
+		conflex.WithBinding(&cfg),
+	}, options...)...)
+	s.Require().NoError(err)
+	return &cfg, c.Load(context.Background())
+}
+
+func (s *PlaygroundTestSuite) TestValid() {
+	cfg, err := s.load(`{"mode": "prod", "server": {"listen_port": 8080}}`, WithValidation())
+	s.Require().NoError(err)
+	s.Equal(8080, cfg.Server.Port)
+}
+
+func (s *PlaygroundTestSuite) TestInvalid() {
+	_, err := s.load(`{"mode": "test", "server": {"listen_port": 0}, "servers": [{"listen_port": 80}, {"listen_port": 70000}]}`,
+		WithValidation())
+	s.Require().Error(err)
+	s.Contains(err.Error(), `config error in binding.mode during validate: failed the "oneof=dev prod" rule`)
+	s.Contains(err.Error(), `config error in binding.server.listen_port during validate: failed the "min=1" rule`)
+
+	details := conflex.ValidationErrors(err)
+	s.Require().Len(details, 3)
+	s.Equal("/servers/1/listen_port", details[2].Path)
+	s.Equal("max=65535", details[2].Expected)
+}
+
+func (s *PlaygroundTestSuite) TestCustomRule() {
+	v := New()
+	s.Require().NoError(v.RegisterValidation("dev", func(fl validator.FieldLevel) bool {
+		return fl.Field().String() == "dev"
+	}))
+	type devConfig struct {
+		Mode string `conflex:"mode" validate:"dev"`
+	}
+
+	var cfg devConfig
+	c, err := conflex.New(
+		conflex.WithContentSource([]byte(`{"mode": "prod"}`), codec.TypeJSON),
+		conflex.WithBinding(&cfg),
+		conflex.WithStructValidation(v),
+	)
+	s.Require().NoError(err)
+
+	err = c.Load(context.Background())
+	var configErr *conflex.ConfigError
+	s.Require().True(errors.As(err, &configErr))
+	s.Contains(err.Error(), `failed the "dev" rule`)
+}
@@ -0,0 +1,163 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package validators provides reusable validation functions for conflex configurations.
+// Each function returns a validator for WithValidator or WithValidatedSource that checks the value at a
+// key, resolved like conflex getters resolve it (see conflex.Lookup):
+//
+//	conflex.WithValidator(validators.All(
+//		validators.Port("server.port"),
+//		validators.URL("upstream.url"),
+//		validators.OneOf("log.level", "debug", "info", "warn", "error"),
+//	))
+//
+// Except for NonEmpty, the validators accept a missing key, so they can be combined with the "required"
+// tag option or NonEmpty for keys that must be present.
+package validators
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cast"
+	"go.companyinfo.dev/conflex"
+)
+
+// Validator is a configuration validation function, as accepted by conflex.WithValidator.
+type Validator = func(values map[string]any) error
+
+// All returns a validator that runs all the given validators and joins their errors.
+func All(validators ...Validator) Validator {
+	return func(values map[string]any) error {
+		var errs []error
+		for _, v := range validators {
+			if err := v(values); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		return errors.Join(errs...)
+	}
+}
+
+// Port returns a validator that checks that the value at key is a TCP port number between 1 and 65535.
+func Port(key string) Validator {
+	return check(key, func(value any) error {
+		port, err := cast.ToIntE(value)
+		if err != nil {
+			return fmt.Errorf("must be a port number, got %v", value)
+		}
+		if port < 1 || port > 65535 {
+			return fmt.Errorf("must be a port number between 1 and 65535, got %d", port)
+		}
+		return nil
+	})
+}
+
+// FileExists returns a validator that checks that the value at key is an absolute path to an existing file.
+func FileExists(key string) Validator {
+	return check(key, func(value any) error {
+		path, err := cast.ToStringE(value)
+		if err != nil {
+			return fmt.Errorf("must be a file path, got %v", value)
+		}
+		if !filepath.IsAbs(path) {
+			return fmt.Errorf("must be an absolute path, got %q", path)
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return fmt.Errorf("file %q: %w", path, err)
+		}
+		if info.IsDir() {
+			return fmt.Errorf("%q is a directory, not a file", path)
+		}
+		return nil
+	})
+}
+
+// URL returns a validator that checks that the value at key is an absolute URL with a scheme and a host.
+// If schemes are given, the URL must use one of them.
+func URL(key string, schemes ...string) Validator {
+	return check(key, func(value any) error {
+		raw, err := cast.ToStringE(value)
+		if err != nil {
+			return fmt.Errorf("must be a URL, got %v", value)
+		}
+		u, err := url.Parse(raw)
+		if err != nil {
+			return fmt.Errorf("must be a URL: %w", err)
+		}
+		if u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("must be an absolute URL with a scheme and host, got %q", raw)
+		}
+		if len(schemes) > 0 && !slices.Contains(schemes, u.Scheme) {
+			return fmt.Errorf("must use one of the schemes %s, got %q", strings.Join(schemes, ", "), u.Scheme)
+		}
+		return nil
+	})
+}
+
+// NonEmpty returns a validator that checks that the key is set to a non-empty string.
+// Unlike the other validators, it fails if the key is missing.
+func NonEmpty(key string) Validator {
+	return func(values map[string]any) error {
+		value, ok := lookup(values, key)
+		if !ok || value == nil {
+			return fmt.Errorf("%s: must be set", key)
+		}
+		s, err := cast.ToStringE(value)
+		if err != nil {
+			return fmt.Errorf("%s: must be a string, got %v", key, value)
+		}
+		if strings.TrimSpace(s) == "" {
+			return fmt.Errorf("%s: must not be empty", key)
+		}
+		return nil
+	}
+}
+
+// OneOf returns a validator that checks that the value at key is one of the allowed values.
+// Values are compared as strings, so OneOf("retries", "1", "3") accepts the number 3.
+func OneOf(key string, allowed ...string) Validator {
+	return check(key, func(value any) error {
+		s, err := cast.ToStringE(value)
+		if err != nil || !slices.Contains(allowed, s) {
+			return fmt.Errorf("must be one of %s, got %v", strings.Join(allowed, ", "), value)
+		}
+		return nil
+	})
+}
+
+// check returns a validator that calls fn with the value at key, if the key is set, prefixing errors with the key.
+func check(key string, fn func(value any) error) Validator {
+	return func(values map[string]any) error {
+		value, ok := lookup(values, key)
+		if !ok || value == nil {
+			return nil
+		}
+		if err := fn(value); err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+		return nil
+	}
+}
+
+// lookup returns the value at key in values, resolving the key like conflex getters do (see conflex.Lookup).
+func lookup(values map[string]any, key string) (any, bool) {
+	return conflex.Lookup(values, key)
+}
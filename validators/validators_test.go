@@ -0,0 +1,118 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validators
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.companyinfo.dev/conflex"
+	"go.companyinfo.dev/conflex/codec"
+)
+
+type ValidatorsTestSuite struct {
+	suite.Suite
+}
+
+func TestValidatorsTestSuite(t *testing.T) {
+	suite.Run(t, new(ValidatorsTestSuite))
+}
+
+func (s *ValidatorsTestSuite) TestPort() {
+	v := Port("server.port")
+	s.NoError(v(map[string]any{"server": map[string]any{"port": 8080}}))
+	s.NoError(v(map[string]any{"server": map[string]any{"port": "443"}}))
+	s.NoError(v(map[string]any{"server": map[string]any{"port": float64(80)}}))
+	s.NoError(v(map[string]any{}), "missing keys are accepted")
+
+	s.EqualError(v(map[string]any{"server": map[string]any{"port": 0}}), "server.port: must be a port number between 1 and 65535, got 0")
+	s.Error(v(map[string]any{"server": map[string]any{"port": 70000}}))
+	s.Error(v(map[string]any{"server": map[string]any{"port": "http"}}))
+}
+
+func (s *ValidatorsTestSuite) TestFileExists() {
+	dir := s.T().TempDir()
+	file := filepath.Join(dir, "cert.pem")
+	s.Require().NoError(os.WriteFile(file, []byte("cert"), 0o600))
+
+	v := FileExists("tls.cert")
+	s.NoError(v(map[string]any{"tls": map[string]any{"cert": file}}))
+	s.ErrorContains(v(map[string]any{"tls": map[string]any{"cert": "cert.pem"}}), "must be an absolute path")
+	s.ErrorContains(v(map[string]any{"tls": map[string]any{"cert": filepath.Join(dir, "missing.pem")}}), "no such file")
+	s.ErrorContains(v(map[string]any{"tls": map[string]any{"cert": dir}}), "is a directory")
+}
+
+func (s *ValidatorsTestSuite) TestURL() {
+	s.NoError(URL("upstream")(map[string]any{"upstream": "https://example.com/api"}))
+	s.Error(URL("upstream")(map[string]any{"upstream": "example.com"}))
+	s.Error(URL("upstream")(map[string]any{"upstream": "http://[::1"}))
+
+	https := URL("upstream", "https")
+	s.NoError(https(map[string]any{"upstream": "https://example.com"}))
+	s.EqualError(https(map[string]any{"upstream": "http://example.com"}), `upstream: must use one of the schemes https, got "http"`)
+}
+
+func (s *ValidatorsTestSuite) TestNonEmpty() {
+	v := NonEmpty("name")
+	s.NoError(v(map[string]any{"name": "app"}))
+	s.EqualError(v(map[string]any{}), "name: must be set")
+	s.EqualError(v(map[string]any{"name": nil}), "name: must be set")
+	s.EqualError(v(map[string]any{"name": "  "}), "name: must not be empty")
+	s.Error(v(map[string]any{"name": []any{"a"}}))
+}
+
+func (s *ValidatorsTestSuite) TestOneOf() {
+	v := OneOf("log.level", "debug", "info")
+	s.NoError(v(map[string]any{"log": map[string]any{"level": "info"}}))
+	s.EqualError(v(map[string]any{"log": map[string]any{"level": "trace"}}), "log.level: must be one of debug, info, got trace")
+	s.NoError(OneOf("retries", "1", "3")(map[string]any{"retries": 3}))
+}
+
+func (s *ValidatorsTestSuite) TestAll() {
+	v := All(Port("port"), NonEmpty("name"))
+	s.NoError(v(map[string]any{"port": 80, "name": "app"}))
+
+	err := v(map[string]any{"port": 0})
+	s.ErrorContains(err, "port: must be a port number")
+	s.ErrorContains(err, "name: must be set")
+}
+
+func (s *ValidatorsTestSuite) TestLookupCaseInsensitive() {
+	s.NoError(NonEmpty("Server.Name")(map[string]any{"server": map[string]any{"name": "app"}}))
+	s.NoError(NonEmpty("server.name")(map[string]any{"server.name": "app"}))
+}
+
+func (s *ValidatorsTestSuite) TestLookupPaths() {
+	values := map[string]any{
+		"labels":  map[string]any{"app.kubernetes.io/name": ""},
+		"servers": []any{map[string]any{"port": 80}, map[string]any{"port": 0}},
+	}
+	s.ErrorContains(NonEmpty(`labels.app\.kubernetes\.io/name`)(values), "must not be empty")
+	s.NoError(Port("servers.0.port")(values))
+	s.ErrorContains(Port("servers.1.port")(values), "servers.1.port: must be a port number between 1 and 65535")
+}
+
+func (s *ValidatorsTestSuite) TestCaseSensitiveKeys() {
+	c, err := conflex.New(
+		conflex.WithContentSource([]byte(`{"Server": {"Port": 0}}`), codec.TypeJSON),
+		conflex.WithCaseSensitiveKeys(),
+		conflex.WithValidator(Port("Server.Port")),
+	)
+	s.Require().NoError(err)
+	s.ErrorContains(c.Load(context.Background()), "Server.Port: must be a port number")
+}
@@ -0,0 +1,154 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.companyinfo.dev/conflex/internal/keypath"
+)
+
+// keyHasPrefix reports whether key equals prefix or is nested below it.
+func keyHasPrefix(key, prefix string) bool {
+	return key == prefix || strings.HasPrefix(key, prefix+".")
+}
+
+// splitKey splits a dot-separated key into its segments, unescaping dots escaped with a backslash, so
+// "labels.app\.kubernetes\.io/name" has the segments "labels" and "app.kubernetes.io/name". See keypath.Split.
+func splitKey(key string) []string {
+	return keypath.Split(key)
+}
+
+// joinKey joins key segments into a dot-separated key, escaping them so that splitKey returns the segments again.
+func joinKey(path []string) string {
+	return keypath.Join(path)
+}
+
+// escapeKeySegment escapes the dots and backslashes in a key segment, as described for splitKey.
+func escapeKeySegment(segment string) string {
+	return keypath.EscapeSegment(segment)
+}
+
+// walkLeaves calls fn for every non-map value in m, passing the path of keys leading to it.
+// Keys are visited in sorted order so callers produce deterministic output.
+// The path slice is reused between calls and must be copied if retained.
+func walkLeaves(m map[string]any, fn func(path []string, value any)) {
+	walkLeavesFrom(nil, m, fn)
+}
+
+func walkLeavesFrom(path []string, m map[string]any, fn func(path []string, value any)) {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		next := append(path, k)
+		if nested, ok := m[k].(map[string]any); ok && len(nested) > 0 {
+			walkLeavesFrom(next, nested, fn)
+			continue
+		}
+		fn(next, m[k])
+	}
+}
+
+// flattenInto adds value to flat under key. Non-empty maps and lists are descended into instead, with list elements
+// keyed by their index. Values are copied, so flat does not share maps or slices with value.
+func flattenInto(flat map[string]any, key string, value any) {
+	switch v := value.(type) {
+	case map[string]any:
+		if len(v) > 0 {
+			for k, item := range v {
+				flattenInto(flat, key+"."+escapeKeySegment(k), item)
+			}
+			return
+		}
+	case []any:
+		if len(v) > 0 {
+			for i, item := range v {
+				flattenInto(flat, key+"."+strconv.Itoa(i), item)
+			}
+			return
+		}
+	case []byte:
+		// Byte slices are values rather than lists.
+	default:
+		rv := reflect.ValueOf(value)
+		if (rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array) && rv.Len() > 0 {
+			for i := 0; i < rv.Len(); i++ {
+				flattenInto(flat, key+"."+strconv.Itoa(i), rv.Index(i).Interface())
+			}
+			return
+		}
+	}
+	flat[key] = copyValue(value)
+}
+
+// extractPath returns a map holding only the value at path in m, nested under the same keys.
+// It returns an empty map if m has no value at path. Values are shared, not copied.
+func extractPath(m map[string]any, path []string) map[string]any {
+	return keypath.Extract(m, path, false)
+}
+
+// setValue sets the value at path in m, creating intermediate maps as needed. It reports false, leaving m
+// unchanged, if a non-map value is in the way.
+func setValue(m map[string]any, path []string, value any) bool {
+	current := m
+	for _, segment := range path[:len(path)-1] {
+		nested, ok := current[segment].(map[string]any)
+		if !ok {
+			if current[segment] != nil {
+				return false
+			}
+			nested = make(map[string]any)
+			current[segment] = nested
+		}
+		current = nested
+	}
+	current[path[len(path)-1]] = value
+	return true
+}
+
+// copyMap returns a deep copy of m. Nested maps and slices are copied recursively; other values are shared.
+func copyMap(m map[string]any) map[string]any {
+	if m == nil {
+		return nil
+	}
+	out := make(map[string]any, len(m))
+	for k, v := range m {
+		out[k] = copyValue(v)
+	}
+	return out
+}
+
+// copyValue returns a deep copy of v if it is a map or slice, and v itself otherwise.
+func copyValue(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		return copyMap(val)
+	case []any:
+		out := make([]any, len(val))
+		for i, item := range val {
+			out[i] = copyValue(item)
+		}
+		return out
+	default:
+		return v
+	}
+}
@@ -0,0 +1,584 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// pathSource is implemented by sources backed by a file on disk, such as source.File.
+type pathSource interface {
+	Path() string
+}
+
+// pathsSource is implemented by file-backed sources that read other files too, such as source.File with
+// include directives. Paths returns every file the source read, so that changes to any of them are observed.
+type pathsSource interface {
+	Paths() []string
+}
+
+// WithPollInterval configures Watch to also reload the configuration every interval.
+// This is intended for sources without native change notification, such as remote stores or environment variables.
+// Change handlers registered with OnChange are only called when the merged configuration actually differs.
+func WithPollInterval(interval time.Duration) Option {
+	return func(c *Conflex) error {
+		if interval <= 0 {
+			return errors.New("poll interval must be positive")
+		}
+		c.pollInterval = interval
+		return nil
+	}
+}
+
+// WithWatchDebounce makes Watch wait until no file events have arrived for the given duration before reloading.
+// Editors and Kubernetes ConfigMap updates typically produce bursts of events for a single change;
+// debouncing coalesces such a burst into a single reload. A value around 500ms suits most setups.
+// Poll ticks configured with WithPollInterval are not debounced.
+func WithWatchDebounce(d time.Duration) Option {
+	return func(c *Conflex) error {
+		if d < 0 {
+			return errors.New("watch debounce cannot be negative")
+		}
+		c.watchDebounce = d
+		return nil
+	}
+}
+
+// Backoff configures exponential backoff for retries: the first retry happens after Initial,
+// and each following one after twice the previous delay, up to Max.
+type Backoff struct {
+	Initial    time.Duration // Delay before the first retry
+	Max        time.Duration // Upper bound of the delay; zero means no bound
+	MaxRetries int           // Consecutive failed attempts after which retrying stops; zero means no limit
+}
+
+// delay returns the delay before retry attempt (starting at 1), or zero if b is nil or the retries are exhausted.
+func (b *Backoff) delay(attempt int) time.Duration {
+	if b == nil || (b.MaxRetries > 0 && attempt > b.MaxRetries) {
+		return 0
+	}
+	d := b.Initial
+	for i := 1; i < attempt; i++ {
+		if (b.Max > 0 && d >= b.Max) || d > math.MaxInt64/2 {
+			break
+		}
+		d *= 2
+	}
+	if b.Max > 0 && d > b.Max {
+		d = b.Max
+	}
+	return d
+}
+
+// WithWatchBackoff makes Watch retry failures instead of waiting for the next change or giving up.
+// A failed reload, for example during a Consul outage or while a file is missing, is retried with the given
+// backoff until it succeeds. A failed file watcher is replaced by a new one, retried with the same backoff,
+// instead of ending Watch. Once MaxRetries consecutive attempts have failed, failed reloads are only retried
+// on the next change or poll, and a file watcher that cannot be replaced ends Watch with an error.
+func WithWatchBackoff(backoff Backoff) Option {
+	return func(c *Conflex) error {
+		if backoff.Initial <= 0 {
+			return errors.New("backoff initial delay must be positive")
+		}
+		if backoff.Max != 0 && backoff.Max < backoff.Initial {
+			return errors.New("backoff max delay cannot be less than the initial delay")
+		}
+		if backoff.MaxRetries < 0 {
+			return errors.New("backoff max retries cannot be negative")
+		}
+		c.watchBackoff = &backoff
+		return nil
+	}
+}
+
+// OnWatchError registers a handler that is called for every failure while watching: failed reloads, file
+// watcher failures, and change notifiers that stopped because of an error. retryIn is the delay before the next
+// attempt, or zero if no retry is scheduled; in that case a failed reload is retried on the next change or poll,
+// a file watcher failure ends Watch, and a stopped change notifier is no longer subscribed to.
+// Handlers are called synchronously from the watch goroutine.
+func (c *Conflex) OnWatchError(fn func(err error, retryIn time.Duration)) {
+	if fn == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.watchErrorHandlers = append(c.watchErrorHandlers, fn)
+}
+
+// notifyWatchError calls the registered watch error handlers.
+func (c *Conflex) notifyWatchError(err error, retryIn time.Duration) {
+	c.mu.RLock()
+	handlers := c.watchErrorHandlers
+	c.mu.RUnlock()
+
+	for _, fn := range handlers {
+		fn(err, retryIn)
+	}
+}
+
+// Watch watches the files backing the configured file sources and reloads the configuration whenever one of them changes.
+// Sources implementing ChangeNotifier trigger a reload whenever they report a change, and if a poll interval
+// is configured with WithPollInterval, the configuration is also reloaded periodically.
+// Reloading runs Load, so the new configuration is validated and rebound before it replaces the current one.
+// If a reload fails, the previous configuration stays in effect, the failure is reported to the handlers
+// registered with OnReloadError and to ReloadStatus, and watching continues.
+// Watch blocks until ctx is cancelled, in which case it returns nil, or until the file watcher fails and
+// cannot be replaced (see WithWatchBackoff). Use StartWatch to run the watch loop in the background instead.
+func (c *Conflex) Watch(ctx context.Context) error {
+	w, err := c.prepareWatch(ctx)
+	if err != nil {
+		return err
+	}
+	defer w.close()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	if err := c.startNotifiers(ctx, w); err != nil {
+		return err
+	}
+
+	return c.runWatch(ctx, w)
+}
+
+// StartWatch starts watching in the background, like Watch, and returns once the watchers are set up.
+// Setup errors are returned directly. The returned channel receives the error that ended the watch loop, if any,
+// and is closed when the loop has stopped, either because ctx was cancelled or because StopWatch was called.
+// This makes it easy to run the watcher in a run group or errgroup:
+//
+//	errs, err := cfg.StartWatch(ctx)
+//	if err != nil {
+//		return err
+//	}
+//	g.Go(func() error { return <-errs })
+//
+// Only one background watch can run at a time.
+func (c *Conflex) StartWatch(ctx context.Context) (<-chan error, error) {
+	c.watchMu.Lock()
+	defer c.watchMu.Unlock()
+
+	if c.watchRun != nil {
+		return nil, errors.New("watch already started")
+	}
+
+	w, err := c.prepareWatch(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	if err := c.startNotifiers(ctx, w); err != nil {
+		cancel()
+		w.close()
+		return nil, err
+	}
+
+	run := &watchRun{cancel: cancel, done: make(chan struct{})}
+	errs := make(chan error, 1)
+	c.watchRun = run
+
+	go func() {
+		defer close(run.done)
+		defer close(errs)
+		defer cancel()
+
+		err := c.runWatch(ctx, w)
+		w.close()
+
+		c.watchMu.Lock()
+		if c.watchRun == run {
+			c.watchRun = nil
+		}
+		c.watchMu.Unlock()
+
+		if err != nil {
+			errs <- err
+		}
+	}()
+
+	return errs, nil
+}
+
+// StopWatch stops the background watch started by StartWatch and waits for it to shut down.
+// If ctx is done before the watch loop has stopped, StopWatch returns the context's error.
+// Calling StopWatch when no watch is running is a no-op.
+func (c *Conflex) StopWatch(ctx context.Context) error {
+	if ctx == nil {
+		return errors.New("context cannot be nil")
+	}
+
+	c.watchMu.Lock()
+	run := c.watchRun
+	c.watchRun = nil
+	c.watchMu.Unlock()
+
+	if run == nil {
+		return nil
+	}
+
+	run.cancel()
+	select {
+	case <-run.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// watchRun tracks a background watch loop started by StartWatch.
+type watchRun struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// watchState holds the resources of a single watch loop.
+type watchState struct {
+	files     map[string]struct{}
+	watcher   *fsnotify.Watcher
+	ticker    *time.Ticker
+	notifiers []int         // Indexes of the sources implementing ChangeNotifier
+	changes   chan struct{} // Receives the notifications of all change notifiers
+	errors    chan error    // Receives the errors that stopped change notifiers
+}
+
+// close releases the file watcher and ticker.
+func (w *watchState) close() {
+	if w.watcher != nil {
+		_ = w.watcher.Close()
+	}
+	if w.ticker != nil {
+		w.ticker.Stop()
+	}
+}
+
+// prepareWatch validates the watch configuration and sets up the file watcher and poll ticker.
+func (c *Conflex) prepareWatch(ctx context.Context) (*watchState, error) {
+	if ctx == nil {
+		return nil, errors.New("context cannot be nil")
+	}
+
+	w := &watchState{files: c.watchedFiles()}
+	for i, src := range c.sources {
+		if notifiesChanges(src) {
+			w.notifiers = append(w.notifiers, i)
+		}
+	}
+	if len(w.files) == 0 && len(w.notifiers) == 0 && c.pollInterval <= 0 {
+		return nil, errors.New("no watchable sources configured")
+	}
+
+	if len(w.files) > 0 {
+		watcher, err := c.newFileWatcher(w.files)
+		if err != nil {
+			return nil, err
+		}
+		w.watcher = watcher
+	}
+
+	if c.pollInterval > 0 {
+		w.ticker = time.NewTicker(c.pollInterval)
+	}
+
+	return w, nil
+}
+
+// startNotifiers subscribes to the change notifiers of the watched sources until ctx is done.
+// Their notifications are coalesced into w.changes, so a burst of notifications causes a single reload.
+// If a notifier closes its channel because of an error, the error is sent to w.errors.
+func (c *Conflex) startNotifiers(ctx context.Context, w *watchState) error {
+	if len(w.notifiers) == 0 {
+		return nil
+	}
+
+	w.changes = make(chan struct{}, 1)
+	w.errors = make(chan error, len(w.notifiers))
+	for _, i := range w.notifiers {
+		changes, err := c.sources[i].(ChangeNotifier).Changes(ctx)
+		if err != nil {
+			return NewConfigError(c.sourceName(i), "watch", err)
+		}
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case _, ok := <-changes:
+					if !ok {
+						if reporter, ok := c.sources[i].(changeErrorReporter); ok && ctx.Err() == nil {
+							if err := reporter.ChangesErr(); err != nil {
+								w.errors <- NewConfigError(c.sourceName(i), "watch", err)
+							}
+						}
+						return
+					}
+					select {
+					case w.changes <- struct{}{}:
+					default:
+					}
+				}
+			}
+		}()
+	}
+
+	return nil
+}
+
+// runWatch reloads the configuration on file events, source notifications and poll ticks until ctx is done or the file watcher fails.
+func (c *Conflex) runWatch(ctx context.Context, w *watchState) error {
+	var events <-chan fsnotify.Event
+	var watchErrors <-chan error
+	if w.watcher != nil {
+		events, watchErrors = w.watcher.Events, w.watcher.Errors
+	}
+
+	var ticks <-chan time.Time
+	if w.ticker != nil {
+		ticks = w.ticker.C
+	}
+
+	// debounced is non-nil while a debounced reload is pending, and retried while a retry is scheduled.
+	var debounce, retry *time.Timer
+	var debounced, retried <-chan time.Time
+	defer func() {
+		if debounce != nil {
+			debounce.Stop()
+		}
+		if retry != nil {
+			retry.Stop()
+		}
+	}()
+
+	// failures counts consecutive failed reloads, to compute the backoff delay.
+	failures := 0
+	reload := func() {
+		// A failed reload keeps the last good configuration in effect.
+		reloadCtx, span := c.startSpan(ctx, "conflex.Watch.reload")
+		err := c.reload(reloadCtx)
+		endSpan(span, err)
+		c.refreshWatchedFiles(w)
+		if err == nil || ctx.Err() != nil {
+			failures = 0
+			retried = nil
+			return
+		}
+		failures++
+		delay := c.watchBackoff.delay(failures)
+		c.notifyWatchError(err, delay)
+		if delay <= 0 {
+			retried = nil
+			return
+		}
+		if retry == nil {
+			retry = time.NewTimer(delay)
+		} else {
+			retry.Reset(delay)
+		}
+		retried = retry.C
+	}
+
+	// changed reloads right away, or schedules a debounced reload if debouncing is enabled.
+	changed := func() {
+		if c.watchDebounce <= 0 {
+			reload()
+			return
+		}
+		if debounce == nil {
+			debounce = time.NewTimer(c.watchDebounce)
+		} else {
+			debounce.Reset(c.watchDebounce)
+		}
+		debounced = debounce.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticks:
+			reload()
+		case <-w.changes:
+			changed()
+		case err := <-w.errors:
+			// The source no longer sends notifications; polling, if configured, still picks up its changes.
+			c.notifyWatchError(err, 0)
+		case <-debounced:
+			debounced = nil
+			reload()
+		case <-retried:
+			retried = nil
+			reload()
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if !isRelevantEvent(event, w.files) {
+				continue
+			}
+			changed()
+		case err, ok := <-watchErrors:
+			if !ok {
+				return nil
+			}
+			if err := c.restartFileWatcher(ctx, w, err); err != nil {
+				return err
+			}
+			if ctx.Err() != nil {
+				return nil
+			}
+			events, watchErrors = w.watcher.Events, w.watcher.Errors
+			// Changes may have been missed while the watcher was down.
+			reload()
+		}
+	}
+}
+
+// restartFileWatcher replaces a failed file watcher, retrying with the watch backoff.
+// It returns an error if no backoff is configured or the retries are exhausted, and nil once a new
+// watcher is in place or ctx is done.
+func (c *Conflex) restartFileWatcher(ctx context.Context, w *watchState, cause error) error {
+	_ = w.watcher.Close()
+	w.watcher = nil
+
+	var err error = NewConfigError("watch", "watch", fmt.Errorf("file watcher failed: %w", cause))
+	for attempt := 1; ; attempt++ {
+		delay := c.watchBackoff.delay(attempt)
+		c.notifyWatchError(err, delay)
+		if delay <= 0 {
+			return err
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+
+		watcher, watchErr := c.newFileWatcher(w.files)
+		if watchErr == nil {
+			w.watcher = watcher
+			return nil
+		}
+		err = watchErr
+	}
+}
+
+// newFileWatcher creates a file system watcher for the given files.
+// It watches the parent directories rather than the files themselves, so that editors and
+// orchestrators that replace files atomically (rename, symlink swap) keep being observed.
+func (c *Conflex) newFileWatcher(files map[string]struct{}) (*fsnotify.Watcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, NewConfigError("watch", "create-watcher", err)
+	}
+
+	dirs := make(map[string]struct{})
+	for file := range files {
+		dirs[filepath.Dir(file)] = struct{}{}
+	}
+	for dir := range dirs {
+		if err := watcher.Add(dir); err != nil {
+			_ = watcher.Close()
+			return nil, NewConfigFieldError("watch", dir, "add-watch", err)
+		}
+	}
+
+	return watcher, nil
+}
+
+// watchedFiles returns the cleaned absolute paths of all file-backed sources and of the files they include.
+func (c *Conflex) watchedFiles() map[string]struct{} {
+	files := make(map[string]struct{})
+	for _, src := range c.sources {
+		var paths []string
+		if ps, ok := src.(pathsSource); ok {
+			paths = ps.Paths()
+		} else if ps, ok := src.(pathSource); ok {
+			paths = []string{ps.Path()}
+		}
+		for _, p := range paths {
+			if p == "" {
+				continue
+			}
+			path, err := filepath.Abs(p)
+			if err != nil {
+				path = filepath.Clean(p)
+			}
+			files[path] = struct{}{}
+		}
+	}
+	return files
+}
+
+// refreshWatchedFiles updates the watched files after a reload, which may have included different files, and
+// watches the directories of newly included files. Failures to watch a directory are reported to the
+// OnWatchError handlers; the files already watched keep being observed.
+func (c *Conflex) refreshWatchedFiles(w *watchState) {
+	files := c.watchedFiles()
+	if w.watcher != nil {
+		watched := make(map[string]struct{})
+		for file := range w.files {
+			watched[filepath.Dir(file)] = struct{}{}
+		}
+		failed := make(map[string]struct{})
+		for file := range files {
+			dir := filepath.Dir(file)
+			if _, ok := watched[dir]; ok {
+				continue
+			}
+			if _, ok := failed[dir]; ok {
+				continue
+			}
+			if err := w.watcher.Add(dir); err != nil {
+				c.notifyWatchError(NewConfigFieldError("watch", dir, "add-watch", err), 0)
+				failed[dir] = struct{}{}
+				continue
+			}
+			watched[dir] = struct{}{}
+		}
+		// Files in directories that could not be watched are left out, so the next reload tries again.
+		for file := range files {
+			if _, ok := failed[filepath.Dir(file)]; ok {
+				delete(files, file)
+			}
+		}
+	}
+	w.files = files
+}
+
+// isRelevantEvent reports whether a file system event may have changed one of the watched files.
+// Besides direct changes to a watched file, it also accepts changes to Kubernetes' "..data" symlink,
+// which is swapped atomically when a mounted ConfigMap or Secret is updated.
+func isRelevantEvent(event fsnotify.Event, files map[string]struct{}) bool {
+	if event.Op == fsnotify.Chmod {
+		return false
+	}
+	name := filepath.Clean(event.Name)
+	if _, ok := files[name]; ok {
+		return true
+	}
+	return strings.HasPrefix(filepath.Base(name), "..")
+}
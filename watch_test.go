@@ -0,0 +1,514 @@
+// Copyright 2025 Company.info B.V.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/stretchr/testify/suite"
+	"go.companyinfo.dev/conflex/codec"
+	"go.companyinfo.dev/conflex/source"
+)
+
+// mockSyncSource is a source whose result can be changed safely while it is being loaded concurrently.
+type mockSyncSource struct {
+	mu   sync.Mutex
+	conf map[string]any
+	err  error
+}
+
+func (m *mockSyncSource) Load(_ context.Context) (map[string]any, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.conf, m.err
+}
+
+func (m *mockSyncSource) set(conf map[string]any, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.conf = conf
+	m.err = err
+}
+
+// notifyingSource is a mockSyncSource that reports changes through ChangeNotifier.
+type notifyingSource struct {
+	mockSyncSource
+	changes chan struct{}
+	err     error
+	ctx     chan context.Context
+}
+
+func (n *notifyingSource) Changes(ctx context.Context) (<-chan struct{}, error) {
+	if n.err != nil {
+		return nil, n.err
+	}
+	n.ctx <- ctx
+	return n.changes, nil
+}
+
+// failingNotifier is a notifyingSource that reports why it closed its changes channel.
+type failingNotifier struct {
+	notifyingSource
+	changesErr error
+}
+
+func (f *failingNotifier) ChangesErr() error {
+	return f.changesErr
+}
+
+type WatchTestSuite struct {
+	suite.Suite
+	dir  string
+	file string
+}
+
+func TestWatchTestSuite(t *testing.T) {
+	suite.Run(t, new(WatchTestSuite))
+}
+
+func (s *WatchTestSuite) SetupTest() {
+	s.dir = s.T().TempDir()
+	s.file = filepath.Join(s.dir, "config.json")
+	s.writeConfig(`{"foo": "bar"}`)
+}
+
+func (s *WatchTestSuite) writeConfig(content string) {
+	s.Require().NoError(os.WriteFile(s.file, []byte(content), 0o644))
+}
+
+// startWatch starts Watch in the background and returns a function that stops it and returns its result.
+func (s *WatchTestSuite) startWatch(c *Conflex) func() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- c.Watch(ctx)
+	}()
+	// Give the watcher time to register before files are modified.
+	time.Sleep(100 * time.Millisecond)
+	return func() error {
+		cancel()
+		return <-done
+	}
+}
+
+func (s *WatchTestSuite) TestWatch_ReloadsOnChange() {
+	c, err := New(WithFileSource(s.file, codec.TypeJSON))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+	s.Equal("bar", c.GetString("foo"))
+
+	stop := s.startWatch(c)
+
+	s.writeConfig(`{"foo": "baz"}`)
+	s.Eventually(func() bool { return c.GetString("foo") == "baz" }, 2*time.Second, 10*time.Millisecond)
+
+	s.NoError(stop())
+}
+
+func (s *WatchTestSuite) TestWatch_AtomicReplace() {
+	c, err := New(WithFileSource(s.file, codec.TypeJSON))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	stop := s.startWatch(c)
+
+	tmp := filepath.Join(s.dir, "config.json.tmp")
+	s.Require().NoError(os.WriteFile(tmp, []byte(`{"foo": "replaced"}`), 0o644))
+	s.Require().NoError(os.Rename(tmp, s.file))
+	s.Eventually(func() bool { return c.GetString("foo") == "replaced" }, 2*time.Second, 10*time.Millisecond)
+
+	s.NoError(stop())
+}
+
+func (s *WatchTestSuite) TestWatch_IncludedFiles() {
+	write := func(path, content string) {
+		s.Require().NoError(os.MkdirAll(filepath.Dir(path), 0o755))
+		s.Require().NoError(os.WriteFile(path, []byte(content), 0o644))
+	}
+	first := filepath.Join(s.dir, "first", "included.json")
+	second := filepath.Join(s.dir, "second", "included.json")
+	write(first, `{"foo": "first"}`)
+	write(second, `{"foo": "second"}`)
+	s.writeConfig(`{"$include": "first/included.json"}`)
+
+	c, err := New(WithFileSource(s.file, codec.TypeJSON))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+	s.Equal("first", c.GetString("foo"))
+
+	stop := s.startWatch(c)
+
+	write(first, `{"foo": "first changed"}`)
+	s.Eventually(func() bool { return c.GetString("foo") == "first changed" }, 2*time.Second, 10*time.Millisecond)
+
+	// Files included by a reload are watched from then on.
+	s.writeConfig(`{"$include": "second/included.json"}`)
+	s.Eventually(func() bool { return c.GetString("foo") == "second" }, 2*time.Second, 10*time.Millisecond)
+	write(second, `{"foo": "second changed"}`)
+	s.Eventually(func() bool { return c.GetString("foo") == "second changed" }, 2*time.Second, 10*time.Millisecond)
+
+	s.NoError(stop())
+}
+
+func (s *WatchTestSuite) TestWatch_KeepsLastGoodConfigOnInvalidChange() {
+	c, err := New(WithFileSource(s.file, codec.TypeJSON))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	stop := s.startWatch(c)
+
+	s.writeConfig(`{"foo": `)
+	time.Sleep(200 * time.Millisecond)
+	s.Equal("bar", c.GetString("foo"))
+
+	s.writeConfig(`{"foo": "fixed"}`)
+	s.Eventually(func() bool { return c.GetString("foo") == "fixed" }, 2*time.Second, 10*time.Millisecond)
+
+	s.NoError(stop())
+}
+
+func (s *WatchTestSuite) TestWatch_NoWatchableSources() {
+	c, err := New(WithSource(&mockSource{conf: map[string]any{"foo": "bar"}}))
+	s.Require().NoError(err)
+	err = c.Watch(context.Background())
+	s.Error(err)
+	s.Contains(err.Error(), "no watchable sources configured")
+}
+
+func (s *WatchTestSuite) TestWatch_NilContext() {
+	c, err := New(WithFileSource(s.file, codec.TypeJSON))
+	s.Require().NoError(err)
+	s.Error(c.Watch(nil))
+}
+
+func (s *WatchTestSuite) TestWatch_PollInterval() {
+	src := &mockSyncSource{conf: map[string]any{"foo": "bar"}}
+	c, err := New(WithSource(src), WithPollInterval(20*time.Millisecond))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	changes := make(chan map[string]any, 10)
+	c.OnChange(func(_, newValues map[string]any) {
+		changes <- newValues
+	})
+
+	stop := s.startWatch(c)
+
+	// Polling an unchanged source does not publish changes.
+	time.Sleep(100 * time.Millisecond)
+	s.Empty(changes)
+
+	src.set(map[string]any{"foo": "baz"}, nil)
+
+	select {
+	case values := <-changes:
+		s.Equal("baz", values["foo"])
+	case <-time.After(2 * time.Second):
+		s.Fail("expected change notification")
+	}
+	s.Equal("baz", c.GetString("foo"))
+
+	s.NoError(stop())
+}
+
+func (s *WatchTestSuite) TestWatch_ChangeNotifier() {
+	src := &notifyingSource{changes: make(chan struct{}), ctx: make(chan context.Context, 1)}
+	src.set(map[string]any{"foo": "bar"}, nil)
+	c, err := New(WithSource(src))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	changes := make(chan map[string]any, 10)
+	c.OnChange(func(_, newValues map[string]any) {
+		changes <- newValues
+	})
+
+	errs, err := c.StartWatch(context.Background())
+	s.Require().NoError(err)
+	notifierCtx := <-src.ctx
+
+	src.set(map[string]any{"foo": "baz"}, nil)
+	src.changes <- struct{}{}
+
+	select {
+	case values := <-changes:
+		s.Equal("baz", values["foo"])
+	case <-time.After(2 * time.Second):
+		s.Fail("expected change notification")
+	}
+
+	s.Require().NoError(c.StopWatch(context.Background()))
+	s.NoError(<-errs)
+
+	// The notifier is released when the watch stops.
+	select {
+	case <-notifierCtx.Done():
+	case <-time.After(2 * time.Second):
+		s.Fail("expected notifier context to be cancelled")
+	}
+}
+
+func (s *WatchTestSuite) TestWatch_ChangeNotifierStopped() {
+	src := &failingNotifier{
+		notifyingSource: notifyingSource{changes: make(chan struct{}), ctx: make(chan context.Context, 1)},
+		changesErr:      errors.New("watch plan failed"),
+	}
+	src.set(map[string]any{"foo": "bar"}, nil)
+	c, err := New(WithSource(src))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	watchErrors := make(chan error, 1)
+	c.OnWatchError(func(err error, retryIn time.Duration) {
+		s.Zero(retryIn)
+		watchErrors <- err
+	})
+
+	stop := s.startWatch(c)
+	<-src.ctx
+	close(src.changes)
+
+	select {
+	case err := <-watchErrors:
+		s.ErrorContains(err, "watch plan failed")
+	case <-time.After(2 * time.Second):
+		s.Fail("expected watch error")
+	}
+
+	s.NoError(stop())
+}
+
+func (s *WatchTestSuite) TestStartWatch_ChangeNotifierError() {
+	src := &notifyingSource{err: errors.New("informer not synced")}
+	c, err := New(WithSource(src))
+	s.Require().NoError(err)
+
+	_, err = c.StartWatch(context.Background())
+	s.Require().Error(err)
+	s.Contains(err.Error(), "source[0]")
+	s.Contains(err.Error(), "informer not synced")
+
+	// A failed start leaves no watch running.
+	_, err = c.StartWatch(context.Background())
+	s.Error(err)
+	s.NotContains(err.Error(), "already started")
+}
+
+func (s *WatchTestSuite) TestStartWatch_StopWatch() {
+	c, err := New(WithFileSource(s.file, codec.TypeJSON))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	errs, err := c.StartWatch(context.Background())
+	s.Require().NoError(err)
+
+	_, err = c.StartWatch(context.Background())
+	s.Error(err)
+	s.Contains(err.Error(), "watch already started")
+
+	s.writeConfig(`{"foo": "baz"}`)
+	s.Eventually(func() bool { return c.GetString("foo") == "baz" }, 2*time.Second, 10*time.Millisecond)
+
+	s.NoError(c.StopWatch(context.Background()))
+	err, ok := <-errs
+	s.NoError(err)
+	s.False(ok, "error channel should be closed after StopWatch")
+
+	// Stopping again is a no-op, and the watch can be restarted.
+	s.NoError(c.StopWatch(context.Background()))
+	_, err = c.StartWatch(context.Background())
+	s.NoError(err)
+	s.NoError(c.StopWatch(context.Background()))
+}
+
+func (s *WatchTestSuite) TestStartWatch_ContextCancelled() {
+	c, err := New(WithFileSource(s.file, codec.TypeJSON))
+	s.Require().NoError(err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errs, err := c.StartWatch(ctx)
+	s.Require().NoError(err)
+
+	cancel()
+	select {
+	case _, ok := <-errs:
+		s.False(ok)
+	case <-time.After(2 * time.Second):
+		s.Fail("expected error channel to be closed")
+	}
+
+	// The finished watch no longer blocks a new one.
+	s.Eventually(func() bool {
+		_, err := c.StartWatch(context.Background())
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+	s.NoError(c.StopWatch(context.Background()))
+}
+
+func (s *WatchTestSuite) TestWatch_CachedSourceWithoutNotifications() {
+	cached, err := source.WithCache(&mockSource{conf: map[string]any{"foo": "bar"}}, time.Minute)
+	s.Require().NoError(err)
+	c, err := New(WithSource(cached))
+	s.Require().NoError(err)
+
+	err = c.Watch(context.Background())
+	s.ErrorContains(err, "no watchable sources configured")
+}
+
+func (s *WatchTestSuite) TestStartWatch_SetupError() {
+	c, err := New(WithSource(&mockSource{conf: map[string]any{"foo": "bar"}}))
+	s.Require().NoError(err)
+
+	errs, err := c.StartWatch(context.Background())
+	s.Error(err)
+	s.Nil(errs)
+	s.Contains(err.Error(), "no watchable sources configured")
+}
+
+func (s *WatchTestSuite) TestWatch_Debounce() {
+	c, err := New(WithFileSource(s.file, codec.TypeJSON), WithWatchDebounce(300*time.Millisecond))
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	stop := s.startWatch(c)
+
+	for i := range 5 {
+		s.writeConfig(fmt.Sprintf(`{"foo": "burst", "n": %d}`, i))
+		time.Sleep(10 * time.Millisecond)
+	}
+	s.Eventually(func() bool { return c.GetInt("n") == 4 }, 2*time.Second, 10*time.Millisecond)
+
+	// The whole burst is coalesced into a single reload.
+	time.Sleep(400 * time.Millisecond)
+	s.Equal(uint64(2), c.ReloadStatus().Successes)
+
+	s.NoError(stop())
+}
+
+func (s *WatchTestSuite) TestWithWatchDebounce_Invalid() {
+	_, err := New(WithWatchDebounce(-time.Second))
+	s.Error(err)
+	s.Contains(err.Error(), "watch debounce cannot be negative")
+}
+
+func (s *WatchTestSuite) TestBackoffDelay() {
+	b := &Backoff{Initial: 100 * time.Millisecond, Max: time.Second, MaxRetries: 6}
+	s.Equal(100*time.Millisecond, b.delay(1))
+	s.Equal(200*time.Millisecond, b.delay(2))
+	s.Equal(800*time.Millisecond, b.delay(4))
+	s.Equal(time.Second, b.delay(5))
+	s.Equal(time.Second, b.delay(6))
+	s.Zero(b.delay(7))
+
+	unbounded := &Backoff{Initial: time.Second}
+	s.Equal(1024*time.Second, unbounded.delay(11))
+	s.Positive(unbounded.delay(1000))
+
+	var none *Backoff
+	s.Zero(none.delay(1))
+}
+
+func (s *WatchTestSuite) TestWatch_RetriesFailedReload() {
+	remote := &mockSyncSource{conf: map[string]any{"remote": "up"}}
+	c, err := New(
+		WithFileSource(s.file, codec.TypeJSON),
+		WithSource(remote),
+		WithWatchBackoff(Backoff{Initial: 20 * time.Millisecond, Max: 50 * time.Millisecond}),
+	)
+	s.Require().NoError(err)
+	s.Require().NoError(c.Load(context.Background()))
+
+	retries := make(chan time.Duration, 100)
+	c.OnWatchError(func(_ error, retryIn time.Duration) {
+		select {
+		case retries <- retryIn:
+		default:
+		}
+	})
+
+	stop := s.startWatch(c)
+
+	remote.set(nil, errors.New("remote unavailable"))
+	s.writeConfig(`{"foo": "baz"}`)
+
+	select {
+	case retryIn := <-retries:
+		s.Equal(20*time.Millisecond, retryIn)
+	case <-time.After(2 * time.Second):
+		s.Fail("expected watch error")
+	}
+	s.Equal("bar", c.GetString("foo"))
+
+	// The retry picks up the recovered source without another file change.
+	remote.set(map[string]any{"remote": "back"}, nil)
+	s.Eventually(func() bool { return c.GetString("remote") == "back" }, 2*time.Second, 10*time.Millisecond)
+	s.Equal("baz", c.GetString("foo"))
+
+	s.NoError(stop())
+}
+
+func (s *WatchTestSuite) TestRestartFileWatcher() {
+	c, err := New(WithWatchBackoff(Backoff{Initial: 5 * time.Millisecond, MaxRetries: 2}))
+	s.Require().NoError(err)
+
+	var retries []time.Duration
+	c.OnWatchError(func(_ error, retryIn time.Duration) {
+		retries = append(retries, retryIn)
+	})
+
+	newState := func(file string) *watchState {
+		watcher, err := fsnotify.NewWatcher()
+		s.Require().NoError(err)
+		return &watchState{files: map[string]struct{}{file: {}}, watcher: watcher}
+	}
+
+	// The directory of the watched file is gone, so the watcher cannot be replaced.
+	w := newState(filepath.Join(s.dir, "missing", "config.json"))
+	err = c.restartFileWatcher(context.Background(), w, errors.New("overflow"))
+	s.Require().Error(err)
+	var configErr *ConfigError
+	s.ErrorAs(err, &configErr)
+	s.Equal([]time.Duration{5 * time.Millisecond, 10 * time.Millisecond, 0}, retries)
+	s.Nil(w.watcher)
+
+	retries = nil
+	w = newState(s.file)
+	s.Require().NoError(c.restartFileWatcher(context.Background(), w, errors.New("overflow")))
+	s.NotNil(w.watcher)
+	s.Equal([]time.Duration{5 * time.Millisecond}, retries)
+	w.close()
+}
+
+func (s *WatchTestSuite) TestWithWatchBackoff_Invalid() {
+	_, err := New(WithWatchBackoff(Backoff{}))
+	s.ErrorContains(err, "backoff initial delay must be positive")
+	_, err = New(WithWatchBackoff(Backoff{Initial: time.Second, Max: time.Millisecond}))
+	s.ErrorContains(err, "backoff max delay cannot be less than the initial delay")
+	_, err = New(WithWatchBackoff(Backoff{Initial: time.Second, MaxRetries: -1}))
+	s.ErrorContains(err, "backoff max retries cannot be negative")
+}
+
+func (s *WatchTestSuite) TestWithPollInterval_Invalid() {
+	_, err := New(WithPollInterval(0))
+	s.Error(err)
+}